@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	ROLE_VIEWER   = "VIEWER"   // read-only access
+	ROLE_OPERATOR = "OPERATOR" // viewer + managing blueprints, projects and pipelines
+	ROLE_ADMIN    = "ADMIN"    // operator + managing connections, api keys and pushing data
+)
+
+// RoleLevels ranks the roles, a role grants all permissions of the roles ranked below it
+var RoleLevels = map[string]int{
+	ROLE_VIEWER:   1,
+	ROLE_OPERATOR: 2,
+	ROLE_ADMIN:    3,
+}
+
+// ApiKey grants its holder access to the REST api with the permissions of Role
+type ApiKey struct {
+	common.Model
+	Name      string     `json:"name" gorm:"type:varchar(255);uniqueIndex"`
+	Role      string     `json:"role" gorm:"type:varchar(20)"`
+	Prefix    string     `json:"prefix" gorm:"type:varchar(20)"`
+	KeyHash   string     `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+	Creator   string     `json:"creator" gorm:"type:varchar(255)"`
+	ExpiredAt *time.Time `json:"expiredAt"`
+}
+
+func (ApiKey) TableName() string {
+	return "_devlake_api_keys"
+}
+
+type ApiInputApiKey struct {
+	Name      string     `json:"name" validate:"required"`
+	Role      string     `json:"role" validate:"required,oneof=VIEWER OPERATOR ADMIN"`
+	ExpiredAt *time.Time `json:"expiredAt"`
+}
+
+// ApiOutputApiKey carries the plain api key which would be returned only once right after creation
+type ApiOutputApiKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
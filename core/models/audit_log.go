@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// AuditLog records a mutating call to the REST api, CreatedAt is when the call was made
+type AuditLog struct {
+	common.Model
+	Actor      string `json:"actor" gorm:"type:varchar(255);index"`
+	Method     string `json:"method" gorm:"type:varchar(10)"`
+	Route      string `json:"route" gorm:"type:varchar(255)"`
+	Path       string `json:"path" gorm:"type:varchar(500)"`
+	Plugin     string `json:"plugin" gorm:"type:varchar(100);index"`
+	EntityType string `json:"entityType" gorm:"type:varchar(100);index"`
+	EntityId   string `json:"entityId" gorm:"type:varchar(255);index"`
+	StatusCode int    `json:"statusCode"`
+	// Diff is a json object of the changed fields, e.g. {"cronConfig": {"before": "0 0 * * *", "after": "0 1 * * *"}},
+	// with the values of sensitive fields redacted
+	Diff string `json:"diff" gorm:"type:text"`
+}
+
+func (AuditLog) TableName() string {
+	return "_devlake_audit_logs"
+}
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chat
+
+import (
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"time"
+)
+
+const (
+	CHANNEL = "CHANNEL"
+	GROUP   = "GROUP"
+	DIRECT  = "DIRECT"
+)
+
+type ChatChannel struct {
+	domainlayer.DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	// Type is one of CHANNEL, GROUP or DIRECT
+	Type        string `gorm:"type:varchar(100)"`
+	IsPrivate   bool
+	IsArchived  bool
+	CreatorId   string `gorm:"type:varchar(255)"`
+	CreatedDate *time.Time
+}
+
+func (ChatChannel) TableName() string {
+	return "chat_channels"
+}
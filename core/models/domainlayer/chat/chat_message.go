@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chat
+
+import (
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"time"
+)
+
+type ChatMessage struct {
+	domainlayer.DomainEntity
+	ChannelId string `gorm:"index;type:varchar(255)"`
+	// ThreadId is the id of the root message of the thread the message belongs to,
+	// the root message itself included, and empty for messages outside any thread
+	ThreadId    string `gorm:"index;type:varchar(255)"`
+	AuthorId    string `gorm:"index;type:varchar(255)"`
+	Type        string `gorm:"type:varchar(100)"`
+	Content     string
+	ReplyCount  int
+	IsDeleted   bool
+	CreatedDate time.Time
+	UpdatedDate *time.Time
+}
+
+func (ChatMessage) TableName() string {
+	return "chat_messages"
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ProjectDoraMetric holds the DORA key metrics of a project rolled up by WEEK or MONTH
+type ProjectDoraMetric struct {
+	ProjectName                 string    `gorm:"primaryKey;type:varchar(100)"`
+	Period                      string    `gorm:"primaryKey;type:varchar(20)"`
+	PeriodStart                 time.Time `gorm:"primaryKey"`
+	PeriodEnd                   time.Time
+	DeploymentCount             int
+	DeploymentDays              int
+	DeploymentFrequencyLevel    string `gorm:"type:varchar(20)"`
+	ChangeCount                 int
+	MedianChangeLeadTimeMinutes *int64
+	ChangeLeadTimeLevel         string `gorm:"type:varchar(20)"`
+	FailedDeploymentCount       int
+	ChangeFailureRate           *float64
+	ChangeFailureRateLevel      string `gorm:"type:varchar(20)"`
+	IncidentCount               int
+	MedianTimeToRestoreMinutes  *int64
+	TimeToRestoreLevel          string `gorm:"type:varchar(20)"`
+	common.NoPKModel
+}
+
+func (ProjectDoraMetric) TableName() string {
+	return "project_dora_metrics"
+}
+
+const (
+	// period
+	DORA_PERIOD_WEEK  = "WEEK"
+	DORA_PERIOD_MONTH = "MONTH"
+
+	// performance level
+	DORA_LEVEL_ELITE  = "ELITE"
+	DORA_LEVEL_HIGH   = "HIGH"
+	DORA_LEVEL_MEDIUM = "MEDIUM"
+	DORA_LEVEL_LOW    = "LOW"
+)
+
+// DoraPeriodOf returns the [start, end) range in UTC of the week (starting on Monday) or month that t falls in
+func DoraPeriodOf(period string, t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if period == DORA_PERIOD_WEEK {
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	}
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
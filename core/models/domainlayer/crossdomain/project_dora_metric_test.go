@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoraPeriodOf(t *testing.T) {
+	// 2023-05-17 is a Wednesday
+	day := time.Date(2023, 5, 17, 13, 30, 0, 0, time.UTC)
+	start, end := DoraPeriodOf(DORA_PERIOD_WEEK, day)
+	assert.Equal(t, time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2023, 5, 22, 0, 0, 0, 0, time.UTC), end)
+	start, end = DoraPeriodOf(DORA_PERIOD_MONTH, day)
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), end)
+	// sunday belongs to the week started on the previous monday
+	start, _ = DoraPeriodOf(DORA_PERIOD_WEEK, time.Date(2023, 5, 21, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC), start)
+	// the period is computed in UTC
+	start, _ = DoraPeriodOf(DORA_PERIOD_MONTH, time.Date(2023, 6, 1, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*3600)))
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), start)
+}
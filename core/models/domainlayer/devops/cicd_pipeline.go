@@ -31,6 +31,7 @@ type CICDPipeline struct {
 	Type         string `gorm:"type:varchar(100);comment: to indicate this is CI or CD"`
 	DurationSec  uint64
 	Environment  string `gorm:"type:varchar(255)"`
+	Branch       string `gorm:"type:varchar(255)"`
 	CreatedDate  time.Time
 	FinishedDate *time.Time
 	CicdScopeId  string `gorm:"index;type:varchar(255)"`
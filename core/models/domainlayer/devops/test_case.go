@@ -0,0 +1,35 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+import (
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// TestCase is a test of a cicd_scope, its id stays the same across runs so flaky tests and
+// duration trends can be followed
+type TestCase struct {
+	domainlayer.DomainEntity
+	CicdScopeId string `gorm:"index;type:varchar(255)"`
+	ClassName   string `gorm:"type:varchar(255)"`
+	Name        string
+}
+
+func (TestCase) TableName() string {
+	return "test_cases"
+}
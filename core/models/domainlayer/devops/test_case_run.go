@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devops
+
+import (
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"time"
+)
+
+const (
+	TEST_PASSED  = "PASSED"
+	TEST_FAILED  = "FAILED"
+	TEST_ERROR   = "ERROR"
+	TEST_SKIPPED = "SKIPPED"
+)
+
+type TestCaseRun struct {
+	domainlayer.DomainEntity
+	TestCaseId     string `gorm:"index;type:varchar(255)"`
+	TestSuiteId    string `gorm:"index;type:varchar(255)"`
+	CicdScopeId    string `gorm:"index;type:varchar(255)"`
+	CicdPipelineId string `gorm:"index;type:varchar(255)"`
+	CicdTaskId     string `gorm:"index;type:varchar(255)"`
+	// Result is one of TEST_PASSED, TEST_FAILED, TEST_ERROR and TEST_SKIPPED
+	Result       string `gorm:"type:varchar(100)"`
+	DurationSec  float64
+	Message      string
+	FinishedDate *time.Time
+}
+
+func (TestCaseRun) TableName() string {
+	return "test_case_runs"
+}
@@ -19,6 +19,7 @@ package domaininfo
 
 import (
 	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
@@ -28,6 +29,12 @@ import (
 
 func GetDomainTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
+		// chat
+		&chat.ChatChannel{},
+		&chat.ChatMessage{},
+		&chat.ChatParticipant{},
+		&chat.ChatReaction{},
+		&chat.ChatThread{},
 		// code
 		&code.Commit{},
 		&code.CommitFile{},
@@ -58,6 +65,7 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&crossdomain.IssueCommit{},
 		&crossdomain.IssueRepoCommit{},
 		&crossdomain.ProjectMapping{},
+		&crossdomain.ProjectDoraMetric{},
 		&crossdomain.ProjectIssueMetric{},
 		&crossdomain.ProjectPrMetric{},
 		&crossdomain.PullRequestIssue{},
@@ -72,6 +80,9 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&devops.CicdDeploymentCommit{},
 		&devops.CiCDPipelineCommit{},
 		&devops.CicdScope{},
+		&devops.TestCase{},
+		&devops.TestCaseRun{},
+		&devops.TestSuite{},
 		// didgen no table
 		// ticket
 		&ticket.Board{},
@@ -79,6 +90,7 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&ticket.BoardSprint{},
 		&ticket.Issue{},
 		&ticket.IssueChangelogs{},
+		&ticket.IssueSnapshot{},
 		&ticket.IssueComment{},
 		&ticket.IssueLabel{},
 		&ticket.IssueWorklog{},
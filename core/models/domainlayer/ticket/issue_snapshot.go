@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	// ISSUE_SNAPSHOT_CHANGELOG marks snapshots replayed from issue_changelogs
+	ISSUE_SNAPSHOT_CHANGELOG = "CHANGELOG"
+	// ISSUE_SNAPSHOT_COLLECTION marks snapshots recorded from the issue state seen by a collection
+	ISSUE_SNAPSHOT_COLLECTION = "COLLECTION"
+)
+
+// IssueSnapshot is the state of an issue at the end of a day (UTC) seen by a board,
+// an issue belonging to several boards has the snapshots of each board
+type IssueSnapshot struct {
+	BoardId        string    `gorm:"primaryKey;type:varchar(255)"`
+	IssueId        string    `gorm:"primaryKey;type:varchar(255)"`
+	SnapshotDate   time.Time `gorm:"primaryKey;type:date"`
+	Status         string    `gorm:"type:varchar(100)"`
+	OriginalStatus string    `gorm:"type:varchar(100)"`
+	StoryPoint     float64
+	AssigneeId     string `gorm:"type:varchar(255)"`
+	Source         string `gorm:"type:varchar(100)"`
+	common.NoPKModel
+}
+
+func (IssueSnapshot) TableName() string {
+	return "issue_snapshots"
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type notification20230612 struct {
+	ChannelId   uint64 `gorm:"index"`
+	Status      string `gorm:"type:varchar(20);index"`
+	Attempts    int
+	LastError   string
+	NextRetryAt *time.Time
+}
+
+func (notification20230612) TableName() string {
+	return "_devlake_notifications"
+}
+
+type notificationChannel20230612 struct {
+	archived.Model
+	Name                     string `gorm:"type:varchar(255);uniqueIndex"`
+	Type                     string `gorm:"type:varchar(20)"`
+	Enable                   bool
+	Events                   string `gorm:"type:json"`
+	BlueprintIds             string `gorm:"type:json"`
+	SubtaskDurationThreshold int64
+	Endpoint                 string `gorm:"type:varchar(500)"`
+	Secret                   string
+	SmtpHost                 string `gorm:"type:varchar(255)"`
+	SmtpPort                 int
+	SmtpUsername             string `gorm:"type:varchar(255)"`
+	SmtpPassword             string
+	SmtpFrom                 string `gorm:"type:varchar(255)"`
+	SmtpTo                   string `gorm:"type:json"`
+}
+
+func (notificationChannel20230612) TableName() string {
+	return "_devlake_notification_channels"
+}
+
+type addNotificationChannels struct{}
+
+func (*addNotificationChannels) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&notification20230612{},
+		&notificationChannel20230612{},
+	)
+}
+
+func (*addNotificationChannels) Version() uint64 {
+	return 20230612000001
+}
+
+func (*addNotificationChannels) Name() string {
+	return "add _devlake_notification_channels table and delivery status to _devlake_notifications"
+}
@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addSubtaskStatus)(nil)
+
+type subtask20230615 struct {
+	Status   string `gorm:"type:varchar(100)"`
+	Message  string
+	Attempts int
+}
+
+func (subtask20230615) TableName() string {
+	return "_devlake_subtasks"
+}
+
+type task20230615 struct {
+	ResumeFrom string `gorm:"type:varchar(255)"`
+}
+
+func (task20230615) TableName() string {
+	return "_devlake_tasks"
+}
+
+type addSubtaskStatus struct{}
+
+func (*addSubtaskStatus) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&subtask20230615{},
+		&task20230615{},
+	)
+}
+
+func (*addSubtaskStatus) Version() uint64 {
+	return 20230615000001
+}
+
+func (*addSubtaskStatus) Name() string {
+	return "add status and attempts to _devlake_subtasks and resume_from to _devlake_tasks"
+}
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*encryptNotificationChannelEndpoints)(nil)
+
+type encryptNotificationChannelEndpoints struct{}
+
+type srcNotificationChannel20230628 struct {
+	archived.Model
+	Endpoint string
+}
+
+type dstNotificationChannel20230628 struct {
+	archived.Model
+	Endpoint string
+}
+
+func (script *encryptNotificationChannelEndpoints) Up(basicRes context.BasicRes) errors.Error {
+	encryptionSecret := basicRes.GetConfig(plugin.EncodeKeyEnvStr)
+	if encryptionSecret == "" {
+		return errors.BadInput.New("invalid encryptionSecret")
+	}
+	return migrationhelper.TransformColumns(
+		basicRes,
+		script,
+		"_devlake_notification_channels",
+		[]string{"endpoint"},
+		func(src *srcNotificationChannel20230628) (*dstNotificationChannel20230628, errors.Error) {
+			endpoint, err := plugin.Encrypt(encryptionSecret, src.Endpoint)
+			if err != nil {
+				return nil, err
+			}
+			return &dstNotificationChannel20230628{
+				Model:    src.Model,
+				Endpoint: endpoint,
+			}, nil
+		},
+	)
+}
+
+func (*encryptNotificationChannelEndpoints) Version() uint64 {
+	return 20230628000001
+}
+
+func (*encryptNotificationChannelEndpoints) Name() string {
+	return "encrypt _devlake_notification_channels.endpoint"
+}
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type ChatChannel struct {
+	DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Type        string `gorm:"type:varchar(100)"`
+	IsPrivate   bool
+	IsArchived  bool
+	CreatorId   string `gorm:"type:varchar(255)"`
+	CreatedDate *time.Time
+}
+
+func (ChatChannel) TableName() string {
+	return "chat_channels"
+}
+
+type ChatMessage struct {
+	DomainEntity
+	ChannelId   string `gorm:"index;type:varchar(255)"`
+	ThreadId    string `gorm:"index;type:varchar(255)"`
+	AuthorId    string `gorm:"index;type:varchar(255)"`
+	Type        string `gorm:"type:varchar(100)"`
+	Content     string
+	ReplyCount  int
+	IsDeleted   bool
+	CreatedDate time.Time
+	UpdatedDate *time.Time
+}
+
+func (ChatMessage) TableName() string {
+	return "chat_messages"
+}
+
+type ChatThread struct {
+	DomainEntity
+	ChannelId         string `gorm:"index;type:varchar(255)"`
+	AuthorId          string `gorm:"type:varchar(255)"`
+	ReplyCount        int
+	ParticipantCount  int
+	CreatedDate       time.Time
+	FirstResponseDate *time.Time
+	LastReplyDate     *time.Time
+}
+
+func (ChatThread) TableName() string {
+	return "chat_threads"
+}
+
+type ChatReaction struct {
+	MessageId string `gorm:"primaryKey;type:varchar(255)"`
+	Name      string `gorm:"primaryKey;type:varchar(255)"`
+	AccountId string `gorm:"primaryKey;type:varchar(255)"`
+	NoPKModel
+}
+
+func (ChatReaction) TableName() string {
+	return "chat_reactions"
+}
+
+type ChatParticipant struct {
+	ChannelId        string `gorm:"primaryKey;type:varchar(255)"`
+	AccountId        string `gorm:"primaryKey;type:varchar(255)"`
+	MessageCount     int
+	FirstMessageDate time.Time
+	LastMessageDate  time.Time
+	NoPKModel
+}
+
+func (ChatParticipant) TableName() string {
+	return "chat_participants"
+}
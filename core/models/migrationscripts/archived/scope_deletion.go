@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type ScopeDeletion struct {
+	Model
+	Plugin         string `gorm:"type:varchar(100);index"`
+	ConnectionId   uint64 `gorm:"index"`
+	ScopeId        string `gorm:"type:varchar(255);index"`
+	RawDataParams  string `gorm:"type:varchar(255)"`
+	DryRun         bool
+	DeleteDataOnly bool
+	Status         string `gorm:"type:varchar(100)"`
+	Message        string
+	TotalTables    int
+	FinishedTables int
+	TotalRows      int64
+	DeletedRows    int64
+	Tables         string `gorm:"type:json"`
+	BeganAt        *time.Time
+	FinishedAt     *time.Time
+}
+
+func (ScopeDeletion) TableName() string {
+	return "_devlake_scope_deletions"
+}
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+)
+
+type TestSuite struct {
+	DomainEntity
+	Name           string `gorm:"type:varchar(255)"`
+	CicdScopeId    string `gorm:"index;type:varchar(255)"`
+	CicdPipelineId string `gorm:"index;type:varchar(255)"`
+	CicdTaskId     string `gorm:"index;type:varchar(255)"`
+	DurationSec    float64
+	TotalCount     int
+	PassedCount    int
+	FailedCount    int
+	ErrorCount     int
+	SkippedCount   int
+	FinishedDate   *time.Time
+}
+
+func (TestSuite) TableName() string {
+	return "test_suites"
+}
+
+type TestCase struct {
+	DomainEntity
+	CicdScopeId string `gorm:"index;type:varchar(255)"`
+	ClassName   string `gorm:"type:varchar(255)"`
+	Name        string
+}
+
+func (TestCase) TableName() string {
+	return "test_cases"
+}
+
+type TestCaseRun struct {
+	DomainEntity
+	TestCaseId     string `gorm:"index;type:varchar(255)"`
+	TestSuiteId    string `gorm:"index;type:varchar(255)"`
+	CicdScopeId    string `gorm:"index;type:varchar(255)"`
+	CicdPipelineId string `gorm:"index;type:varchar(255)"`
+	CicdTaskId     string `gorm:"index;type:varchar(255)"`
+	Result         string `gorm:"type:varchar(100)"`
+	DurationSec    float64
+	Message        string
+	FinishedDate   *time.Time
+}
+
+func (TestCaseRun) TableName() string {
+	return "test_case_runs"
+}
@@ -86,5 +86,17 @@ func All() []plugin.MigrationScript {
 		new(modifyPrLabelsAndComments),
 		new(renameFinishedCommitsDiffs),
 		new(addUpdatedDateToIssueComments),
+		new(addProjectDoraMetrics),
+		new(addApiKeys),
+		new(addAuditLogs),
+		new(addNotificationChannels),
+		new(addSubtaskStatus),
+		new(addPipelinePriority),
+		new(addScopeDeletions),
+		new(addIssueSnapshots),
+		new(addChatTables),
+		new(addTestReportTables),
+		new(addBranchToCicdPipelines),
+		new(encryptNotificationChannelEndpoints),
 	}
 }
@@ -18,6 +18,8 @@ limitations under the License.
 package models
 
 import (
+	"time"
+
 	"github.com/apache/incubator-devlake/core/models/common"
 )
 
@@ -25,19 +27,96 @@ type NotificationType string
 
 const (
 	NotificationPipelineStatusChanged NotificationType = "PipelineStatusChanged"
+	NotificationPipelineFailed        NotificationType = "PipelineFailed"
+	NotificationTaskFailed            NotificationType = "TaskFailed"
+	// NotificationBlueprintRecovered is sent when a pipeline succeeded while the previous one of the blueprint failed
+	NotificationBlueprintRecovered NotificationType = "BlueprintRecovered"
+	// NotificationSubtaskSlow is sent when a subtask took longer than the threshold of the channel
+	NotificationSubtaskSlow NotificationType = "SubtaskExceededDuration"
+)
+
+const (
+	NOTIFICATION_PENDING  = "PENDING"
+	NOTIFICATION_SENT     = "SENT"
+	NOTIFICATION_RETRYING = "RETRYING"
+	NOTIFICATION_FAILED   = "FAILED"
+)
+
+const (
+	NOTIFICATION_CHANNEL_WEBHOOK = "WEBHOOK"
+	NOTIFICATION_CHANNEL_SLACK   = "SLACK"
+	NOTIFICATION_CHANNEL_FEISHU  = "FEISHU"
+	NOTIFICATION_CHANNEL_SMTP    = "SMTP"
 )
 
-// Notification records notifications sent by lake
+// Notification records notifications sent by lake, ChannelId is 0 for the NOTIFICATION_ENDPOINT
 type Notification struct {
 	common.Model
 	Type         NotificationType
+	ChannelId    uint64 `gorm:"index"`
 	Endpoint     string
 	Nonce        string
 	ResponseCode int
 	Response     string
 	Data         string
+	Status       string `gorm:"type:varchar(20);index"`
+	Attempts     int
+	LastError    string
+	NextRetryAt  *time.Time
 }
 
 func (Notification) TableName() string {
 	return "_devlake_notifications"
 }
+
+// NotificationChannel is where notifications get delivered to, only the subscribed events
+// of the specified blueprints (all blueprints if empty) would be sent
+type NotificationChannel struct {
+	common.Model
+	Name         string             `json:"name" gorm:"type:varchar(255);uniqueIndex" validate:"required"`
+	Type         string             `json:"type" gorm:"type:varchar(20)" validate:"required,oneof=WEBHOOK SLACK FEISHU SMTP"`
+	Enable       bool               `json:"enable"`
+	Events       []NotificationType `json:"events" gorm:"type:json;serializer:json" validate:"required,min=1"`
+	BlueprintIds []uint64           `json:"blueprintIds" gorm:"type:json;serializer:json"`
+	// SubtaskDurationThreshold in seconds, for the SubtaskExceededDuration event
+	SubtaskDurationThreshold int64 `json:"subtaskDurationThreshold"`
+	// Endpoint is the url of WEBHOOK, SLACK and FEISHU channels, encrypted as the webhook urls carry the credentials
+	Endpoint string `json:"endpoint" gorm:"serializer:encdec"`
+	// Secret signs the payload of WEBHOOK and FEISHU channels
+	Secret       string   `json:"secret" gorm:"serializer:encdec"`
+	SmtpHost     string   `json:"smtpHost" gorm:"type:varchar(255)"`
+	SmtpPort     int      `json:"smtpPort"`
+	SmtpUsername string   `json:"smtpUsername" gorm:"type:varchar(255)"`
+	SmtpPassword string   `json:"smtpPassword" gorm:"serializer:encdec"`
+	SmtpFrom     string   `json:"smtpFrom" gorm:"type:varchar(255)"`
+	SmtpTo       []string `json:"smtpTo" gorm:"type:json;serializer:json"`
+}
+
+func (NotificationChannel) TableName() string {
+	return "_devlake_notification_channels"
+}
+
+// Subscribes tells whether the channel should receive the event of the blueprint
+func (c *NotificationChannel) Subscribes(event NotificationType, blueprintId uint64) bool {
+	if !c.Enable {
+		return false
+	}
+	if len(c.BlueprintIds) > 0 {
+		found := false
+		for _, id := range c.BlueprintIds {
+			if id == blueprintId {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, e := range c.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -25,6 +25,14 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 )
 
+const (
+	// PIPELINE_PRIORITY_CRON is the priority of pipelines created by the cron scheduler
+	PIPELINE_PRIORITY_CRON = 0
+	// PIPELINE_PRIORITY_MANUAL is the default priority of pipelines triggered by users,
+	// pending pipelines with higher priorities are dequeued first
+	PIPELINE_PRIORITY_MANUAL = 100
+)
+
 type Pipeline struct {
 	common.Model
 	Name          string          `json:"name" gorm:"index"`
@@ -41,6 +49,7 @@ type Pipeline struct {
 	Stage         int             `json:"stage"`
 	Labels        []string        `json:"labels" gorm:"-"`
 	SkipOnFail    bool            `json:"skipOnFail"`
+	Priority      int             `json:"priority"`
 }
 
 // We use a 2D array because the request body must be an array of a set of tasks
@@ -50,6 +59,7 @@ type NewPipeline struct {
 	Plan        plugin.PipelinePlan `json:"plan" swaggertype:"array,string" example:"please check api /pipelines/<PLUGIN_NAME>/pipeline-plan"`
 	Labels      []string            `json:"labels"`
 	SkipOnFail  bool                `json:"skipOnFail"`
+	Priority    *int                `json:"priority"` // PIPELINE_PRIORITY_MANUAL when omitted, 0 is the cron priority
 	BlueprintId uint64
 }
 
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ScopeDeletion is a background job deleting the data collected for a scope, it reuses the task statuses
+type ScopeDeletion struct {
+	common.Model
+	Plugin         string `json:"plugin" gorm:"type:varchar(100);index"`
+	ConnectionId   uint64 `json:"connectionId" gorm:"index"`
+	ScopeId        string `json:"scopeId" gorm:"type:varchar(255);index"`
+	RawDataParams  string `json:"rawDataParams" gorm:"type:varchar(255)"`
+	DryRun         bool   `json:"dryRun"`
+	DeleteDataOnly bool   `json:"deleteDataOnly"`
+	Status         string `json:"status" gorm:"type:varchar(100)"`
+	Message        string `json:"message"`
+	TotalTables    int    `json:"totalTables"`
+	FinishedTables int    `json:"finishedTables"`
+	TotalRows      int64  `json:"totalRows"`
+	DeletedRows    int64  `json:"deletedRows"`
+	// Tables holds the row counts per table, DerivedFrom is set for the tables cascaded through a plugin.TableLineage
+	Tables     []*ScopeDeletionTable `json:"tables" gorm:"type:json;serializer:json"`
+	BeganAt    *time.Time            `json:"beganAt"`
+	FinishedAt *time.Time            `json:"finishedAt"`
+}
+
+// ScopeDeletionTable is the progress of a ScopeDeletion on a table
+type ScopeDeletionTable struct {
+	Table       string `json:"table"`
+	DerivedFrom string `json:"derivedFrom,omitempty"`
+	Rows        int64  `json:"rows"`
+	Deleted     int64  `json:"deleted"`
+}
+
+func (ScopeDeletion) TableName() string {
+	return "_devlake_scope_deletions"
+}
@@ -34,6 +34,7 @@ const (
 	TASK_FAILED    = "TASK_FAILED"
 	TASK_CANCELLED = "TASK_CANCELLED"
 	TASK_PARTIAL   = "TASK_PARTIAL"
+	TASK_TIMEOUT   = "TASK_TIMEOUT"
 )
 
 var PendingTaskStatus = []string{TASK_CREATED, TASK_RERUN, TASK_RUNNING}
@@ -65,6 +66,8 @@ type Task struct {
 	BeganAt       *time.Time `json:"beganAt"`
 	FinishedAt    *time.Time `json:"finishedAt" gorm:"index"`
 	SpentSeconds  int        `json:"spentSeconds"`
+	// ResumeFrom makes the task skip the subtasks before it, which succeeded in the task it reran
+	ResumeFrom string `json:"resumeFrom"`
 }
 
 type NewTask struct {
@@ -74,6 +77,7 @@ type NewTask struct {
 	PipelineRow int    `json:"-"`
 	PipelineCol int    `json:"-"`
 	IsRerun     bool   `json:"-"`
+	ResumeFrom  string `json:"-"`
 }
 
 type Subtask struct {
@@ -84,6 +88,9 @@ type Subtask struct {
 	BeganAt      *time.Time `json:"beganAt"`
 	FinishedAt   *time.Time `json:"finishedAt" gorm:"index"`
 	SpentSeconds int64      `json:"spentSeconds"`
+	Status       string     `json:"status"`
+	Message      string     `json:"message"`
+	Attempts     int        `json:"attempts"`
 }
 
 func (Task) TableName() string {
@@ -54,12 +54,14 @@ const (
 	AUTH_METHOD_BASIC  = "BasicAuth"
 	AUTH_METHOD_TOKEN  = "AccessToken"
 	AUTH_METHOD_APPKEY = "AppKey"
+	AUTH_METHOD_OAUTH2 = "OAuth2"
 )
 
 var ALL_AUTH = map[string]bool{
 	AUTH_METHOD_BASIC:  true,
 	AUTH_METHOD_TOKEN:  true,
 	AUTH_METHOD_APPKEY: true,
+	AUTH_METHOD_OAUTH2: true,
 }
 
 // MultiAuthenticator represents the API Connection supports multiple authorization methods
@@ -81,3 +83,8 @@ type AccessTokenAuthenticator interface {
 type AppKeyAuthenticator interface {
 	GetAppKeyAuthenticator() ApiAuthenticator
 }
+
+// OAuth2Authenticator represents the OAuth 2.0 Authentication with token exchange and refresh
+type OAuth2Authenticator interface {
+	GetOAuth2Authenticator() ApiAuthenticator
+}
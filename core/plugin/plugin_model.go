@@ -23,3 +23,22 @@ type PluginModel interface {
 	// This method returns all models of the current plugin
 	GetTablesInfo() []dal.Tabler
 }
+
+// TableLineage declares that rows of Table were derived from rows of ParentTable without inheriting their
+// `_raw_data_params`, so they get deleted along with the parent rows when a scope is deleted
+type TableLineage struct {
+	Table string
+	// Column of Table holding the ParentColumn value of the parent row
+	Column       string
+	ParentTable  string
+	ParentColumn string
+	// Where narrows the derived rows down further, e.g. `_raw_data_table = 'gitextractor'`
+	Where string
+	// Exclusive keeps the rows still referenced by parent rows not being deleted, for tables shared by scopes
+	Exclusive bool
+}
+
+// PluginDataLineage is implemented by plugins deriving data from what other plugins produced
+type PluginDataLineage interface {
+	DataLineage() []TableLineage
+}
@@ -0,0 +1,170 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// SecretProvider resolves references like `vault://secret/devlake#token` which are stored in place of secrets,
+// so encdec fields may keep a pointer to the secret instead of the secret itself
+type SecretProvider interface {
+	// Scheme returns the url scheme of the references resolved by the provider, e.g. `vault`
+	Scheme() string
+	// Resolve returns the secret referred by ref
+	Resolve(ctx context.Context, ref *url.URL) (string, errors.Error)
+}
+
+type cachedSecret struct {
+	value     string
+	expiresAt time.Time
+}
+
+var secretProviders = make(map[string]SecretProvider)
+var secretCache = make(map[string]cachedSecret)
+var secretCacheTTL = 5 * time.Minute
+var secretLock sync.RWMutex
+
+// RegisterSecretProvider registers the provider for its scheme, replacing the one registered before
+func RegisterSecretProvider(provider SecretProvider) {
+	secretLock.Lock()
+	defer secretLock.Unlock()
+	secretProviders[provider.Scheme()] = provider
+}
+
+// SetSecretCacheTTL sets how long resolved secrets are cached, 0 disables the cache
+func SetSecretCacheTTL(ttl time.Duration) {
+	secretLock.Lock()
+	defer secretLock.Unlock()
+	secretCacheTTL = ttl
+	secretCache = make(map[string]cachedSecret)
+}
+
+func getSecretProvider(value string) SecretProvider {
+	i := strings.Index(value, "://")
+	if i <= 0 {
+		return nil
+	}
+	secretLock.RLock()
+	defer secretLock.RUnlock()
+	return secretProviders[value[:i]]
+}
+
+// IsSecretReference tells if the value refers to a secret kept by one of the registered providers
+func IsSecretReference(value string) bool {
+	return getSecretProvider(value) != nil
+}
+
+// ResolveSecret returns the secret referred by value, or value itself if it is not a reference
+func ResolveSecret(ctx context.Context, value string) (string, errors.Error) {
+	provider := getSecretProvider(value)
+	if provider == nil {
+		return value, nil
+	}
+	secretLock.RLock()
+	cached, ok := secretCache[value]
+	secretLock.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+	ref, err := url.Parse(value)
+	if err != nil {
+		return "", errors.BadInput.Wrap(err, "invalid secret reference")
+	}
+	secret, resolveErr := provider.Resolve(ctx, ref)
+	if resolveErr != nil {
+		// the reference may carry a version or a path, but never the secret itself
+		return "", errors.Default.Wrap(resolveErr, fmt.Sprintf("failed to resolve secret %s", value))
+	}
+	secretLock.Lock()
+	if secretCacheTTL > 0 {
+		secretCache[value] = cachedSecret{value: secret, expiresAt: time.Now().Add(secretCacheTTL)}
+	}
+	secretLock.Unlock()
+	return secret, nil
+}
+
+// ResolveSecrets replaces the references held by the encdec string fields of obj with the secrets in place,
+// obj must be a pointer to struct and should not be saved afterwards, or the secrets would replace the references
+func ResolveSecrets(ctx context.Context, obj interface{}) errors.Error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.Default.New("secrets can only be resolved for a pointer to struct")
+	}
+	return resolveStructSecrets(ctx, v.Elem())
+}
+
+// CopyWithSecretsResolved returns a copy of obj, a pointer to struct, with the secrets resolved, obj itself keeps
+// the references so it could still be saved or put into pipeline plans
+func CopyWithSecretsResolved[T any](ctx context.Context, obj T) (T, errors.Error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return obj, errors.Default.New("secrets can only be resolved for a pointer to struct")
+	}
+	copied := reflect.New(v.Elem().Type())
+	copied.Elem().Set(v.Elem())
+	err := resolveStructSecrets(ctx, copied.Elem())
+	if err != nil {
+		return obj, err
+	}
+	return copied.Interface().(T), nil
+}
+
+func resolveStructSecrets(ctx context.Context, v reflect.Value) errors.Error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, fieldValue := t.Field(i), v.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			if err := resolveStructSecrets(ctx, fieldValue); err != nil {
+				return err
+			}
+		case reflect.Ptr:
+			if !fieldValue.IsNil() && fieldValue.Elem().Kind() == reflect.Struct {
+				// the pointed struct may be shared with a copy keeping the references
+				pointed := reflect.New(fieldValue.Elem().Type())
+				pointed.Elem().Set(fieldValue.Elem())
+				if err := resolveStructSecrets(ctx, pointed.Elem()); err != nil {
+					return err
+				}
+				fieldValue.Set(pointed)
+			}
+		case reflect.String:
+			if !strings.Contains(field.Tag.Get("gorm"), "serializer:encdec") {
+				continue
+			}
+			secret, err := ResolveSecret(ctx, fieldValue.String())
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("failed to resolve %s", field.Name))
+			}
+			fieldValue.SetString(secret)
+		}
+	}
+	return nil
+}
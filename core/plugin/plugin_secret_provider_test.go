@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSecretProvider struct {
+	resolved int
+}
+
+func (p *testSecretProvider) Scheme() string {
+	return "test"
+}
+
+func (p *testSecretProvider) Resolve(_ context.Context, ref *url.URL) (string, errors.Error) {
+	p.resolved++
+	if ref.Fragment == "" {
+		return "", errors.NotFound.New("no key")
+	}
+	return ref.Host + "-" + ref.Fragment, nil
+}
+
+type testSecretAuth struct {
+	Password string `gorm:"serializer:encdec"`
+}
+
+type testSecretConnection struct {
+	Name string
+	testSecretAuth
+	Token  string `gorm:"serializer:encdec"`
+	Nested *testSecretAuth
+}
+
+func TestResolveSecrets(t *testing.T) {
+	provider := &testSecretProvider{}
+	RegisterSecretProvider(provider)
+	SetSecretCacheTTL(time.Minute)
+
+	conn := &testSecretConnection{
+		Name:           "test://name#not-a-secret-field",
+		testSecretAuth: testSecretAuth{Password: "plain"},
+		Token:          "test://store#token",
+		Nested:         &testSecretAuth{Password: "test://store#password"},
+	}
+	assert.Nil(t, ResolveSecrets(context.Background(), conn))
+	assert.Equal(t, "test://name#not-a-secret-field", conn.Name)
+	assert.Equal(t, "plain", conn.Password)
+	assert.Equal(t, "store-token", conn.Token)
+	assert.Equal(t, "store-password", conn.Nested.Password)
+
+	// cached
+	secret, err := ResolveSecret(context.Background(), "test://store#token")
+	assert.Nil(t, err)
+	assert.Equal(t, "store-token", secret)
+	assert.Equal(t, 2, provider.resolved)
+
+	// unknown schemes are not references
+	secret, err = ResolveSecret(context.Background(), "unknown://store#token")
+	assert.Nil(t, err)
+	assert.Equal(t, "unknown://store#token", secret)
+
+	_, err = ResolveSecret(context.Background(), "test://store")
+	assert.NotNil(t, err)
+}
+
+func TestCopyWithSecretsResolved(t *testing.T) {
+	RegisterSecretProvider(&testSecretProvider{})
+	SetSecretCacheTTL(0)
+
+	conn := &testSecretConnection{
+		Token:  "test://store#token",
+		Nested: &testSecretAuth{Password: "test://store#password"},
+	}
+	resolved, err := CopyWithSecretsResolved(context.Background(), conn)
+	assert.Nil(t, err)
+	assert.Equal(t, "store-token", resolved.Token)
+	assert.Equal(t, "store-password", resolved.Nested.Password)
+	// the original keeps the references
+	assert.Equal(t, "test://store#token", conn.Token)
+	assert.Equal(t, "test://store#password", conn.Nested.Password)
+
+	_, err = CopyWithSecretsResolved(context.Background(), *conn)
+	assert.NotNil(t, err)
+}
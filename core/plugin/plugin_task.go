@@ -21,6 +21,7 @@ import (
 	"context"
 	corecontext "github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
+	"time"
 )
 
 type ProgressType int
@@ -78,6 +79,7 @@ const DOMAIN_TYPE_CODE_REVIEW = "CODEREVIEW"   //nolint
 const DOMAIN_TYPE_CROSS = "CROSS"              //nolint
 const DOMAIN_TYPE_CICD = "CICD"                //nolint
 const DOMAIN_TYPE_CODE_QUALITY = "CODEQUALITY" //nolint
+const DOMAIN_TYPE_CHAT = "CHAT"                //nolint
 
 var DOMAIN_TYPES = []string{
 	DOMAIN_TYPE_CODE,
@@ -86,6 +88,7 @@ var DOMAIN_TYPES = []string{
 	DOMAIN_TYPE_CROSS,
 	DOMAIN_TYPE_CICD,
 	DOMAIN_TYPE_CODE_QUALITY,
+	DOMAIN_TYPE_CHAT,
 } //nolint
 
 // SubTaskMeta Metadata of a subtask
@@ -98,6 +101,25 @@ type SubTaskMeta struct {
 	Description      string
 	DomainTypes      []string
 	Dependencies     []*SubTaskMeta
+	// Retry enables automatic retries on transient errors, i.e. network errors, 5xx responses and DB deadlocks
+	Retry *SubTaskRetry
+}
+
+// SubTaskRetry defines how a failed subtask would be retried
+type SubTaskRetry struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// Backoff is the wait before the first retry, it doubles on each of the following retries
+	Backoff time.Duration
+	// IsRetryable overrides the default transient error detection if not nil
+	IsRetryable func(err errors.Error) bool
+}
+
+// CollectorRetry retries api collectors failed on transient errors, a collector starts over on retries so that
+// hour-long collections don't have to wait for a rerun of the task
+var CollectorRetry = &SubTaskRetry{
+	MaxRetries: 3,
+	Backoff:    time.Minute,
 }
 
 // PluginTask Implement this interface to let framework run tasks for you
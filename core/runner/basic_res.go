@@ -26,6 +26,7 @@ import (
 	contextimpl "github.com/apache/incubator-devlake/impls/context"
 	"github.com/apache/incubator-devlake/impls/dalgorm"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/impls/secretprovider"
 	"gorm.io/gorm"
 	"sync"
 )
@@ -50,6 +51,7 @@ func CreateAppBasicRes() context.BasicRes {
 		panic(err)
 	}
 	dalgorm.Init(cfg.GetString(plugin.EncodeKeyEnvStr))
+	secretprovider.Init(cfg)
 	return CreateBasicRes(cfg, logger, db)
 }
 
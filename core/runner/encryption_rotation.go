@@ -0,0 +1,390 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"gorm.io/gorm/schema"
+)
+
+const defaultRotationBatchSize = 500
+
+// EncryptionRotationOptions controls how RotateEncryptionSecret re-encrypts the `encdec` columns
+type EncryptionRotationOptions struct {
+	OldSecret string
+	NewSecret string
+	// DryRun decrypts every value with the old secret and reports what would change, without writing anything
+	DryRun bool
+	// CheckpointPath is a json file recording the progress, a rotation interrupted halfway resumes from it
+	CheckpointPath string
+	BatchSize      int
+}
+
+// EncryptedTableReport summarizes the rotation of a single table
+type EncryptedTableReport struct {
+	Table   string   `json:"table"`
+	Columns []string `json:"columns"`
+	Rows    int      `json:"rows"`
+	// Rotated counts values re-encrypted (or that would be, in dry-run mode)
+	Rotated int `json:"rotated"`
+	// Skipped counts values already encrypted by the new secret, i.e. done by a previous run
+	Skipped int  `json:"skipped"`
+	Resumed bool `json:"resumed"`
+}
+
+// EncryptionRotationReport summarizes a whole rotation
+type EncryptionRotationReport struct {
+	DryRun bool                    `json:"dryRun"`
+	Tables []*EncryptedTableReport `json:"tables"`
+}
+
+type encryptedTable struct {
+	name        string
+	primaryKeys []string
+	columns     []string
+}
+
+type encryptionRotationCheckpoint struct {
+	SecretFingerprint string   `json:"secretFingerprint"`
+	CompletedTables   []string `json:"completedTables"`
+	CurrentTable      string   `json:"currentTable"`
+	Offset            int      `json:"offset"`
+}
+
+// RotateEncryptionSecret re-encrypts every `serializer:encdec` column of the framework and loaded plugins
+// from OldSecret to NewSecret. Each batch is updated within a transaction and recorded into the checkpoint
+// file afterward, values already encrypted by NewSecret are left untouched, so it is safe to run it again
+// after a failure.
+func RotateEncryptionSecret(basicRes context.BasicRes, options *EncryptionRotationOptions) (*EncryptionRotationReport, errors.Error) {
+	if options.OldSecret == "" || options.NewSecret == "" {
+		return nil, errors.BadInput.New("both the old and the new encryption secrets are required")
+	}
+	if options.OldSecret == options.NewSecret {
+		return nil, errors.BadInput.New("the new encryption secret must differ from the old one")
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultRotationBatchSize
+	}
+	checkpoint, err := loadRotationCheckpoint(options.CheckpointPath, options.NewSecret)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := collectEncryptedTables(basicRes.GetDal())
+	if err != nil {
+		return nil, err
+	}
+	logger := basicRes.GetLogger()
+	report := &EncryptionRotationReport{DryRun: options.DryRun}
+	for _, table := range tables {
+		tableReport := &EncryptedTableReport{Table: table.name, Columns: table.columns}
+		report.Tables = append(report.Tables, tableReport)
+		if !options.DryRun && checkpoint.isCompleted(table.name) {
+			tableReport.Resumed = true
+			logger.Info("skip table %s, it was rotated by a previous run", table.name)
+			continue
+		}
+		offset := 0
+		if !options.DryRun && checkpoint.CurrentTable == table.name {
+			offset = checkpoint.Offset
+			tableReport.Resumed = offset > 0
+		}
+		logger.Info("rotating %s%v from offset %d", table.name, table.columns, offset)
+		err = rotateEncryptedTable(basicRes.GetDal(), table, offset, options, tableReport, func(nextOffset int) errors.Error {
+			checkpoint.CurrentTable = table.name
+			checkpoint.Offset = nextOffset
+			return checkpoint.save(options.CheckpointPath)
+		})
+		if err != nil {
+			return report, errors.Default.Wrap(err, fmt.Sprintf("failed to rotate table %s", table.name))
+		}
+		if !options.DryRun {
+			checkpoint.CompletedTables = append(checkpoint.CompletedTables, table.name)
+			checkpoint.CurrentTable = ""
+			checkpoint.Offset = 0
+			err = checkpoint.save(options.CheckpointPath)
+			if err != nil {
+				return report, err
+			}
+		}
+	}
+	return report, nil
+}
+
+func rotateEncryptedTable(
+	db dal.Dal,
+	table *encryptedTable,
+	offset int,
+	options *EncryptionRotationOptions,
+	tableReport *EncryptedTableReport,
+	onBatchDone func(nextOffset int) errors.Error,
+) errors.Error {
+	selected := append(append([]string{}, table.primaryKeys...), table.columns...)
+	for {
+		cursor, err := db.Cursor(
+			dal.Select(strings.Join(selected, ", ")),
+			dal.From(table.name),
+			dal.Orderby(strings.Join(table.primaryKeys, ", ")),
+			dal.Limit(options.BatchSize),
+			dal.Offset(offset),
+		)
+		if err != nil {
+			return err
+		}
+		var batch [][]interface{}
+		for cursor.Next() {
+			row := make([]interface{}, len(selected))
+			dest := make([]interface{}, len(selected))
+			for i := range table.primaryKeys {
+				dest[i] = &row[i]
+			}
+			for i := range table.columns {
+				dest[len(table.primaryKeys)+i] = &sql.NullString{}
+			}
+			if e := cursor.Scan(dest...); e != nil {
+				cursor.Close()
+				return errors.Convert(e)
+			}
+			for i := range table.columns {
+				row[len(table.primaryKeys)+i] = dest[len(table.primaryKeys)+i]
+			}
+			batch = append(batch, row)
+		}
+		cursor.Close()
+		if len(batch) == 0 {
+			return nil
+		}
+		err = rotateEncryptedBatch(db, table, batch, options, tableReport)
+		if err != nil {
+			return err
+		}
+		offset += len(batch)
+		if !options.DryRun {
+			err = onBatchDone(offset)
+			if err != nil {
+				return err
+			}
+		}
+		if len(batch) < options.BatchSize {
+			return nil
+		}
+	}
+}
+
+func rotateEncryptedBatch(
+	db dal.Dal,
+	table *encryptedTable,
+	batch [][]interface{},
+	options *EncryptionRotationOptions,
+	tableReport *EncryptedTableReport,
+) (err errors.Error) {
+	var tx dal.Transaction
+	if !options.DryRun {
+		tx = db.Begin()
+		defer func() {
+			if r := recover(); r != nil || err != nil {
+				if e := tx.Rollback(); e != nil {
+					err = errors.Default.Wrap(e, "failed to rollback")
+				}
+			}
+		}()
+	}
+	where := make([]string, len(table.primaryKeys))
+	for i, pk := range table.primaryKeys {
+		where[i] = fmt.Sprintf("%s = ?", pk)
+	}
+	for _, row := range batch {
+		tableReport.Rows++
+		var sets []dal.DalSet
+		for i, column := range table.columns {
+			value := row[len(table.primaryKeys)+i].(*sql.NullString)
+			if !value.Valid || value.String == "" {
+				continue
+			}
+			rotated, changed, e := rotateEncryptedValue(options.OldSecret, options.NewSecret, value.String)
+			if e != nil {
+				return errors.Default.Wrap(e, fmt.Sprintf("column %s of row %v", column, row[:len(table.primaryKeys)]))
+			}
+			if !changed {
+				tableReport.Skipped++
+				continue
+			}
+			tableReport.Rotated++
+			sets = append(sets, dal.DalSet{ColumnName: column, Value: rotated})
+		}
+		if tx == nil || len(sets) == 0 {
+			continue
+		}
+		err = tx.UpdateColumns(table.name, sets, dal.Where(strings.Join(where, " AND "), row[:len(table.primaryKeys)]...))
+		if err != nil {
+			return err
+		}
+	}
+	if tx != nil {
+		err = tx.Commit()
+	}
+	return err
+}
+
+// rotateEncryptedValue returns the value re-encrypted by newSecret, changed would be false if it was
+// encrypted by newSecret already
+func rotateEncryptedValue(oldSecret, newSecret, value string) (rotated string, changed bool, err errors.Error) {
+	if _, e := plugin.Decrypt(newSecret, value); e == nil {
+		return value, false, nil
+	}
+	plainText, err := plugin.Decrypt(oldSecret, value)
+	if err != nil {
+		return "", false, errors.BadInput.Wrap(err, "value can not be decrypted by the old encryption secret")
+	}
+	rotated, err = plugin.Encrypt(newSecret, plainText)
+	if err != nil {
+		return "", false, err
+	}
+	return rotated, true, nil
+}
+
+// collectEncryptedTables lists all existing tables with `serializer:encdec` columns, sorted by name
+func collectEncryptedTables(db dal.Dal) ([]*encryptedTable, errors.Error) {
+	tablers := []dal.Tabler{
+		&models.Task{},
+		&models.Pipeline{},
+		&models.Blueprint{},
+		&models.NotificationChannel{},
+	}
+	for _, pluginMeta := range plugin.AllPlugins() {
+		if pluginModel, ok := pluginMeta.(plugin.PluginModel); ok {
+			tablers = append(tablers, pluginModel.GetTablesInfo()...)
+		}
+	}
+	cache := &sync.Map{}
+	found := make(map[string]*encryptedTable)
+	for _, tabler := range tablers {
+		var model interface{} = tabler
+		if dynamic, ok := tabler.(models.DynamicTabler); ok {
+			model = dynamic.Unwrap()
+		}
+		table, err := parseEncryptedTable(model, tabler.TableName(), cache)
+		if err != nil {
+			return nil, err
+		}
+		if table == nil || found[table.name] != nil || !db.HasTable(table.name) {
+			continue
+		}
+		found[table.name] = table
+	}
+	tables := make([]*encryptedTable, 0, len(found))
+	for _, table := range found {
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].name < tables[j].name
+	})
+	return tables, nil
+}
+
+func parseEncryptedTable(model interface{}, tableName string, cache *sync.Map) (*encryptedTable, errors.Error) {
+	s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to parse model of table %s", tableName))
+	}
+	table := &encryptedTable{name: tableName}
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if strings.EqualFold(field.TagSettings["SERIALIZER"], "encdec") {
+			table.columns = append(table.columns, field.DBName)
+		}
+	}
+	if len(table.columns) == 0 {
+		return nil, nil
+	}
+	for _, field := range s.PrimaryFields {
+		table.primaryKeys = append(table.primaryKeys, field.DBName)
+	}
+	if len(table.primaryKeys) == 0 {
+		return nil, errors.Default.New(fmt.Sprintf("table %s has encrypted columns but no primary key", tableName))
+	}
+	return table, nil
+}
+
+func loadRotationCheckpoint(path string, newSecret string) (*encryptionRotationCheckpoint, errors.Error) {
+	checkpoint := &encryptionRotationCheckpoint{SecretFingerprint: secretFingerprint(newSecret)}
+	if path == "" {
+		return checkpoint, nil
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	saved := &encryptionRotationCheckpoint{}
+	if err = json.Unmarshal(content, saved); err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("invalid checkpoint file %s", path))
+	}
+	if saved.SecretFingerprint != checkpoint.SecretFingerprint {
+		return nil, errors.BadInput.New(fmt.Sprintf("checkpoint file %s was created for another encryption secret", path))
+	}
+	return saved, nil
+}
+
+func (c *encryptionRotationCheckpoint) isCompleted(table string) bool {
+	for _, completed := range c.CompletedTables {
+		if completed == table {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *encryptionRotationCheckpoint) save(path string) errors.Error {
+	if path == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Convert(err)
+	}
+	// write to a temporary file then rename, so an interruption never leaves a truncated checkpoint
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, content, 0600); err != nil {
+		return errors.Convert(err)
+	}
+	return errors.Convert(os.Rename(tmp, path))
+}
+
+// secretFingerprint identifies a secret in the checkpoint file without revealing it
+func secretFingerprint(secret string) string {
+	sum := sha256.Sum256([]byte("devlake-encryption-rotation:" + secret))
+	return hex.EncodeToString(sum[:8])
+}
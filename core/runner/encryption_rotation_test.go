@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/impls/dalgorm"
+	"github.com/stretchr/testify/assert"
+)
+
+type rotationTestConnection struct {
+	common.Model
+	Name     string
+	Token    string `gorm:"serializer:encdec"`
+	Password string `gorm:"column:pwd;serializer:encdec"`
+}
+
+func (rotationTestConnection) TableName() string {
+	return "_tool_rotation_test_connections"
+}
+
+func TestRotateEncryptedValue(t *testing.T) {
+	encrypted, err := plugin.Encrypt("old", "token")
+	assert.Nil(t, err)
+
+	rotated, changed, err := rotateEncryptedValue("old", "new", encrypted)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	plainText, err := plugin.Decrypt("new", rotated)
+	assert.Nil(t, err)
+	assert.Equal(t, "token", plainText)
+
+	// values rotated by a previous run are left untouched
+	again, changed, err := rotateEncryptedValue("old", "new", rotated)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, rotated, again)
+
+	unknown, err := plugin.Encrypt("unknown", "token")
+	assert.Nil(t, err)
+	_, _, err = rotateEncryptedValue("old", "new", unknown)
+	assert.NotNil(t, err)
+}
+
+func TestParseEncryptedTable(t *testing.T) {
+	dalgorm.Init("secret")
+	table, err := parseEncryptedTable(&rotationTestConnection{}, "_tool_rotation_test_connections", &sync.Map{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id"}, table.primaryKeys)
+	assert.Equal(t, []string{"token", "pwd"}, table.columns)
+
+	table, err = parseEncryptedTable(&common.Model{}, "plain", &sync.Map{})
+	assert.Nil(t, err)
+	assert.Nil(t, table)
+}
+
+func TestRotationCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	checkpoint, err := loadRotationCheckpoint(path, "new")
+	assert.Nil(t, err)
+	assert.False(t, checkpoint.isCompleted("_devlake_tasks"))
+
+	checkpoint.CompletedTables = append(checkpoint.CompletedTables, "_devlake_tasks")
+	checkpoint.CurrentTable = "_devlake_pipelines"
+	checkpoint.Offset = 1000
+	assert.Nil(t, checkpoint.save(path))
+
+	resumed, err := loadRotationCheckpoint(path, "new")
+	assert.Nil(t, err)
+	assert.True(t, resumed.isCompleted("_devlake_tasks"))
+	assert.Equal(t, "_devlake_pipelines", resumed.CurrentTable)
+	assert.Equal(t, 1000, resumed.Offset)
+
+	// a checkpoint must not be resumed with another secret
+	_, err = loadRotationCheckpoint(path, "another")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	goerror "errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const (
+	defaultRetryBackoff = 10 * time.Second
+	// apiClientErrorPrefix is the message prefix of errors made by ApiAsyncClient for failed responses
+	apiClientErrorPrefix = "Http DoAsync error"
+)
+
+// transientMessages are substrings of errors that are likely to go away on retry, mostly DB locking errors
+var transientMessages = []string{
+	"deadlock",
+	"lock wait timeout exceeded",
+	"connection reset by peer",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"tls handshake timeout",
+}
+
+// getRetryBackoff tells whether the failed attempt should be retried and how long to wait before it
+func getRetryBackoff(ctx gocontext.Context, retry *plugin.SubTaskRetry, attempts int, err errors.Error) (time.Duration, bool) {
+	if err == nil || retry == nil || attempts > retry.MaxRetries || ctx.Err() != nil {
+		return 0, false
+	}
+	isRetryable := IsTransientError
+	if retry.IsRetryable != nil {
+		isRetryable = retry.IsRetryable
+	}
+	if !isRetryable(err) {
+		return 0, false
+	}
+	backoff := retry.Backoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	return backoff * time.Duration(1<<(attempts-1)), true
+}
+
+// IsTransientError tells whether the error was caused by network issues, 5xx/429 responses or DB deadlocks
+func IsTransientError(err errors.Error) bool {
+	if err == nil || errors.Is(err, errSubtaskTimeout) ||
+		errors.Is(err, gocontext.Canceled) || errors.Is(err, gocontext.DeadlineExceeded) {
+		return false
+	}
+	// errors returned by the ApiClient carry the response status as their type, note that a 500 response shares
+	// the type with errors.Internal, which is used for server side bugs as well
+	for e := error(err); e != nil; e = goerror.Unwrap(e) {
+		lakeErr := errors.AsLakeErrorType(e)
+		if lakeErr == nil {
+			continue
+		}
+		t := lakeErr.GetType()
+		code := t.GetHttpCode()
+		if t == errors.Default || t != errors.HttpStatus(code) {
+			continue
+		}
+		if code == http.StatusTooManyRequests || (code > http.StatusInternalServerError) ||
+			(t == errors.Internal && strings.HasPrefix(lakeErr.Messages().Get(), apiClientErrorPrefix)) {
+			return true
+		}
+	}
+	var netErr net.Error
+	if goerror.As(err, &netErr) ||
+		goerror.Is(err, io.ErrUnexpectedEOF) ||
+		goerror.Is(err, syscall.ECONNRESET) ||
+		goerror.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, transient := range transientMessages {
+		if strings.Contains(message, transient) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, IsTransientError(errors.Default.Wrap(errors.HttpStatus(502).New("bad gateway"), "Retry exceeded 3 times")))
+	assert.True(t, IsTransientError(errors.HttpStatus(429).New("too many requests")))
+	assert.False(t, IsTransientError(errors.HttpStatus(404).New("not found")))
+	assert.False(t, IsTransientError(errors.Default.New("invalid options")))
+	assert.False(t, IsTransientError(errors.Internal.New("bug")))
+	assert.True(t, IsTransientError(errors.HttpStatus(500).New("Http DoAsync error calling [GET issues]. Response: oops")))
+	assert.True(t, IsTransientError(errors.Convert(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED})))
+	assert.True(t, IsTransientError(errors.Default.New("Error 1213: Deadlock found when trying to get lock")))
+	assert.False(t, IsTransientError(errors.Convert(gocontext.Canceled)))
+	assert.False(t, IsTransientError(errors.Default.Wrap(errSubtaskTimeout, "subtask timed out")))
+}
+
+func TestGetRetryBackoff(t *testing.T) {
+	ctx := gocontext.Background()
+	transient := errors.HttpStatus(503).New("unavailable")
+	retry := &plugin.SubTaskRetry{MaxRetries: 2, Backoff: time.Second}
+
+	_, ok := getRetryBackoff(ctx, nil, 1, transient)
+	assert.False(t, ok)
+	backoff, ok := getRetryBackoff(ctx, retry, 1, transient)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, backoff)
+	backoff, ok = getRetryBackoff(ctx, retry, 2, transient)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, backoff)
+	_, ok = getRetryBackoff(ctx, retry, 3, transient)
+	assert.False(t, ok)
+	_, ok = getRetryBackoff(ctx, retry, 1, errors.Default.New("invalid options"))
+	assert.False(t, ok)
+
+	retry.IsRetryable = func(err errors.Error) bool { return true }
+	_, ok = getRetryBackoff(ctx, retry, 1, errors.Default.New("invalid options"))
+	assert.True(t, ok)
+
+	cancelled, cancel := gocontext.WithCancel(ctx)
+	cancel()
+	_, ok = getRetryBackoff(cancelled, retry, 1, transient)
+	assert.False(t, ok)
+}
@@ -57,6 +57,9 @@ func RunTask(
 	if err != nil {
 		return err
 	}
+	timeout := GetTaskTimeout(basicRes, task.Plugin)
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
 	beganAt := time.Now()
 	// make sure task status always correct even if it panicked
 	defer func() {
@@ -74,6 +77,13 @@ func RunTask(
 		finishedAt := time.Now()
 		spentSeconds := finishedAt.Unix() - beganAt.Unix()
 		if err != nil {
+			status := models.TASK_FAILED
+			if IsTimeout(ctx, err) {
+				status = models.TASK_TIMEOUT
+				if ctx.Err() == gocontext.DeadlineExceeded {
+					err = errors.Default.Wrap(err, fmt.Sprintf("task timed out after %s", time.Duration(spentSeconds)*time.Second))
+				}
+			}
 			lakeErr := errors.AsLakeErrorType(err)
 			subTaskName := "unknown"
 			if lakeErr = lakeErr.As(errors.SubtaskErr); lakeErr != nil {
@@ -84,7 +94,7 @@ func RunTask(
 				lakeErr = errors.Convert(err)
 			}
 			dbe := db.UpdateColumns(task, []dal.DalSet{
-				{ColumnName: "status", Value: models.TASK_FAILED},
+				{ColumnName: "status", Value: status},
 				{ColumnName: "message", Value: lakeErr.Error()},
 				{ColumnName: "error_name", Value: lakeErr.Messages().Format()},
 				{ColumnName: "finished_at", Value: finishedAt},
@@ -120,8 +130,13 @@ func RunTask(
 		}
 	}()
 
+	// the pipeline might be timed out before the task started
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return errors.Convert(ctxErr)
+	}
+
 	// start execution
-	logger.Info("start executing task: %d", task.ID)
+	logger.Info("start executing task: %d, timeout: %s", task.ID, timeout)
 	dbe := db.UpdateColumns(task, []dal.DalSet{
 		{ColumnName: "status", Value: models.TASK_RUNNING},
 		{ColumnName: "message", Value: ""},
@@ -223,6 +238,20 @@ func RunPluginSubTasks(
 		}
 	}
 
+	// skip subtasks succeeded in the previous run when resuming
+	if task.ResumeFrom != "" {
+		if _, ok := subtasksFlag[task.ResumeFrom]; !ok {
+			return errors.Default.New(fmt.Sprintf("subtask %s to resume from does not exist", task.ResumeFrom))
+		}
+		for _, subtaskMeta := range subtaskMetas {
+			if subtaskMeta.Name == task.ResumeFrom {
+				break
+			}
+			subtasksFlag[subtaskMeta.Name] = false
+		}
+		logger.Info("resume from subtask %s", task.ResumeFrom)
+	}
+
 	// calculate total step(number of task to run)
 	steps := 0
 	for _, enabled := range subtasksFlag {
@@ -248,6 +277,7 @@ func RunPluginSubTasks(
 	// execute subtasks in order
 	taskCtx.SetProgress(0, steps)
 	subtaskNumber := 0
+	subtaskTimeout := GetSubtaskTimeout(basicRes)
 	for _, subtaskMeta := range subtaskMetas {
 		subtaskCtx, err := taskCtx.SubTaskContext(subtaskMeta.Name)
 		if err != nil {
@@ -259,6 +289,10 @@ func RunPluginSubTasks(
 			continue
 		}
 
+		// stop right away once the task or pipeline was cancelled or timed out
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.SubtaskErr.Wrap(ctxErr, fmt.Sprintf("subtask %s was not started", subtaskMeta.Name), errors.WithData(&subtaskMeta))
+		}
 		// run subtask
 		logger.Info("executing subtask %s", subtaskMeta.Name)
 		subtaskNumber++
@@ -269,7 +303,7 @@ func RunPluginSubTasks(
 				SubTaskNumber: subtaskNumber,
 			}
 		}
-		err = runSubtask(basicRes, subtaskCtx, task.ID, subtaskNumber, subtaskMeta.EntryPoint)
+		err = runSubtask(basicRes, subtaskCtx, task.ID, subtaskNumber, &subtaskMeta, subtaskTimeout)
 		if err != nil {
 			err = errors.SubtaskErr.Wrap(err, fmt.Sprintf("subtask %s ended unexpectedly", subtaskMeta.Name), errors.WithData(&subtaskMeta))
 			logger.Error(err, "")
@@ -313,8 +347,9 @@ func runSubtask(
 	ctx plugin.SubTaskContext,
 	parentID uint64,
 	subtaskNumber int,
-	entryPoint plugin.SubTaskEntryPoint,
-) errors.Error {
+	subtaskMeta *plugin.SubTaskMeta,
+	timeout time.Duration,
+) (err errors.Error) {
 	beginAt := time.Now()
 	subtask := &models.Subtask{
 		Name:    ctx.GetName(),
@@ -326,9 +361,30 @@ func runSubtask(
 		finishedAt := time.Now()
 		subtask.FinishedAt = &finishedAt
 		subtask.SpentSeconds = finishedAt.Unix() - beginAt.Unix()
+		subtask.Status = models.TASK_COMPLETED
+		if err != nil {
+			subtask.Status = models.TASK_FAILED
+			if IsTimeout(ctx.GetContext(), err) {
+				subtask.Status = models.TASK_TIMEOUT
+			}
+			subtask.Message = err.Error()
+		}
 		recordSubtask(basicRes, subtask)
 	}()
-	return entryPoint(ctx)
+	for {
+		subtask.Attempts++
+		err = runSubtaskWithTimeout(ctx, subtaskMeta.EntryPoint, timeout)
+		backoff, retryable := getRetryBackoff(ctx.GetContext(), subtaskMeta.Retry, subtask.Attempts, err)
+		if !retryable {
+			return err
+		}
+		ctx.GetLogger().Warn(err, "subtask %s failed on attempt #%d, retrying in %s", subtaskMeta.Name, subtask.Attempts, backoff)
+		select {
+		case <-ctx.GetContext().Done():
+			return errors.Convert(ctx.GetContext().Err())
+		case <-time.After(backoff):
+		}
+	}
 }
 
 func recordSubtask(basicRes context.BasicRes, subtask *models.Subtask) {
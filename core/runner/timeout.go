@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// errSubtaskTimeout marks the error of a subtask exceeding SUBTASK_TIMEOUT
+var errSubtaskTimeout = errors.Timeout.New("subtask timeout")
+
+// GetPipelineTimeout returns the PIPELINE_TIMEOUT, 0 means no timeout
+func GetPipelineTimeout(basicRes context.BasicRes) time.Duration {
+	return getTimeout(basicRes, "PIPELINE_TIMEOUT")
+}
+
+// GetTaskTimeout returns the timeout of tasks of the plugin, TASK_TIMEOUT_<PLUGIN> overrides TASK_TIMEOUT
+func GetTaskTimeout(basicRes context.BasicRes, pluginName string) time.Duration {
+	if timeout := getTimeout(basicRes, "TASK_TIMEOUT_"+strings.ToUpper(pluginName)); timeout > 0 {
+		return timeout
+	}
+	return getTimeout(basicRes, "TASK_TIMEOUT")
+}
+
+// GetSubtaskTimeout returns the SUBTASK_TIMEOUT, 0 means no timeout
+func GetSubtaskTimeout(basicRes context.BasicRes) time.Duration {
+	return getTimeout(basicRes, "SUBTASK_TIMEOUT")
+}
+
+func getTimeout(basicRes context.BasicRes, name string) time.Duration {
+	value := strings.TrimSpace(basicRes.GetConfig(name))
+	if value == "" {
+		return 0
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		basicRes.GetLogger().Warn(err, "invalid %s: %s, it should be a positive duration like 2h", name, value)
+		return 0
+	}
+	return timeout
+}
+
+// withTimeout returns a child context with the timeout, the context would be cancelable if timeout was 0
+func withTimeout(ctx gocontext.Context, timeout time.Duration) (gocontext.Context, gocontext.CancelFunc) {
+	if timeout > 0 {
+		return gocontext.WithTimeout(ctx, timeout)
+	}
+	return gocontext.WithCancel(ctx)
+}
+
+// IsTimeout tells whether the task ended up timed out with the given error
+func IsTimeout(ctx gocontext.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	return ctx.Err() == gocontext.DeadlineExceeded || errors.Is(err, errSubtaskTimeout)
+}
+
+// timeoutSubTaskContext replaces the context of the subtask with one carrying the SUBTASK_TIMEOUT deadline
+type timeoutSubTaskContext struct {
+	plugin.SubTaskContext
+	ctx gocontext.Context
+}
+
+func (c *timeoutSubTaskContext) GetContext() gocontext.Context {
+	return c.ctx
+}
+
+func runSubtaskWithTimeout(
+	subtaskCtx plugin.SubTaskContext,
+	entryPoint plugin.SubTaskEntryPoint,
+	timeout time.Duration,
+) errors.Error {
+	if timeout <= 0 {
+		return entryPoint(subtaskCtx)
+	}
+	ctx, cancel := gocontext.WithTimeout(subtaskCtx.GetContext(), timeout)
+	defer cancel()
+	err := entryPoint(&timeoutSubTaskContext{subtaskCtx, ctx})
+	// the deadline of the task or pipeline is not the subtask's business
+	if err != nil && ctx.Err() == gocontext.DeadlineExceeded && subtaskCtx.GetContext().Err() == nil {
+		return errors.Default.Wrap(
+			errSubtaskTimeout,
+			fmt.Sprintf("subtask %s timed out after %s: %s", subtaskCtx.GetName(), timeout, err.Error()),
+		)
+	}
+	return err
+}
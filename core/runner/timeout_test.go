@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	contextimpl "github.com/apache/incubator-devlake/impls/context"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTaskTimeout(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("TASK_TIMEOUT", "2h")
+	cfg.Set("TASK_TIMEOUT_GITHUB", "30m")
+	cfg.Set("SUBTASK_TIMEOUT", "bad")
+	basicRes := contextimpl.NewDefaultBasicRes(cfg, logruslog.Global, nil)
+	assert.Equal(t, 30*time.Minute, GetTaskTimeout(basicRes, "github"))
+	assert.Equal(t, 2*time.Hour, GetTaskTimeout(basicRes, "gitlab"))
+	assert.Equal(t, time.Duration(0), GetSubtaskTimeout(basicRes))
+	assert.Equal(t, time.Duration(0), GetPipelineTimeout(basicRes))
+}
+
+func TestIsTimeout(t *testing.T) {
+	ctx, cancel := withTimeout(gocontext.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	assert.True(t, IsTimeout(ctx, errors.Convert(ctx.Err())))
+	assert.False(t, IsTimeout(ctx, nil))
+
+	ctx, cancel = withTimeout(gocontext.Background(), 0)
+	cancel()
+	assert.False(t, IsTimeout(ctx, errors.Convert(ctx.Err())))
+	assert.True(t, IsTimeout(ctx, errors.Default.Wrap(errSubtaskTimeout, "subtask collectIssues timed out")))
+}
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import "sort"
+
+// Average returns the mean of values, or nil if there is none
+func Average(values []int64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	var sum int64
+	for _, v := range values {
+		sum += v
+	}
+	avg := float64(sum) / float64(len(values))
+	return &avg
+}
+
+// Median returns the median of values, the mean of the two middle ones rounded down for an even count,
+// or nil if there is none
+func Median(values []int64) *int64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := make([]int64, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	result := sorted[mid]
+	if len(sorted)%2 == 0 {
+		result = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return &result
+}
@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAverageAndMedian(t *testing.T) {
+	assert.Nil(t, Average(nil))
+	assert.Nil(t, Median(nil))
+	assert.Equal(t, 2.5, *Average([]int64{1, 2, 3, 4}))
+	assert.Equal(t, int64(2), *Median([]int64{4, 1, 3, 2}))
+	assert.Equal(t, int64(3), *Median([]int64{5, 3, 1}))
+	// the input is left unsorted
+	values := []int64{4, 1, 3, 1}
+	assert.Equal(t, int64(2), *Median(values))
+	assert.Equal(t, []int64{4, 1, 3, 1}, values)
+}
@@ -220,6 +220,53 @@ func (t *DataFlowTester) Subtask(subtaskMeta plugin.SubTaskMeta, taskData interf
 	}
 }
 
+// UseCassette makes api clients created afterward go through the cassette, requests are served by the exchanges
+// recorded in it, unless API_CASSETTE_MODE is set to record, in which case they are sent to the real server and recorded
+func (t *DataFlowTester) UseCassette(cassettePath string) {
+	mode := t.Cfg.GetString(`API_CASSETTE_MODE`)
+	if mode == `` {
+		mode = api.CASSETTE_MODE_REPLAY
+	}
+	// load it again so every exchange could be replayed once more
+	_, err := api.LoadCassette(cassettePath, mode)
+	if err != nil {
+		panic(err)
+	}
+	t.Cfg.Set(`API_CASSETTE`, cassettePath)
+	t.Cfg.Set(`API_CASSETTE_MODE`, mode)
+}
+
+// SubtaskWithCassette prepares the task data from the options by the plugin and executes the specified subtask,
+// with all api clients going through the cassette, it is for testing collectors offline:
+//
+//	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_connections.csv", &models.GithubConnection{})
+//	dataflowTester.SubtaskWithCassette(tasks.CollectApiIssuesMeta, "./cassettes/issues.json", map[string]interface{}{
+//		"connectionId": 1,
+//		"name": "apache/incubator-devlake",
+//	})
+//	dataflowTester.VerifyTableWithOptions(...)
+func (t *DataFlowTester) SubtaskWithCassette(subtaskMeta plugin.SubTaskMeta, cassettePath string, options map[string]interface{}) {
+	t.UseCassette(cassettePath)
+	pluginTask, ok := t.Plugin.(plugin.PluginTask)
+	if !ok {
+		panic(errors.Default.New(fmt.Sprintf("plugin %s doesn't implement PluginTask", t.Name)))
+	}
+	taskCtx := contextimpl.NewDefaultTaskContext(context.Background(), runner.CreateBasicRes(t.Cfg, t.Log, t.Db), t.Name, nil, nil)
+	taskData, err := pluginTask.PrepareTaskData(taskCtx, options)
+	if err != nil {
+		panic(err)
+	}
+	if closeablePlugin, ok := t.Plugin.(plugin.CloseablePluginTask); ok {
+		defer func() {
+			taskCtx.SetData(taskData)
+			if err := closeablePlugin.Close(taskCtx); err != nil {
+				panic(err)
+			}
+		}()
+	}
+	t.Subtask(subtaskMeta, taskData)
+}
+
 // SubtaskContext creates a subtask context
 func (t *DataFlowTester) SubtaskContext(taskData interface{}) plugin.SubTaskContext {
 	return contextimpl.NewStandaloneSubTaskContext(context.Background(), runner.CreateBasicRes(t.Cfg, t.Log, t.Db), t.Name, taskData)
@@ -45,6 +45,7 @@ type ApiAsyncClient struct {
 	maxRetry     int
 	numOfWorkers int
 	logger       log.Logger
+	rateLimiter  *ConnectionRateLimiter
 }
 
 const defaultTimeout = 120 * time.Second
@@ -123,6 +124,16 @@ func CreateAsyncApiClient(
 		return nil, errors.Default.Wrap(err, "failed to create scheduler")
 	}
 
+	// share the budget with other tasks running on the same connection
+	var connectionRateLimiter *ConnectionRateLimiter
+	if connectionId := apiClient.GetConnectionId(); connectionId != 0 {
+		connectionRateLimiter, err = GetConnectionRateLimiter(taskCtx.GetName(), connectionId, requests, duration)
+		if err != nil {
+			return nil, err
+		}
+		scheduler.SetRateLimiter(connectionRateLimiter)
+	}
+
 	// finally, wrap around api client with async sematic
 	return &ApiAsyncClient{
 		apiClient,
@@ -130,6 +141,7 @@ func CreateAsyncApiClient(
 		retry,
 		numOfWorkers,
 		logger,
+		connectionRateLimiter,
 	}, nil
 }
 
@@ -180,6 +192,11 @@ func (apiClient *ApiAsyncClient) DoAsync(
 			}
 		}
 
+		// let the connection rate limiter know how the server feels about our pace
+		if err == nil && apiClient.rateLimiter != nil {
+			apiClient.rateLimiter.Observe(res, respBody)
+		}
+
 		// check
 		needRetry := false
 		errMessage := "unknown"
@@ -57,6 +57,7 @@ type ApiClient struct {
 	afterResponse common.ApiClientAfterResponse
 	ctx           gocontext.Context
 	logger        log.Logger
+	connectionId  uint64
 }
 
 // NewApiClientFromConnection creates ApiClient based on given connection.
@@ -65,30 +66,66 @@ func NewApiClientFromConnection(
 	br context.BasicRes,
 	connection aha.ApiConnection,
 ) (*ApiClient, errors.Error) {
-	if reflect.ValueOf(connection).Kind() != reflect.Ptr {
-		return nil, errors.Default.New("connection is not a pointer")
+	apiClient, _, err := NewApiClientWithResolvedConnection(ctx, br, connection)
+	return apiClient, err
+}
+
+// NewApiClientWithResolvedConnection creates ApiClient based on given connection, and returns the copy of the connection
+// the ApiClient authenticates with, i.e. with the secrets kept by external providers resolved and the access tokens fetched.
+// The connection itself is left untouched, while the copy must never be saved or put into pipeline plans.
+func NewApiClientWithResolvedConnection[C aha.ApiConnection](
+	ctx gocontext.Context,
+	br context.BasicRes,
+	original C,
+) (*ApiClient, C, errors.Error) {
+	if reflect.ValueOf(original).Kind() != reflect.Ptr {
+		return nil, original, errors.Default.New("connection is not a pointer")
+	}
+	// secrets kept by external providers are resolved when they are about to be used
+	connection, err := aha.CopyWithSecretsResolved(ctx, original)
+	if err != nil {
+		return nil, original, err
 	}
 	apiClient, err := NewApiClient(ctx, connection.GetEndpoint(), nil, 0, connection.GetProxy(), br)
 	if err != nil {
-		return nil, err
+		return nil, original, err
+	}
+	// async clients of the same connection share a rate limiter, saved connections only
+	if value := reflect.ValueOf(connection).Elem(); value.Kind() == reflect.Struct {
+		if id := value.FieldByName("ID"); id.IsValid() && id.Kind() == reflect.Uint64 {
+			apiClient.connectionId = id.Uint()
+		}
+	}
+
+	// OAuth2 tokens are exchanged through the same proxy and tls settings
+	if oauth2Conn, ok := any(connection).(interface{ SetOAuth2HttpClient(*http.Client) }); ok {
+		oauth2Conn.SetOAuth2HttpClient(apiClient.client)
+	}
+	// refresh tokens rotated by the server replace the ones stored, or the next task would use the invalidated one
+	if oauth2Conn, ok := any(connection).(interface{ OnRefreshTokenRotated(func(string)) }); ok {
+		oauth2Conn.OnRefreshTokenRotated(func(refreshToken string) {
+			if err := persistRotatedRefreshToken(br, original, refreshToken); err != nil {
+				br.GetLogger().Error(err, "failed to persist the rotated refresh token")
+			}
+		})
 	}
 
 	// if connection needs to prepare the ApiClient, i.e. fetch token for future requests
-	if prepareApiClient, ok := connection.(aha.PrepareApiClient); ok {
+	if prepareApiClient, ok := any(connection).(aha.PrepareApiClient); ok {
 		err = prepareApiClient.PrepareApiClient(apiClient)
 		if err != nil {
-			return nil, err
+			return nil, original, err
 		}
 	}
 
 	// if connection requires authorization
-	if authenticator, ok := connection.(aha.ApiAuthenticator); ok {
+	if authenticator, ok := any(connection).(aha.ApiAuthenticator); ok {
 		apiClient.SetBeforeFunction(func(req *http.Request) errors.Error {
 			return authenticator.SetupAuthentication(req)
 		})
 	}
 
-	return apiClient, nil
+	return apiClient, connection, nil
 }
 
 // NewApiClient creates a new synchronize ApiClient
@@ -118,7 +155,14 @@ func NewApiClient(
 		apiClient.client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
 
-	if proxy != "" {
+	// requests are served by the cassette when replaying, there is nothing to connect to
+	cassette, err := GetCassette(br)
+	if err != nil {
+		return nil, err
+	}
+	replaying := cassette != nil && cassette.Replaying()
+
+	if proxy != "" && !replaying {
 		err = apiClient.SetProxy(proxy)
 		if err != nil {
 			return nil, errors.Convert(err)
@@ -131,7 +175,7 @@ func NewApiClient(
 		if res.StatusCode == http.StatusBadGateway {
 			return nil, errors.BadInput.New(fmt.Sprintf("fail to connect to %v via %v", endpoint, proxy))
 		}
-	} else {
+	} else if !replaying {
 		// check connectivity
 		parsedUrl, err := url.Parse(endpoint)
 		if err != nil {
@@ -153,6 +197,9 @@ func NewApiClient(
 			return nil, errors.Default.Wrap(err, "Failed to connect")
 		}
 	}
+	if cassette != nil {
+		apiClient.client.Transport = cassette.Wrap(apiClient.client.Transport)
+	}
 	apiClient.SetContext(ctx)
 
 	return apiClient, nil
@@ -176,6 +223,11 @@ func (apiClient *ApiClient) SetEndpoint(endpoint string) {
 	apiClient.endpoint = endpoint
 }
 
+// GetConnectionId returns the ID of the connection the ApiClient was created from, 0 if it wasn't
+func (apiClient *ApiClient) GetConnectionId() uint64 {
+	return apiClient.connectionId
+}
+
 // GetEndpoint FIXME ...
 func (apiClient *ApiClient) GetEndpoint() string {
 	return apiClient.endpoint
@@ -0,0 +1,313 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// ThrottlePause is how long requests of a connection are paused when the server throttles without telling when to retry
+var ThrottlePause = 30 * time.Second
+
+// maxThrottledInterval caps how far the interval between requests grows due to throttling
+const maxThrottledInterval = time.Minute
+
+// ConnectionRateLimiter is a token bucket shared by all api clients of the same connection within the process,
+// it slows down when the server throttles requests and pauses as long as Retry-After tells
+type ConnectionRateLimiter struct {
+	plugin       string
+	connectionId uint64
+	mu           sync.Mutex
+	// baseInterval is the interval between requests derived from the budget, interval grows from it on throttling
+	baseInterval    time.Duration
+	interval        time.Duration
+	next            time.Time
+	pausedUntil     time.Time
+	windowStart     time.Time
+	window          time.Duration
+	budget          int
+	used            int
+	remaining       int
+	resetAt         time.Time
+	throttled       int
+	lastThrottledAt time.Time
+	lastUsedAt      time.Time
+}
+
+// ConnectionRateLimit is a snapshot of a ConnectionRateLimiter
+type ConnectionRateLimit struct {
+	Plugin          string `json:"plugin"`
+	ConnectionId    uint64 `json:"connectionId"`
+	RequestsPerHour int    `json:"requestsPerHour"`
+	// CurrentRequestsPerHour is lower than RequestsPerHour after the server throttled requests
+	CurrentRequestsPerHour int `json:"currentRequestsPerHour"`
+	// Remaining is reported by the server if it does, otherwise estimated by the requests sent in the current window
+	Remaining       int        `json:"remaining"`
+	ResetAt         *time.Time `json:"resetAt"`
+	PausedUntil     *time.Time `json:"pausedUntil"`
+	Throttled       int        `json:"throttled"`
+	LastThrottledAt *time.Time `json:"lastThrottledAt"`
+	LastUsedAt      *time.Time `json:"lastUsedAt"`
+}
+
+var connectionRateLimiters = make(map[string]*ConnectionRateLimiter)
+var connectionRateLimitersLock sync.Mutex
+
+// GetConnectionRateLimiter returns the rate limiter shared by the connection, the budget of requests per duration
+// replaces the previous one as it is calculated by the latest task
+func GetConnectionRateLimiter(pluginName string, connectionId uint64, requests int, duration time.Duration) (*ConnectionRateLimiter, errors.Error) {
+	if requests <= 0 || duration <= 0 {
+		return nil, errors.Default.New("requests and duration must be greater than 0")
+	}
+	connectionRateLimitersLock.Lock()
+	defer connectionRateLimitersLock.Unlock()
+	key := pluginName + ":" + strconv.FormatUint(connectionId, 10)
+	limiter, ok := connectionRateLimiters[key]
+	if !ok {
+		limiter = &ConnectionRateLimiter{
+			plugin:       pluginName,
+			connectionId: connectionId,
+			remaining:    -1,
+		}
+		connectionRateLimiters[key] = limiter
+	}
+	limiter.setBudget(requests, duration)
+	return limiter, nil
+}
+
+// GetConnectionRateLimits returns snapshots of all rate limiters of the process
+func GetConnectionRateLimits() []*ConnectionRateLimit {
+	connectionRateLimitersLock.Lock()
+	limiters := make([]*ConnectionRateLimiter, 0, len(connectionRateLimiters))
+	for _, limiter := range connectionRateLimiters {
+		limiters = append(limiters, limiter)
+	}
+	connectionRateLimitersLock.Unlock()
+	sort.Slice(limiters, func(i, j int) bool {
+		if limiters[i].plugin != limiters[j].plugin {
+			return limiters[i].plugin < limiters[j].plugin
+		}
+		return limiters[i].connectionId < limiters[j].connectionId
+	})
+	limits := make([]*ConnectionRateLimit, len(limiters))
+	for i, limiter := range limiters {
+		limits[i] = limiter.Snapshot()
+	}
+	return limits
+}
+
+func (l *ConnectionRateLimiter) setBudget(requests int, duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.budget = requests
+	l.window = duration
+	l.baseInterval = duration / time.Duration(requests)
+	if l.interval < l.baseInterval {
+		l.interval = l.baseInterval
+	}
+}
+
+// Wait blocks until the next request of the connection is allowed to be sent
+func (l *ConnectionRateLimiter) Wait(ctx context.Context) errors.Error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		at := now
+		if at.Before(l.next) {
+			at = l.next
+		}
+		if at.Before(l.pausedUntil) {
+			at = l.pausedUntil
+		}
+		l.next = at.Add(l.currentInterval(now))
+		l.mu.Unlock()
+
+		if delay := at.Sub(now); delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return errors.Convert(ctx.Err())
+			case <-timer.C:
+			}
+		}
+
+		l.mu.Lock()
+		// the server might have throttled us while waiting, take another turn after the pause
+		if time.Now().Before(l.pausedUntil) {
+			l.mu.Unlock()
+			continue
+		}
+		now = time.Now()
+		if l.windowStart.IsZero() || now.Sub(l.windowStart) >= l.window {
+			l.windowStart = now
+			l.used = 0
+		}
+		l.used++
+		l.lastUsedAt = now
+		l.mu.Unlock()
+		return nil
+	}
+}
+
+// currentInterval spreads the remaining requests reported by the server till its reset time
+func (l *ConnectionRateLimiter) currentInterval(now time.Time) time.Duration {
+	interval := l.interval
+	if l.remaining >= 0 && l.resetAt.After(now) {
+		if serverInterval := l.resetAt.Sub(now) / time.Duration(l.remaining+1); serverInterval > interval {
+			interval = serverInterval
+		}
+	}
+	return interval
+}
+
+// Observe adapts the limiter to the response, throttled responses slow it down and pause it,
+// successful ones bring the pace back to the budget gradually
+func (l *ConnectionRateLimiter) Observe(res *http.Response, body []byte) {
+	now := time.Now()
+	remaining, resetAt, hasRemaining := parseRateLimitRemaining(res.Header, now)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if hasRemaining {
+		l.remaining = remaining
+		l.resetAt = resetAt
+	}
+	if isThrottled(res, body) {
+		l.throttled++
+		l.lastThrottledAt = now
+		l.interval *= 2
+		if l.interval > maxThrottledInterval {
+			l.interval = maxThrottledInterval
+		}
+		if l.interval < l.baseInterval {
+			l.interval = l.baseInterval
+		}
+		pausedUntil := parseRetryAfter(res.Header, now)
+		if pausedUntil.IsZero() && hasRemaining && remaining == 0 {
+			pausedUntil = resetAt
+		}
+		if pausedUntil.IsZero() {
+			pausedUntil = now.Add(ThrottlePause)
+		}
+		if pausedUntil.After(l.pausedUntil) {
+			l.pausedUntil = pausedUntil
+		}
+		return
+	}
+	if res.StatusCode < http.StatusBadRequest && l.interval > l.baseInterval {
+		l.interval -= (l.interval - l.baseInterval) / 10
+		if l.interval-l.baseInterval < time.Millisecond {
+			l.interval = l.baseInterval
+		}
+	}
+}
+
+// Snapshot returns the current state of the limiter
+func (l *ConnectionRateLimiter) Snapshot() *ConnectionRateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	limit := &ConnectionRateLimit{
+		Plugin:                 l.plugin,
+		ConnectionId:           l.connectionId,
+		RequestsPerHour:        int(time.Hour / l.baseInterval),
+		CurrentRequestsPerHour: int(time.Hour / l.currentInterval(now)),
+		Throttled:              l.throttled,
+	}
+	if l.remaining >= 0 && l.resetAt.After(now) {
+		limit.Remaining = l.remaining
+		limit.ResetAt = timePtr(l.resetAt)
+	} else {
+		limit.Remaining = l.budget
+		if !l.windowStart.IsZero() && now.Sub(l.windowStart) < l.window {
+			limit.Remaining = l.budget - l.used
+			limit.ResetAt = timePtr(l.windowStart.Add(l.window))
+		}
+	}
+	if l.pausedUntil.After(now) {
+		limit.PausedUntil = timePtr(l.pausedUntil)
+	}
+	if !l.lastThrottledAt.IsZero() {
+		limit.LastThrottledAt = timePtr(l.lastThrottledAt)
+	}
+	if !l.lastUsedAt.IsZero() {
+		limit.LastUsedAt = timePtr(l.lastUsedAt)
+	}
+	return limit
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// isThrottled tells 429 and the 403 of secondary rate limits apart from other errors
+func isThrottled(res *http.Response, body []byte) bool {
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if res.StatusCode != http.StatusForbidden {
+		return false
+	}
+	return res.Header.Get("Retry-After") != "" ||
+		res.Header.Get("X-RateLimit-Remaining") == "0" ||
+		bytes.Contains(bytes.ToLower(body), []byte("rate limit"))
+}
+
+// parseRetryAfter parses the Retry-After header which is either seconds or a http date
+func parseRetryAfter(header http.Header, now time.Time) time.Time {
+	retryAfter := header.Get("Retry-After")
+	if retryAfter == "" {
+		return time.Time{}
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		return now.Add(time.Duration(seconds) * time.Second)
+	}
+	if at, err := http.ParseTime(retryAfter); err == nil {
+		return at
+	}
+	return time.Time{}
+}
+
+// parseRateLimitRemaining parses X-RateLimit-Remaining/X-RateLimit-Reset or RateLimit-Remaining/RateLimit-Reset headers,
+// reset is either an epoch in seconds or seconds to wait
+func parseRateLimitRemaining(header http.Header, now time.Time) (int, time.Time, bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		remaining, err := strconv.Atoi(header.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+		reset, err := strconv.ParseInt(header.Get(prefix+"Reset"), 10, 64)
+		if err != nil {
+			continue
+		}
+		// anything earlier than 2001-09-09 can't be an epoch
+		if reset < 1e9 {
+			return remaining, now.Add(time.Duration(reset) * time.Second), true
+		}
+		return remaining, time.Unix(reset, 0), true
+	}
+	return 0, time.Time{}, false
+}
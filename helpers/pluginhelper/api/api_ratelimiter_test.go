@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConnectionRateLimiter(t *testing.T) {
+	limiter, err := GetConnectionRateLimiter("ratelimitertest", 1, 3600, time.Hour)
+	assert.Nil(t, err)
+	// the latest budget wins
+	shared, err := GetConnectionRateLimiter("ratelimitertest", 1, 7200, time.Hour)
+	assert.Nil(t, err)
+	assert.Same(t, limiter, shared)
+	assert.Equal(t, 7200, limiter.Snapshot().RequestsPerHour)
+
+	other, err := GetConnectionRateLimiter("ratelimitertest", 2, 3600, time.Hour)
+	assert.Nil(t, err)
+	assert.NotSame(t, limiter, other)
+
+	_, err = GetConnectionRateLimiter("ratelimitertest", 3, 0, time.Hour)
+	assert.NotNil(t, err)
+}
+
+func TestConnectionRateLimiterObserve(t *testing.T) {
+	limiter := &ConnectionRateLimiter{remaining: -1}
+	limiter.setBudget(3600, time.Hour)
+
+	res := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	res.Header.Set("Retry-After", "120")
+	limiter.Observe(res, nil)
+	snapshot := limiter.Snapshot()
+	assert.Equal(t, 1, snapshot.Throttled)
+	assert.Equal(t, 1800, snapshot.CurrentRequestsPerHour)
+	assert.NotNil(t, snapshot.PausedUntil)
+	assert.True(t, snapshot.PausedUntil.After(time.Now().Add(110*time.Second)))
+
+	// successful responses bring the pace back gradually
+	ok := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	limiter.Observe(ok, nil)
+	assert.Greater(t, limiter.Snapshot().CurrentRequestsPerHour, 1800)
+	for i := 0; i < 200; i++ {
+		limiter.Observe(ok, nil)
+	}
+	assert.Equal(t, 3600, limiter.Snapshot().CurrentRequestsPerHour)
+}
+
+func TestConnectionRateLimiterRemaining(t *testing.T) {
+	limiter := &ConnectionRateLimiter{remaining: -1}
+	limiter.setBudget(3600, time.Hour)
+	assert.Equal(t, 3600, limiter.Snapshot().Remaining)
+
+	assert.Nil(t, limiter.Wait(context.Background()))
+	assert.Equal(t, 3599, limiter.Snapshot().Remaining)
+
+	res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	res.Header.Set("X-RateLimit-Remaining", "10")
+	res.Header.Set("X-RateLimit-Reset", "600")
+	limiter.Observe(res, nil)
+	snapshot := limiter.Snapshot()
+	assert.Equal(t, 10, snapshot.Remaining)
+	// 10 requests spread over the 10 minutes left
+	assert.LessOrEqual(t, snapshot.CurrentRequestsPerHour, 66)
+}
+
+func TestConnectionRateLimiterWait(t *testing.T) {
+	limiter := &ConnectionRateLimiter{remaining: -1}
+	limiter.setBudget(20, time.Second)
+	begin := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(begin), 100*time.Millisecond)
+
+	limiter.pausedUntil = time.Now().Add(200 * time.Millisecond)
+	begin = time.Now()
+	assert.Nil(t, limiter.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(begin), 200*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter.pausedUntil = time.Now().Add(time.Second)
+	assert.NotNil(t, limiter.Wait(ctx))
+}
+
+func TestIsThrottled(t *testing.T) {
+	res := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	assert.False(t, isThrottled(res, []byte(`{"message": "Resource not accessible by integration"}`)))
+	assert.True(t, isThrottled(res, []byte(`{"message": "You have exceeded a secondary rate limit."}`)))
+	res.Header.Set("Retry-After", "60")
+	assert.True(t, isThrottled(res, nil))
+	assert.True(t, isThrottled(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	assert.False(t, isThrottled(&http.Response{StatusCode: http.StatusInternalServerError}, nil))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	header := http.Header{}
+	assert.True(t, parseRetryAfter(header, now).IsZero())
+	header.Set("Retry-After", "30")
+	assert.Equal(t, now.Add(30*time.Second), parseRetryAfter(header, now))
+	header.Set("Retry-After", "Thu, 01 Jun 2023 00:01:00 GMT")
+	assert.Equal(t, now.Add(time.Minute), parseRetryAfter(header, now).UTC())
+}
+
+func TestParseRateLimitRemaining(t *testing.T) {
+	now := time.Unix(1685577600, 0)
+	header := http.Header{}
+	_, _, ok := parseRateLimitRemaining(header, now)
+	assert.False(t, ok)
+
+	header.Set("X-RateLimit-Remaining", "42")
+	header.Set("X-RateLimit-Reset", "1685581200")
+	remaining, resetAt, ok := parseRateLimitRemaining(header, now)
+	assert.True(t, ok)
+	assert.Equal(t, 42, remaining)
+	assert.Equal(t, now.Add(time.Hour), resetAt)
+
+	header = http.Header{}
+	header.Set("RateLimit-Remaining", "5")
+	header.Set("RateLimit-Reset", "60")
+	remaining, resetAt, ok = parseRateLimitRemaining(header, now)
+	assert.True(t, ok)
+	assert.Equal(t, 5, remaining)
+	assert.Equal(t, now.Add(time.Minute), resetAt)
+}
@@ -0,0 +1,378 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+const (
+	// CASSETTE_MODE_RECORD sends requests to the server and records the exchanges into the cassette
+	CASSETTE_MODE_RECORD = "record"
+	// CASSETTE_MODE_REPLAY serves requests from the exchanges recorded in the cassette without reaching the server
+	CASSETTE_MODE_REPLAY = "replay"
+)
+
+const cassetteRedacted = "REDACTED"
+
+// headers carrying credentials, they are redacted from the cassette
+var cassetteSecretHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"Private-Token",
+	"X-Api-Key",
+	"X-Auth-Token",
+}
+
+// query parameters carrying credentials, they are redacted from the cassette
+var cassetteSecretParams = []string{
+	"access_token",
+	"api_key",
+	"apikey",
+	"client_secret",
+	"key",
+	"password",
+	"private_token",
+	"secret",
+	"token",
+}
+
+// json fields whose names contain any of these carry credentials, they are redacted from the cassette
+var cassetteSecretFields = []string{"token", "secret", "password"}
+
+// CassetteRequest is a recorded http request
+type CassetteRequest struct {
+	Method string      `json:"method"`
+	Url    string      `json:"url"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// CassetteResponse is a recorded http response
+type CassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// CassetteInteraction is a recorded http exchange
+type CassetteInteraction struct {
+	Request  *CassetteRequest  `json:"request"`
+	Response *CassetteResponse `json:"response"`
+	used     bool
+}
+
+// Cassette records http exchanges into a file with credentials redacted and replays them deterministically,
+// a request is served by the first unused interaction with the same method, url and body
+type Cassette struct {
+	path         string
+	mode         string
+	mu           sync.Mutex
+	interactions []*CassetteInteraction
+}
+
+var cassettes = make(map[string]*Cassette)
+var cassettesLock sync.Mutex
+
+// LoadCassette creates a cassette in the mode and registers it for the path, cassettes in record mode start empty
+func LoadCassette(path string, mode string) (*Cassette, errors.Error) {
+	cassette := &Cassette{
+		path: path,
+		mode: mode,
+	}
+	switch mode {
+	case CASSETTE_MODE_RECORD:
+	case CASSETTE_MODE_REPLAY:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to read cassette %s", path))
+		}
+		err = json.Unmarshal(content, &cassette.interactions)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to parse cassette %s", path))
+		}
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid cassette mode %s, it should be %s or %s", mode, CASSETTE_MODE_RECORD, CASSETTE_MODE_REPLAY))
+	}
+	cassettesLock.Lock()
+	defer cassettesLock.Unlock()
+	cassettes[path] = cassette
+	return cassette, nil
+}
+
+// GetCassette returns the cassette configured by API_CASSETTE and API_CASSETTE_MODE (replay by default),
+// nil if there is none
+func GetCassette(br context.BasicRes) (*Cassette, errors.Error) {
+	path := br.GetConfig("API_CASSETTE")
+	if path == "" {
+		return nil, nil
+	}
+	mode := br.GetConfig("API_CASSETTE_MODE")
+	if mode == "" {
+		mode = CASSETTE_MODE_REPLAY
+	}
+	cassettesLock.Lock()
+	cassette, ok := cassettes[path]
+	cassettesLock.Unlock()
+	if ok && cassette.mode == mode {
+		return cassette, nil
+	}
+	return LoadCassette(path, mode)
+}
+
+// WrapTransportWithCassette returns the transport going through the configured cassette,
+// for http clients not created by NewApiClient, e.g. the graphql ones
+func WrapTransportWithCassette(br context.BasicRes, transport http.RoundTripper) (http.RoundTripper, errors.Error) {
+	cassette, err := GetCassette(br)
+	if err != nil || cassette == nil {
+		return transport, err
+	}
+	return cassette.Wrap(transport), nil
+}
+
+// Replaying tells if requests are served by the cassette instead of the server
+func (c *Cassette) Replaying() bool {
+	return c.mode == CASSETTE_MODE_REPLAY
+}
+
+// Wrap returns a http.RoundTripper replaying or recording through the next one
+func (c *Cassette) Wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &cassetteTransport{cassette: c, next: next}
+}
+
+// Save writes the recorded interactions to the cassette file
+func (c *Cassette) Save() errors.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.save()
+}
+
+func (c *Cassette) save() errors.Error {
+	content, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return errors.Convert(err)
+	}
+	if err = os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return errors.Convert(err)
+	}
+	return errors.Convert(os.WriteFile(c.path, content, 0644))
+}
+
+func (c *Cassette) replay(req *CassetteRequest) (*CassetteResponse, errors.Error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, interaction := range c.interactions {
+		recorded := interaction.Request
+		if !interaction.used && recorded.Method == req.Method && recorded.Url == req.Url && recorded.Body == req.Body {
+			interaction.used = true
+			return interaction.Response, nil
+		}
+	}
+	return nil, errors.NotFound.New(fmt.Sprintf("no recorded interaction left in cassette %s for %s %s", c.path, req.Method, req.Url))
+}
+
+func (c *Cassette) record(interaction *CassetteInteraction) errors.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+	// save on every exchange, so the cassette is complete even if the subtask panics
+	return c.save()
+}
+
+type cassetteTransport struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	recordedReq := &CassetteRequest{
+		Method: req.Method,
+		Url:    redactUrl(req.URL),
+		Header: redactHeader(req.Header),
+		Body:   redactBody(reqBody, true),
+	}
+
+	if t.cassette.Replaying() {
+		recordedRes, err := t.cassette.replay(recordedReq)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recordedRes.StatusCode, http.StatusText(recordedRes.StatusCode)),
+			StatusCode:    recordedRes.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recordedRes.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(recordedRes.Body)),
+			ContentLength: int64(len(recordedRes.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	recordErr := t.cassette.record(&CassetteInteraction{
+		Request: recordedReq,
+		Response: &CassetteResponse{
+			StatusCode: res.StatusCode,
+			Header:     redactHeader(res.Header),
+			Body:       redactBody(resBody, false),
+		},
+	})
+	if recordErr != nil {
+		return nil, recordErr
+	}
+	return res, nil
+}
+
+func redactUrl(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.UserPassword(cassetteRedacted, cassetteRedacted)
+	}
+	query := redacted.Query()
+	for name := range query {
+		for _, secret := range cassetteSecretParams {
+			if strings.EqualFold(name, secret) {
+				query.Set(name, cassetteRedacted)
+			}
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range cassetteSecretHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, cassetteRedacted)
+		}
+	}
+	return redacted
+}
+
+// redactBody redacts credentials from json and form bodies, other bodies are kept as they are,
+// json bodies are formatted the same way if canonical, so requests match regardless of whitespaces and field order
+func redactBody(body []byte, canonical bool) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if decoder.Decode(&value) != nil {
+		return redactForm(body)
+	}
+	if !redactJson(value) && !canonical {
+		return string(body)
+	}
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+// redactForm redacts credentials from form bodies, i.e. the client secret sent to OAuth2 token endpoints
+func redactForm(body []byte) string {
+	if !bytes.Contains(body, []byte("=")) {
+		return string(body)
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return string(body)
+	}
+	redacted := false
+	for name := range values {
+		if isSecretField(name) {
+			values.Set(name, cassetteRedacted)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return string(body)
+	}
+	return values.Encode()
+}
+
+// redactJson replaces string values of secret fields in place, and tells if anything was redacted
+func redactJson(value interface{}) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if _, ok := field.(string); ok && isSecretField(name) {
+				v[name] = cassetteRedacted
+				redacted = true
+			} else if redactJson(field) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if redactJson(item) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range cassetteSecretFields {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
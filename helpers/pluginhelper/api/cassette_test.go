@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Header().Set("X-Total", "2")
+		_, _ = w.Write([]byte(`{"page": "` + r.URL.Query().Get("page") + `", "access_token": "ghs_secret", "id": 12345678901234567}`))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassettes", "issues.json")
+
+	// record
+	cassette, err := LoadCassette(path, CASSETTE_MODE_RECORD)
+	assert.Nil(t, err)
+	client := &http.Client{Transport: cassette.Wrap(nil)}
+	for _, page := range []string{"1", "2", "1"} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/issues?page="+page+"&access_token=ghp_secret", nil)
+		req.Header.Set("Authorization", "Bearer ghp_secret")
+		res, err := client.Do(req)
+		assert.Nil(t, err)
+		body, _ := io.ReadAll(res.Body)
+		assert.Contains(t, string(body), "ghs_secret")
+	}
+	assert.Equal(t, 3, requests)
+	content, _ := os.ReadFile(path)
+	assert.NotContains(t, string(content), "secret")
+	assert.NotContains(t, string(content), "session=abc")
+	assert.Contains(t, string(content), "12345678901234567")
+
+	// replay
+	cassette, err = LoadCassette(path, CASSETTE_MODE_REPLAY)
+	assert.Nil(t, err)
+	client = &http.Client{Transport: cassette.Wrap(nil)}
+	for _, page := range []string{"2", "1", "1"} {
+		res, err := client.Get(server.URL + "/issues?page=" + page + "&access_token=another_token")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "2", res.Header.Get("X-Total"))
+		body, _ := io.ReadAll(res.Body)
+		assert.True(t, strings.HasPrefix(string(body), `{"access_token":"REDACTED","id":12345678901234567,"page":"`+page+`"`))
+	}
+	assert.Equal(t, 3, requests)
+
+	// every interaction is replayed once
+	_, getErr := client.Get(server.URL + "/issues?page=1")
+	assert.NotNil(t, getErr)
+	_, getErr = client.Get(server.URL + "/issues?page=1&access_token=REDACTED")
+	assert.NotNil(t, getErr)
+}
+
+func TestCassetteMatchesRequestBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "graphql.json")
+
+	cassette, err := LoadCassette(path, CASSETTE_MODE_RECORD)
+	assert.Nil(t, err)
+	client := &http.Client{Transport: cassette.Wrap(nil)}
+	for _, query := range []string{`{"query": "a"}`, `{"query": "b"}`} {
+		_, postErr := client.Post(server.URL+"/graphql", "application/json", strings.NewReader(query))
+		assert.Nil(t, postErr)
+	}
+
+	cassette, err = LoadCassette(path, CASSETTE_MODE_REPLAY)
+	assert.Nil(t, err)
+	client = &http.Client{Transport: cassette.Wrap(nil)}
+	res, postErr := client.Post(server.URL+"/graphql", "application/json", strings.NewReader(`{"query":"b"}`))
+	assert.Nil(t, postErr)
+	body, _ := io.ReadAll(res.Body)
+	assert.Equal(t, `{"query": "b"}`, string(body))
+	_, postErr = client.Post(server.URL+"/graphql", "application/json", strings.NewReader(`{"query": "c"}`))
+	assert.NotNil(t, postErr)
+}
+
+func TestRedactFormBody(t *testing.T) {
+	assert.Equal(t, "client_id=id&client_secret=REDACTED&grant_type=client_credentials",
+		redactBody([]byte("grant_type=client_credentials&client_id=id&client_secret=s3cret"), true))
+	assert.Equal(t, "plain text", redactBody([]byte("plain text"), true))
+}
+
+func TestLoadCassetteInvalidMode(t *testing.T) {
+	_, err := LoadCassette(filepath.Join(t.TempDir(), "cassette.json"), "rewind")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,204 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+)
+
+// ChatActivityGeneratorArgs includes the arguments of ChatActivityGenerator
+//
+//	ChatActivityGeneratorArgs {
+//				RawDataSubTaskArgs: args about raw data task, threads and participants are tagged with them
+//				ChannelIdPattern:   LIKE pattern matching the domain ids of the channels to process,
+//				                    e.g. didgen.NewDomainIdGenerator(&models.SlackChannel{}).Generate(connectionId, didgen.WILDCARD)
+//				BatchSize:          batch size
+type ChatActivityGeneratorArgs struct {
+	RawDataSubTaskArgs
+	ChannelIdPattern string
+	BatchSize        int
+}
+
+// ChatActivityGenerator derives `chat_threads` and `chat_participants` from the `chat_messages`
+// converted by a plugin, so every chat plugin shares the same definition of a thread reply,
+// a first response and a participant
+type ChatActivityGenerator struct {
+	*RawDataSubTask
+	args *ChatActivityGeneratorArgs
+}
+
+// NewChatActivityGenerator creates a ChatActivityGenerator using ChatActivityGeneratorArgs
+func NewChatActivityGenerator(args ChatActivityGeneratorArgs) (*ChatActivityGenerator, errors.Error) {
+	rawDataSubTask, err := NewRawDataSubTask(args.RawDataSubTaskArgs)
+	if err != nil {
+		return nil, err
+	}
+	if args.ChannelIdPattern == "" {
+		return nil, errors.Default.New("ChannelIdPattern is required for ChatActivityGenerator")
+	}
+	if args.BatchSize == 0 {
+		args.BatchSize = 500
+	}
+	return &ChatActivityGenerator{
+		RawDataSubTask: rawDataSubTask,
+		args:           &args,
+	}, nil
+}
+
+// Execute function implements Subtask interface.
+func (generator *ChatActivityGenerator) Execute() errors.Error {
+	divider := NewBatchSaveDivider(generator.args.Ctx, generator.args.BatchSize, generator.table, generator.params)
+	generator.args.Ctx.SetProgress(0, -1)
+	if err := generator.generateThreads(divider); err != nil {
+		return err
+	}
+	if err := generator.generateParticipants(divider); err != nil {
+		return err
+	}
+	return divider.Close()
+}
+
+func (generator *ChatActivityGenerator) generateThreads(divider *BatchSaveDivider) errors.Error {
+	db := generator.args.Ctx.GetDal()
+	batch, err := divider.ForType(reflect.TypeOf(&chat.ChatThread{}))
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.From(&chat.ChatMessage{}),
+		dal.Where("channel_id LIKE ? AND thread_id != ''", generator.args.ChannelIdPattern),
+		dal.Orderby("thread_id, created_date"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	ctx := generator.args.Ctx.GetContext()
+	var messages []*chat.ChatMessage
+	flush := func() errors.Error {
+		if len(messages) == 0 {
+			return nil
+		}
+		thread := summarizeChatThread(messages[0].ThreadId, messages)
+		thread.RawDataOrigin = common.RawDataOrigin{
+			RawDataTable:  generator.table,
+			RawDataParams: generator.params,
+		}
+		messages = nil
+		generator.args.Ctx.IncProgress(1)
+		return batch.Add(thread)
+	}
+	for cursor.Next() {
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		default:
+		}
+		message := &chat.ChatMessage{}
+		if err := db.Fetch(cursor, message); err != nil {
+			return errors.Default.Wrap(err, "error fetching chat message")
+		}
+		if len(messages) > 0 && messages[0].ThreadId != message.ThreadId {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		messages = append(messages, message)
+	}
+	return flush()
+}
+
+func (generator *ChatActivityGenerator) generateParticipants(divider *BatchSaveDivider) errors.Error {
+	db := generator.args.Ctx.GetDal()
+	batch, err := divider.ForType(reflect.TypeOf(&chat.ChatParticipant{}))
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.Select(`channel_id, author_id AS account_id, COUNT(*) AS message_count,
+			MIN(created_date) AS first_message_date, MAX(created_date) AS last_message_date`),
+		dal.From(&chat.ChatMessage{}),
+		dal.Where("channel_id LIKE ? AND author_id != ''", generator.args.ChannelIdPattern),
+		dal.Groupby("channel_id, author_id"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	for cursor.Next() {
+		participant := &chat.ChatParticipant{}
+		if err := db.Fetch(cursor, participant); err != nil {
+			return errors.Default.Wrap(err, "error fetching chat participant")
+		}
+		participant.RawDataOrigin = common.RawDataOrigin{
+			RawDataTable:  generator.table,
+			RawDataParams: generator.params,
+		}
+		if err := batch.Add(participant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// summarizeChatThread builds a thread out of its messages sorted by creation date. The root
+// message is the one whose id is the thread id, or the earliest one when the root was not collected.
+// Replies without author, i.e. posted by bots, don't count as a response.
+func summarizeChatThread(threadId string, messages []*chat.ChatMessage) *chat.ChatThread {
+	root := messages[0]
+	for _, message := range messages {
+		if message.Id == threadId {
+			root = message
+			break
+		}
+	}
+	thread := &chat.ChatThread{
+		DomainEntity: domainlayer.DomainEntity{Id: threadId},
+		ChannelId:    root.ChannelId,
+		AuthorId:     root.AuthorId,
+		CreatedDate:  root.CreatedDate,
+	}
+	participants := map[string]bool{}
+	if root.AuthorId != "" {
+		participants[root.AuthorId] = true
+	}
+	for _, message := range messages {
+		if message == root {
+			continue
+		}
+		thread.ReplyCount++
+		if message.AuthorId != "" {
+			participants[message.AuthorId] = true
+		}
+		replyDate := message.CreatedDate
+		if thread.FirstResponseDate == nil && message.AuthorId != "" && message.AuthorId != root.AuthorId && !replyDate.Before(root.CreatedDate) {
+			thread.FirstResponseDate = &replyDate
+		}
+		thread.LastReplyDate = &replyDate
+	}
+	thread.ParticipantCount = len(participants)
+	return thread
+}
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeChatThread(t *testing.T) {
+	minute := func(m int) time.Time {
+		return time.Date(2023, 6, 1, 9, m, 0, 0, time.UTC)
+	}
+	message := func(id, author string, m int) *chat.ChatMessage {
+		return &chat.ChatMessage{
+			DomainEntity: domainlayer.DomainEntity{Id: id},
+			ChannelId:    "channel1",
+			ThreadId:     "root",
+			AuthorId:     author,
+			CreatedDate:  minute(m),
+		}
+	}
+
+	thread := summarizeChatThread("root", []*chat.ChatMessage{
+		message("root", "alice", 0),
+		message("reply1", "alice", 2),
+		message("bot", "", 4),
+		message("reply2", "bob", 5),
+		message("reply3", "carol", 9),
+		message("reply4", "bob", 12),
+	})
+	assert.Equal(t, "root", thread.Id)
+	assert.Equal(t, "channel1", thread.ChannelId)
+	assert.Equal(t, "alice", thread.AuthorId)
+	assert.Equal(t, minute(0), thread.CreatedDate)
+	assert.Equal(t, 5, thread.ReplyCount)
+	assert.Equal(t, 3, thread.ParticipantCount)
+	assert.Equal(t, minute(5), *thread.FirstResponseDate)
+	assert.Equal(t, minute(12), *thread.LastReplyDate)
+
+	// the earliest reply stands for the root message when the latter was not collected
+	thread = summarizeChatThread("root", []*chat.ChatMessage{
+		message("reply1", "alice", 2),
+		message("reply2", "alice", 3),
+	})
+	assert.Equal(t, "alice", thread.AuthorId)
+	assert.Equal(t, minute(2), thread.CreatedDate)
+	assert.Equal(t, 1, thread.ReplyCount)
+	assert.Equal(t, 1, thread.ParticipantCount)
+	assert.Nil(t, thread.FirstResponseDate)
+	assert.Equal(t, minute(3), *thread.LastReplyDate)
+}
@@ -29,6 +29,32 @@ type BaseConnection struct {
 	common.Model
 }
 
+// GitHostKeys are the ssh host keys trusted by gitextractor when cloning the repos of a connection,
+// the global GIT_SSH_KNOWN_HOSTS is used when both are empty
+type GitHostKeys struct {
+	// KnownHosts is the content of a known_hosts file
+	KnownHosts string `mapstructure:"knownHosts" json:"knownHosts" gorm:"type:text"`
+	// HostKeyFingerprints are comma separated SHA256 fingerprints pinning the host keys, formatted like SHA256:xxxx
+	HostKeyFingerprints string `mapstructure:"hostKeyFingerprints" json:"hostKeyFingerprints" gorm:"type:text"`
+}
+
+// AddToGitExtractorOptions puts the host keys into the options of a gitextractor task
+func (k GitHostKeys) AddToGitExtractorOptions(options map[string]interface{}) map[string]interface{} {
+	if strings.TrimSpace(k.KnownHosts) != "" {
+		options["knownHosts"] = k.KnownHosts
+	}
+	fingerprints := make([]string, 0)
+	for _, fingerprint := range strings.Split(k.HostKeyFingerprints, ",") {
+		if fingerprint = strings.TrimSpace(fingerprint); fingerprint != "" {
+			fingerprints = append(fingerprints, fingerprint)
+		}
+	}
+	if len(fingerprints) > 0 {
+		options["hostKeyFingerprints"] = fingerprints
+	}
+	return options
+}
+
 // RestConnection implements the ApiConnection interface
 type RestConnection struct {
 	Endpoint         string `mapstructure:"endpoint" validate:"required" json:"endpoint"`
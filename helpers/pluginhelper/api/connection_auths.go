@@ -18,16 +18,23 @@ limitations under the License.
 package api
 
 import (
+	gocontext "context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/plugin"
 
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/go-playground/validator/v10"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 // BasicAuth implements HTTP Basic Authentication
@@ -92,9 +99,149 @@ func (ak *AppKey) GetAppKeyAuthenticator() plugin.ApiAuthenticator {
 	return ak
 }
 
+// OAUTH2_REFRESH_AHEAD is how long before its expiry an OAuth2 access token gets refreshed
+const OAUTH2_REFRESH_AHEAD = time.Minute
+
+// OAuth2 implements the OAuth 2.0 client credentials grant, or the refresh token grant once RefreshToken is given,
+// the access token is fetched on the first request and refreshed ahead of its expiry
+type OAuth2 struct {
+	ClientId     string `mapstructure:"clientId" validate:"required" json:"clientId"`
+	ClientSecret string `mapstructure:"clientSecret" json:"clientSecret" gorm:"serializer:encdec"`
+	TokenUrl     string `mapstructure:"tokenUrl" validate:"required,url" json:"tokenUrl"`
+	// Scopes are separated by spaces or commas
+	Scopes       string `mapstructure:"scopes" json:"scopes"`
+	RefreshToken string `mapstructure:"refreshToken" json:"refreshToken" gorm:"serializer:encdec"`
+	state        *oauth2State
+}
+
+type oauth2State struct {
+	sync.Mutex
+	token      *oauth2.Token
+	httpClient *http.Client
+	onRotate   func(refreshToken string)
+}
+
+var oauth2StateLock sync.Mutex
+
+func (o *OAuth2) getState() *oauth2State {
+	oauth2StateLock.Lock()
+	defer oauth2StateLock.Unlock()
+	if o.state == nil {
+		o.state = &oauth2State{}
+	}
+	return o.state
+}
+
+// GetScopes returns the scopes to be requested
+func (o *OAuth2) GetScopes() []string {
+	return strings.FieldsFunc(o.Scopes, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+}
+
+// SetOAuth2HttpClient sets the client exchanging tokens, so the proxy and tls settings of the connection apply
+func (o *OAuth2) SetOAuth2HttpClient(client *http.Client) {
+	o.getState().httpClient = client
+}
+
+// OnRefreshTokenRotated registers a callback for the new refresh token issued by servers rotating them,
+// the token is kept in RefreshToken anyway, NewApiClientFromConnection registers one saving it into the connection row
+func (o *OAuth2) OnRefreshTokenRotated(callback func(refreshToken string)) {
+	o.getState().onRotate = callback
+}
+
+// persistRotatedRefreshToken saves the refresh token rotated by the server into the row of the connection, encrypted,
+// servers like Atlassian 3LO invalidate the old one, so the next task would fail with the one stored before
+func persistRotatedRefreshToken(br context.BasicRes, connection interface{}, refreshToken string) errors.Error {
+	v := reflect.ValueOf(connection)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	id, stored := v.Elem().FieldByName("ID"), v.Elem().FieldByName("RefreshToken")
+	tabler, ok := connection.(dal.Tabler)
+	// connections under test are not saved
+	if !ok || !id.IsValid() || id.Kind() != reflect.Uint64 || id.Uint() == 0 || !stored.IsValid() || stored.Kind() != reflect.String {
+		return nil
+	}
+	if plugin.IsSecretReference(stored.String()) {
+		return errors.Default.New(fmt.Sprintf("refresh token of connection %d was rotated, please update it in the secret provider", id.Uint()))
+	}
+	encrypted, err := plugin.Encrypt(br.GetConfig(plugin.EncodeKeyEnvStr), refreshToken)
+	if err != nil {
+		return err
+	}
+	err = br.GetDal().UpdateColumn(tabler.TableName(), "refresh_token", encrypted, dal.Where("id = ?", id.Uint()))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to save the rotated refresh token of connection %d", id.Uint()))
+	}
+	// in case the connection gets saved afterward
+	stored.SetString(refreshToken)
+	return nil
+}
+
+// GetOAuth2Token returns a valid access token, exchanging a new one if the cached one is about to expire
+func (o *OAuth2) GetOAuth2Token() (*oauth2.Token, errors.Error) {
+	state := o.getState()
+	state.Lock()
+	defer state.Unlock()
+	if state.token != nil && (state.token.Expiry.IsZero() || time.Now().Add(OAUTH2_REFRESH_AHEAD).Before(state.token.Expiry)) {
+		return state.token, nil
+	}
+	ctx := gocontext.Background()
+	if state.httpClient != nil {
+		ctx = gocontext.WithValue(ctx, oauth2.HTTPClient, state.httpClient)
+	}
+	var token *oauth2.Token
+	var err error
+	if o.RefreshToken != "" {
+		config := &oauth2.Config{
+			ClientID:     o.ClientId,
+			ClientSecret: o.ClientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: o.TokenUrl},
+			Scopes:       o.GetScopes(),
+		}
+		// a token without access token is always refreshed
+		token, err = config.TokenSource(ctx, &oauth2.Token{RefreshToken: o.RefreshToken}).Token()
+	} else {
+		config := &clientcredentials.Config{
+			ClientID:     o.ClientId,
+			ClientSecret: o.ClientSecret,
+			TokenURL:     o.TokenUrl,
+			Scopes:       o.GetScopes(),
+		}
+		token, err = config.Token(ctx)
+	}
+	if err != nil {
+		return nil, errors.Unauthorized.Wrap(err, "failed to exchange OAuth2 access token")
+	}
+	if token.RefreshToken != "" && o.RefreshToken != "" && token.RefreshToken != o.RefreshToken {
+		o.RefreshToken = token.RefreshToken
+		if state.onRotate != nil {
+			state.onRotate(token.RefreshToken)
+		}
+	}
+	state.token = token
+	return token, nil
+}
+
+// SetupAuthentication sets up the request headers for authentication
+func (o *OAuth2) SetupAuthentication(request *http.Request) errors.Error {
+	token, err := o.GetOAuth2Token()
+	if err != nil {
+		return err
+	}
+	token.SetAuthHeader(request)
+	return nil
+}
+
+// GetOAuth2Authenticator returns SetupAuthentication
+func (o *OAuth2) GetOAuth2Authenticator() plugin.ApiAuthenticator {
+	return o
+}
+
 // MultiAuth implements the MultiAuthenticator interface
 type MultiAuth struct {
-	AuthMethod       string `mapstructure:"authMethod" json:"authMethod" validate:"required,oneof=BasicAuth AccessToken AppKey"`
+	AuthMethod       string `mapstructure:"authMethod" json:"authMethod" validate:"required,oneof=BasicAuth AccessToken AppKey OAuth2"`
 	apiAuthenticator plugin.ApiAuthenticator
 }
 
@@ -127,6 +274,12 @@ func (ma *MultiAuth) GetApiAuthenticator(connection plugin.ApiConnection) (plugi
 		}
 		// check ae/models/connection.go:AeAppKey if you needed an example
 		ma.apiAuthenticator = appKey.GetAppKeyAuthenticator()
+	case plugin.AUTH_METHOD_OAUTH2:
+		oauth2Auth, ok := connection.(plugin.OAuth2Authenticator)
+		if !ok {
+			return nil, errors.Default.New("connection doesn't support OAuth2 Authentication")
+		}
+		ma.apiAuthenticator = oauth2Auth.GetOAuth2Authenticator()
 	default:
 		return nil, errors.Default.New("no Authentication Method was specified")
 	}
@@ -0,0 +1,177 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newOAuth2TokenServer(t *testing.T, expiresIn int, exchanges *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		n := atomic.AddInt32(exchanges, 1)
+		body := map[string]interface{}{
+			"access_token": fmt.Sprintf("access-%d", n),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+			"grant":        r.PostForm.Get("grant_type"),
+			"scope":        r.PostForm.Get("scope"),
+		}
+		if r.PostForm.Get("grant_type") == "refresh_token" {
+			body["refresh_token"] = fmt.Sprintf("refresh-%d", n)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.Nil(t, json.NewEncoder(w).Encode(body))
+	}))
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	var exchanges int32
+	server := newOAuth2TokenServer(t, 3600, &exchanges)
+	defer server.Close()
+
+	auth := &OAuth2{ClientId: "id", ClientSecret: "secret", TokenUrl: server.URL, Scopes: "read, write"}
+	token, err := auth.GetOAuth2Token()
+	assert.Nil(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+	assert.Equal(t, "client_credentials", token.Extra("grant"))
+	assert.Equal(t, "read write", token.Extra("scope"))
+
+	// cached until it is about to expire
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	assert.Nil(t, auth.SetupAuthentication(req))
+	assert.Equal(t, "Bearer access-1", req.Header.Get("Authorization"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&exchanges))
+}
+
+func TestOAuth2RefreshAhead(t *testing.T) {
+	var exchanges int32
+	// expires within OAUTH2_REFRESH_AHEAD, so every request exchanges a new token
+	server := newOAuth2TokenServer(t, 30, &exchanges)
+	defer server.Close()
+
+	auth := &OAuth2{ClientId: "id", TokenUrl: server.URL}
+	first, err := auth.GetOAuth2Token()
+	assert.Nil(t, err)
+	second, err := auth.GetOAuth2Token()
+	assert.Nil(t, err)
+	assert.Equal(t, "access-1", first.AccessToken)
+	assert.Equal(t, "access-2", second.AccessToken)
+}
+
+func TestOAuth2RefreshTokenRotation(t *testing.T) {
+	var exchanges int32
+	server := newOAuth2TokenServer(t, 3600, &exchanges)
+	defer server.Close()
+
+	auth := &OAuth2{ClientId: "id", ClientSecret: "secret", TokenUrl: server.URL, RefreshToken: "refresh-0"}
+	rotated := ""
+	auth.OnRefreshTokenRotated(func(refreshToken string) {
+		rotated = refreshToken
+	})
+	token, err := auth.GetOAuth2Token()
+	assert.Nil(t, err)
+	assert.Equal(t, "refresh_token", token.Extra("grant"))
+	assert.Equal(t, "refresh-1", auth.RefreshToken)
+	assert.Equal(t, "refresh-1", rotated)
+}
+
+type oauth2TestConnection struct {
+	RestConnection
+	MultiAuth
+	OAuth2
+}
+
+func (conn *oauth2TestConnection) SetupAuthentication(req *http.Request) errors.Error {
+	return conn.MultiAuth.SetupAuthenticationForConnection(conn, req)
+}
+
+func TestMultiAuthOAuth2(t *testing.T) {
+	var exchanges int32
+	server := newOAuth2TokenServer(t, 3600, &exchanges)
+	defer server.Close()
+
+	conn := &oauth2TestConnection{
+		MultiAuth: MultiAuth{AuthMethod: "OAuth2"},
+		OAuth2:    OAuth2{ClientId: "id", TokenUrl: server.URL},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	assert.Nil(t, conn.SetupAuthentication(req))
+	assert.Equal(t, "Bearer access-1", req.Header.Get("Authorization"))
+}
+
+type oauth2TestSavedConnection struct {
+	BaseConnection
+	oauth2TestConnection
+}
+
+func (oauth2TestSavedConnection) TableName() string {
+	return "_tool_oauth2_test_connections"
+}
+
+func TestOAuth2RotatedRefreshTokenPersisted(t *testing.T) {
+	var exchanges int32
+	server := newOAuth2TokenServer(t, 3600, &exchanges)
+	defer server.Close()
+
+	encryptionSecret := "ABCDEFGHIJKLMNOPQRSTUVWXYZABCDEF"
+	mockDal := new(mockdal.Dal)
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	mockRes.On("GetConfig", plugin.EncodeKeyEnvStr).Return(encryptionSecret)
+	mockRes.On("GetConfig", mock.Anything).Return("")
+	saved := ""
+	mockDal.On("UpdateColumn", "_tool_oauth2_test_connections", "refresh_token", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			saved = args.String(2)
+		}).Return(nil).Once()
+
+	conn := &oauth2TestSavedConnection{}
+	conn.ID = 1
+	conn.Endpoint = server.URL
+	conn.AuthMethod = "OAuth2"
+	conn.OAuth2 = OAuth2{ClientId: "id", ClientSecret: "secret", TokenUrl: server.URL, RefreshToken: "refresh-0"}
+	apiClient, err := NewApiClientFromConnection(gocontext.Background(), mockRes, conn)
+	assert.Nil(t, err)
+	res, err := apiClient.Get("/", nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer access-1", res.Request.Header.Get("Authorization"))
+
+	// the rotated refresh token is saved encrypted, so the next task picks it up
+	mockDal.AssertExpectations(t)
+	assert.NotEqual(t, "refresh-1", saved)
+	decrypted, err := plugin.Decrypt(encryptionSecret, saved)
+	assert.Nil(t, err)
+	assert.Equal(t, "refresh-1", decrypted)
+	assert.Equal(t, "refresh-1", conn.RefreshToken)
+}
@@ -0,0 +1,323 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+)
+
+// IssueSnapshotFields tells which issue_changelogs fields (matched by field_id or field_name) change
+// the snapshotted attributes of an issue
+type IssueSnapshotFields struct {
+	Status     []string
+	StoryPoint []string
+	Assignee   []string
+}
+
+// IssueSnapshotGeneratorArgs includes the arguments of IssueSnapshotGenerator
+//
+//	IssueSnapshotGeneratorArgs {
+//				RawDataSubTaskArgs: args about raw data task, snapshots are tagged with them
+//				BoardId:            domain id of the board whose issues get snapshotted
+//				Fields:             changelog fields to replay, `status` and `assignee` by default
+//				Since:              first day of snapshots, nil means the creation date of each issue
+//				BatchSize:          batch size
+type IssueSnapshotGeneratorArgs struct {
+	RawDataSubTaskArgs
+	BoardId   string
+	Fields    IssueSnapshotFields
+	Since     *time.Time
+	BatchSize int
+}
+
+// IssueSnapshotGenerator records a daily `issue_snapshots` row for every issue of a board.
+// Snapshots of issues with changelogs are replayed backward from the current state of the issue,
+// one row for each day from its creation until it is done (or today when it is still open).
+// Days before the last snapshot of an issue are settled, so a run only regenerates the days from
+// it on, unless the changelogs now go further back than the first snapshot, e.g. after timeAfter
+// was moved earlier, in which case the whole history of the issue is regenerated.
+// Issues without changelogs get a snapshot of their current state on every run instead, so
+// their history is built up by successive collections. Snapshots are never deleted by a run.
+type IssueSnapshotGenerator struct {
+	*RawDataSubTask
+	args *IssueSnapshotGeneratorArgs
+	now  func() time.Time
+}
+
+// NewIssueSnapshotGenerator creates an IssueSnapshotGenerator using IssueSnapshotGeneratorArgs
+func NewIssueSnapshotGenerator(args IssueSnapshotGeneratorArgs) (*IssueSnapshotGenerator, errors.Error) {
+	rawDataSubTask, err := NewRawDataSubTask(args.RawDataSubTaskArgs)
+	if err != nil {
+		return nil, err
+	}
+	if args.BoardId == "" {
+		return nil, errors.Default.New("BoardId is required for IssueSnapshotGenerator")
+	}
+	if len(args.Fields.Status) == 0 {
+		args.Fields.Status = []string{"status"}
+	}
+	if len(args.Fields.Assignee) == 0 {
+		args.Fields.Assignee = []string{"assignee"}
+	}
+	if args.BatchSize == 0 {
+		args.BatchSize = 500
+	}
+	return &IssueSnapshotGenerator{
+		RawDataSubTask: rawDataSubTask,
+		args:           &args,
+		now:            time.Now,
+	}, nil
+}
+
+// Execute function implements Subtask interface.
+func (generator *IssueSnapshotGenerator) Execute() errors.Error {
+	db := generator.args.Ctx.GetDal()
+	batch, err := NewBatchSave(generator.args.Ctx, reflect.TypeOf(&ticket.IssueSnapshot{}), generator.args.BatchSize)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.Select("issues.*"),
+		dal.From(&ticket.Issue{}),
+		dal.Join("JOIN board_issues ON board_issues.issue_id = issues.id"),
+		dal.Where("board_issues.board_id = ?", generator.args.BoardId),
+		dal.Orderby("issues.id"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	generator.args.Ctx.SetProgress(0, -1)
+	ctx := generator.args.Ctx.GetContext()
+	today := truncateToDay(generator.now())
+	issues := make([]*ticket.Issue, 0, generator.args.BatchSize)
+	flush := func() errors.Error {
+		if len(issues) == 0 {
+			return nil
+		}
+		err := generator.snapshotIssues(issues, today, batch)
+		generator.args.Ctx.IncProgress(len(issues))
+		issues = issues[:0]
+		return err
+	}
+	for cursor.Next() {
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		default:
+		}
+		issue := &ticket.Issue{}
+		if err := db.Fetch(cursor, issue); err != nil {
+			return errors.Default.Wrap(err, "error fetching issue")
+		}
+		issues = append(issues, issue)
+		if len(issues) == generator.args.BatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return batch.Close()
+}
+
+func (generator *IssueSnapshotGenerator) snapshotIssues(issues []*ticket.Issue, today time.Time, batch *BatchSave) errors.Error {
+	issueIds := make([]string, len(issues))
+	for i, issue := range issues {
+		issueIds[i] = issue.Id
+	}
+	fields := generator.args.Fields
+	fieldNames := make([]string, 0, len(fields.Status)+len(fields.StoryPoint)+len(fields.Assignee))
+	fieldNames = append(append(append(fieldNames, fields.Status...), fields.StoryPoint...), fields.Assignee...)
+	var changelogs []*ticket.IssueChangelogs
+	err := generator.args.Ctx.GetDal().All(
+		&changelogs,
+		dal.Where("issue_id IN ? AND (field_id IN ? OR field_name IN ?)", issueIds, fieldNames, fieldNames),
+	)
+	if err != nil {
+		return err
+	}
+	changelogsByIssue := make(map[string][]*ticket.IssueChangelogs)
+	for _, changelog := range changelogs {
+		changelogsByIssue[changelog.IssueId] = append(changelogsByIssue[changelog.IssueId], changelog)
+	}
+	var ranges []*issueSnapshotRange
+	err = generator.args.Ctx.GetDal().All(
+		&ranges,
+		dal.Select("issue_id, MIN(snapshot_date) AS first_date, MAX(snapshot_date) AS last_date"),
+		dal.From(&ticket.IssueSnapshot{}),
+		dal.Where(
+			"board_id = ? AND issue_id IN ? AND source = ?",
+			generator.args.BoardId, issueIds, ticket.ISSUE_SNAPSHOT_CHANGELOG,
+		),
+		dal.Groupby("issue_id"),
+	)
+	if err != nil {
+		return err
+	}
+	rangesByIssue := make(map[string]*issueSnapshotRange, len(ranges))
+	for _, r := range ranges {
+		rangesByIssue[r.IssueId] = r
+	}
+	for _, issue := range issues {
+		snapshots := replayIssueSnapshots(
+			issue, changelogsByIssue[issue.Id], &fields, generator.args.Since, rangesByIssue[issue.Id], today,
+		)
+		for _, snapshot := range snapshots {
+			snapshot.BoardId = generator.args.BoardId
+			snapshot.RawDataOrigin = common.RawDataOrigin{
+				RawDataTable:  generator.table,
+				RawDataParams: generator.params,
+			}
+			if err := batch.Add(snapshot); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// issueSnapshotRange is the span of days already snapshotted from the changelogs of an issue
+type issueSnapshotRange struct {
+	IssueId   string
+	FirstDate time.Time
+	LastDate  time.Time
+}
+
+// replayIssueSnapshots returns the daily snapshots of an issue by undoing its changelogs from
+// the newest to the oldest, starting off the current state of the issue, the days before the
+// last existing snapshot are skipped when the changelogs do not start before the first one
+func replayIssueSnapshots(
+	issue *ticket.Issue,
+	changelogs []*ticket.IssueChangelogs,
+	fields *IssueSnapshotFields,
+	since *time.Time,
+	existing *issueSnapshotRange,
+	today time.Time,
+) []*ticket.IssueSnapshot {
+	state := &ticket.IssueSnapshot{
+		IssueId:        issue.Id,
+		Status:         issue.Status,
+		OriginalStatus: issue.OriginalStatus,
+		StoryPoint:     issue.StoryPoint,
+		AssigneeId:     issue.AssigneeId,
+	}
+	if len(changelogs) == 0 {
+		// without history, the current state is all we know, and it is recorded as of today,
+		// or as of the day the issue was resolved so that done issues do not pile up rows
+		state.Source = ticket.ISSUE_SNAPSHOT_COLLECTION
+		state.SnapshotDate = today
+		if issue.Status == ticket.DONE {
+			if issue.ResolutionDate != nil {
+				state.SnapshotDate = truncateToDay(*issue.ResolutionDate)
+			} else if issue.UpdatedDate != nil {
+				state.SnapshotDate = truncateToDay(*issue.UpdatedDate)
+			}
+		}
+		if (since != nil && state.SnapshotDate.Before(truncateToDay(*since))) || state.SnapshotDate.After(today) {
+			return nil
+		}
+		return []*ticket.IssueSnapshot{state}
+	}
+
+	sort.SliceStable(changelogs, func(i, j int) bool {
+		return changelogs[i].CreatedDate.After(changelogs[j].CreatedDate)
+	})
+	first := changelogs[len(changelogs)-1].CreatedDate
+	if issue.CreatedDate != nil && issue.CreatedDate.Before(first) {
+		first = *issue.CreatedDate
+	}
+	start := truncateToDay(first)
+	if since != nil && start.Before(truncateToDay(*since)) {
+		start = truncateToDay(*since)
+	}
+	// the last snapshot is regenerated as well, the issue may have changed later on that day
+	if existing != nil && !start.Before(truncateToDay(existing.FirstDate)) {
+		if lastDay := truncateToDay(existing.LastDate); lastDay.After(start) {
+			start = lastDay
+		}
+	}
+	end := today
+	if issue.Status == ticket.DONE {
+		last := changelogs[0].CreatedDate
+		if issue.ResolutionDate != nil && issue.ResolutionDate.After(last) {
+			last = *issue.ResolutionDate
+		}
+		if lastDay := truncateToDay(last); lastDay.Before(end) {
+			end = lastDay
+		}
+	}
+
+	var snapshots []*ticket.IssueSnapshot
+	next := 0
+	for day := end; !day.Before(start); day = day.AddDate(0, 0, -1) {
+		endOfDay := day.AddDate(0, 0, 1)
+		for ; next < len(changelogs) && !changelogs[next].CreatedDate.Before(endOfDay); next++ {
+			undoIssueChangelog(state, changelogs[next], fields)
+		}
+		snapshot := *state
+		snapshot.SnapshotDate = day
+		snapshot.Source = ticket.ISSUE_SNAPSHOT_CHANGELOG
+		snapshots = append(snapshots, &snapshot)
+	}
+	return snapshots
+}
+
+// undoIssueChangelog reverts the state to what it was before the changelog
+func undoIssueChangelog(state *ticket.IssueSnapshot, changelog *ticket.IssueChangelogs, fields *IssueSnapshotFields) {
+	switch {
+	case matchesChangelogField(changelog, fields.Status):
+		state.Status = changelog.FromValue
+		state.OriginalStatus = changelog.OriginalFromValue
+	case matchesChangelogField(changelog, fields.StoryPoint):
+		// an unparsable or empty value means the issue was not estimated yet
+		state.StoryPoint, _ = strconv.ParseFloat(strings.TrimSpace(changelog.OriginalFromValue), 64)
+	case matchesChangelogField(changelog, fields.Assignee):
+		state.AssigneeId = changelog.OriginalFromValue
+	}
+}
+
+func matchesChangelogField(changelog *ticket.IssueChangelogs, names []string) bool {
+	for _, name := range names {
+		if changelog.FieldId == name || changelog.FieldName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Check if IssueSnapshotGenerator implements SubTask interface
+var _ plugin.SubTask = (*IssueSnapshotGenerator)(nil)
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayIssueSnapshots(t *testing.T) {
+	day := func(d, h int) time.Time {
+		return time.Date(2023, 6, d, h, 0, 0, 0, time.UTC)
+	}
+	created, resolved := day(1, 9), day(4, 15)
+	issue := &ticket.Issue{
+		DomainEntity:   domainlayer.DomainEntity{Id: "issue1"},
+		Status:         ticket.DONE,
+		OriginalStatus: "Done",
+		StoryPoint:     5,
+		AssigneeId:     "bob",
+		CreatedDate:    &created,
+		ResolutionDate: &resolved,
+	}
+	changelogs := []*ticket.IssueChangelogs{
+		{FieldId: "status", FromValue: ticket.TODO, OriginalFromValue: "To Do", ToValue: ticket.IN_PROGRESS, OriginalToValue: "Doing", CreatedDate: day(2, 10)},
+		{FieldId: "status", FromValue: ticket.IN_PROGRESS, OriginalFromValue: "Doing", ToValue: ticket.DONE, OriginalToValue: "Done", CreatedDate: resolved},
+		{FieldId: "customfield_10024", FieldName: "Story Points", OriginalFromValue: "3", OriginalToValue: "5", CreatedDate: day(3, 11)},
+		{FieldName: "assignee", OriginalFromValue: "", OriginalToValue: "alice", CreatedDate: day(1, 12)},
+		{FieldName: "assignee", OriginalFromValue: "alice", OriginalToValue: "bob", CreatedDate: day(3, 8)},
+	}
+	fields := &IssueSnapshotFields{
+		Status:     []string{"status"},
+		StoryPoint: []string{"customfield_10024"},
+		Assignee:   []string{"assignee"},
+	}
+
+	snapshots := replayIssueSnapshots(issue, changelogs, fields, nil, nil, day(10, 0))
+	assert.Len(t, snapshots, 4)
+	expected := []struct {
+		status, originalStatus, assignee string
+		storyPoint                       float64
+	}{
+		{ticket.DONE, "Done", "bob", 5},
+		{ticket.IN_PROGRESS, "Doing", "bob", 5},
+		{ticket.IN_PROGRESS, "Doing", "alice", 3},
+		{ticket.TODO, "To Do", "alice", 3},
+	}
+	for i, snapshot := range snapshots {
+		assert.Equal(t, day(4-i, 0), snapshot.SnapshotDate)
+		assert.Equal(t, expected[i].status, snapshot.Status)
+		assert.Equal(t, expected[i].originalStatus, snapshot.OriginalStatus)
+		assert.Equal(t, expected[i].assignee, snapshot.AssigneeId)
+		assert.Equal(t, expected[i].storyPoint, snapshot.StoryPoint)
+		assert.Equal(t, ticket.ISSUE_SNAPSHOT_CHANGELOG, snapshot.Source)
+	}
+
+	// open issues are snapshotted until today, starting from `since`
+	issue.Status, issue.ResolutionDate = ticket.IN_PROGRESS, nil
+	since := day(8, 20)
+	snapshots = replayIssueSnapshots(issue, changelogs, fields, &since, nil, day(10, 0))
+	assert.Len(t, snapshots, 3)
+	assert.Equal(t, day(10, 0), snapshots[0].SnapshotDate)
+	assert.Equal(t, day(8, 0), snapshots[2].SnapshotDate)
+
+	// the days before the last snapshot of the previous run are not generated again
+	existing := &issueSnapshotRange{IssueId: "issue1", FirstDate: day(1, 0), LastDate: day(8, 0)}
+	snapshots = replayIssueSnapshots(issue, changelogs, fields, nil, existing, day(10, 0))
+	assert.Len(t, snapshots, 3)
+	assert.Equal(t, day(10, 0), snapshots[0].SnapshotDate)
+	assert.Equal(t, day(8, 0), snapshots[2].SnapshotDate)
+	assert.Equal(t, ticket.IN_PROGRESS, snapshots[2].Status)
+	assert.Equal(t, "bob", snapshots[2].AssigneeId)
+
+	// unless the changelogs start before the first snapshot, then the whole history is generated
+	existing.FirstDate = day(5, 0)
+	snapshots = replayIssueSnapshots(issue, changelogs, fields, nil, existing, day(10, 0))
+	assert.Len(t, snapshots, 10)
+	assert.Equal(t, day(1, 0), snapshots[9].SnapshotDate)
+	assert.Equal(t, ticket.TODO, snapshots[9].Status)
+}
+
+func TestReplayIssueSnapshotsWithoutChangelogs(t *testing.T) {
+	today := time.Date(2023, 6, 10, 0, 0, 0, 0, time.UTC)
+	resolved := time.Date(2023, 6, 7, 18, 0, 0, 0, time.UTC)
+	issue := &ticket.Issue{
+		DomainEntity: domainlayer.DomainEntity{Id: "issue1"},
+		Status:       ticket.TODO,
+		StoryPoint:   2,
+	}
+	snapshots := replayIssueSnapshots(issue, nil, &IssueSnapshotFields{}, nil, nil, today)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, today, snapshots[0].SnapshotDate)
+	assert.Equal(t, ticket.ISSUE_SNAPSHOT_COLLECTION, snapshots[0].Source)
+
+	issue.Status, issue.ResolutionDate = ticket.DONE, &resolved
+	snapshots = replayIssueSnapshots(issue, nil, &IssueSnapshotFields{}, nil, nil, today)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, time.Date(2023, 6, 7, 0, 0, 0, 0, time.UTC), snapshots[0].SnapshotDate)
+
+	since := today
+	snapshots = replayIssueSnapshots(issue, nil, &IssueSnapshotFields{}, &since, nil, today)
+	assert.Empty(t, snapshots)
+}
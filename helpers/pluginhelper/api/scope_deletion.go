@@ -0,0 +1,267 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const (
+	// scopeDeletionChunkSize is the number of rows deleted by a statement, each statement commits on its own
+	// so no table gets locked for long
+	scopeDeletionChunkSize = 5000
+	// maxLineageDepth stops cascading cyclic lineages
+	maxLineageDepth = 5
+)
+
+// scopeDeletionStep deletes the rows of a table matching `where`
+type scopeDeletionStep struct {
+	table       string
+	derivedFrom string
+	where       string
+	params      []interface{}
+	depth       int
+}
+
+// collectDataLineages returns the lineages declared by all plugins
+func collectDataLineages() []plugin.TableLineage {
+	var lineages []plugin.TableLineage
+	names := make([]string, 0)
+	plugins := plugin.AllPlugins()
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if declarer, ok := plugins[name].(plugin.PluginDataLineage); ok {
+			lineages = append(lineages, declarer.DataLineage()...)
+		}
+	}
+	return lineages
+}
+
+// buildScopeDeletionSteps cascades the base steps through the lineages. Derived steps are ordered before
+// the steps they were derived from, so the parent rows they are selected by still exist when deleting them.
+func buildScopeDeletionSteps(base []*scopeDeletionStep, lineages []plugin.TableLineage) []*scopeDeletionStep {
+	steps := append([]*scopeDeletionStep{}, base...)
+	frontier := base
+	for depth := 1; depth <= maxLineageDepth && len(frontier) > 0; depth++ {
+		var derived []*scopeDeletionStep
+		for _, parent := range frontier {
+			for _, lineage := range lineages {
+				// mysql refuses to delete from a table selected by the subquery
+				if lineage.ParentTable != parent.table || lineage.Table == lineage.ParentTable {
+					continue
+				}
+				derived = append(derived, deriveScopeDeletionStep(parent, lineage, depth))
+			}
+		}
+		steps = append(steps, derived...)
+		frontier = derived
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].depth > steps[j].depth
+	})
+	return steps
+}
+
+func deriveScopeDeletionStep(parent *scopeDeletionStep, lineage plugin.TableLineage, depth int) *scopeDeletionStep {
+	where := fmt.Sprintf(
+		"%s IN (SELECT %s FROM %s WHERE %s)",
+		lineage.Column, lineage.ParentColumn, lineage.ParentTable, parent.where,
+	)
+	params := append([]interface{}{}, parent.params...)
+	if lineage.Exclusive {
+		where += fmt.Sprintf(
+			" AND %s NOT IN (SELECT %s FROM %s WHERE %s IS NOT NULL AND NOT (%s))",
+			lineage.Column, lineage.ParentColumn, lineage.ParentTable, lineage.ParentColumn, parent.where,
+		)
+		params = append(params, parent.params...)
+	}
+	if lineage.Where != "" {
+		where = fmt.Sprintf("(%s) AND %s", lineage.Where, where)
+	}
+	return &scopeDeletionStep{
+		table:       lineage.Table,
+		derivedFrom: parent.table,
+		where:       where,
+		params:      params,
+		depth:       depth,
+	}
+}
+
+// scopeDeletionSteps returns the steps deleting the data of the scope from the given tables and the derived tables
+func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) scopeDeletionSteps(tables []string, rawDataParams string) []*scopeDeletionStep {
+	rawDataTablePrefix := fmt.Sprintf("_raw_%s%%", gs.plugin)
+	var base []*scopeDeletionStep
+	for _, table := range tables {
+		step := &scopeDeletionStep{table: table}
+		if strings.HasPrefix(table, "_raw_") {
+			// raw table: should check connection and scope
+			step.where = "params = ?"
+			step.params = []interface{}{rawDataParams}
+		} else if strings.HasPrefix(table, "_tool_") {
+			// tool layer table: should check connection and scope
+			step.where = "_raw_data_params = ?"
+			step.params = []interface{}{rawDataParams}
+		} else if table == (models.CollectorLatestState{}.TableName()) {
+			// diff sync state: should check plugin, connection and scope
+			step.where = "raw_data_table LIKE ? AND raw_data_params = ?"
+			step.params = []interface{}{rawDataTablePrefix, rawDataParams}
+		} else {
+			// domain layer table: should check plugin, connection and scope
+			step.where = "_raw_data_table LIKE ? AND _raw_data_params = ?"
+			step.params = []interface{}{rawDataTablePrefix, rawDataParams}
+		}
+		base = append(base, step)
+	}
+	var steps []*scopeDeletionStep
+	for _, step := range buildScopeDeletionSteps(base, collectDataLineages()) {
+		if step.depth == 0 || gs.db.HasTable(step.table) {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// findActiveScopeDeletion returns the deletion of the scope which has not finished yet
+func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) findActiveScopeDeletion(connectionId uint64, scopeId string) (*models.ScopeDeletion, errors.Error) {
+	deletion := &models.ScopeDeletion{}
+	err := gs.db.First(
+		deletion,
+		dal.Where(
+			"plugin = ? AND connection_id = ? AND scope_id = ? AND status IN ?",
+			gs.plugin, connectionId, scopeId, []string{models.TASK_CREATED, models.TASK_RUNNING},
+		),
+	)
+	if gs.db.IsErrorNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// runScopeDeletion deletes the data of the scope chunk by chunk in background, onDeleted is called after all data
+// were deleted, the progress is saved into the deletion record
+func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) runScopeDeletion(
+	deletion *models.ScopeDeletion,
+	steps []*scopeDeletionStep,
+	onDeleted func() errors.Error,
+) {
+	var err errors.Error
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Default.New(fmt.Sprintf("run scope deletion panicked: %v", r))
+		}
+		finishedAt := time.Now()
+		deletion.FinishedAt = &finishedAt
+		deletion.Status = models.TASK_COMPLETED
+		if err != nil {
+			gs.log.Error(err, "failed to delete data of scope %s", deletion.ScopeId)
+			deletion.Status = models.TASK_FAILED
+			deletion.Message = err.Error()
+		}
+		if e := gs.db.Update(deletion); e != nil {
+			gs.log.Error(e, "failed to save scope deletion #%d", deletion.ID)
+		}
+	}()
+	err = gs.executeScopeDeletion(deletion, steps, onDeleted)
+}
+
+func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) executeScopeDeletion(
+	deletion *models.ScopeDeletion,
+	steps []*scopeDeletionStep,
+	onDeleted func() errors.Error,
+) errors.Error {
+	beganAt := time.Now()
+	deletion.BeganAt = &beganAt
+	deletion.Status = models.TASK_RUNNING
+	for i, step := range steps {
+		count, err := gs.db.Count(dal.From(step.table), dal.Where(step.where, step.params...))
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error counting rows of %s", step.table))
+		}
+		deletion.Tables[i].Rows = count
+		deletion.TotalRows += count
+	}
+	err := gs.db.Update(deletion)
+	if err != nil || deletion.DryRun {
+		return err
+	}
+	for i, step := range steps {
+		err = gs.deleteInChunks(step, deletion.Tables[i].Rows, func(deleted int64) errors.Error {
+			deletion.Tables[i].Deleted += deleted
+			deletion.DeletedRows += deleted
+			return gs.db.Update(deletion)
+		})
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error deleting data bound to scope %s from %s", deletion.RawDataParams, step.table))
+		}
+		deletion.FinishedTables++
+	}
+	err = onDeleted()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// deleteInChunks deletes the rows of the step by statements deleting at most scopeDeletionChunkSize rows,
+// rows is the number of rows counted beforehand, the table is counted again in case new rows were inserted meanwhile
+func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) deleteInChunks(step *scopeDeletionStep, rows int64, onChunk func(deleted int64) errors.Error) errors.Error {
+	var sql string
+	if gs.db.Dialect() == "postgres" {
+		sql = fmt.Sprintf(
+			"DELETE FROM %s WHERE ctid IN (SELECT ctid FROM %s WHERE %s LIMIT %d)",
+			step.table, step.table, step.where, scopeDeletionChunkSize,
+		)
+	} else {
+		sql = fmt.Sprintf("DELETE FROM %s WHERE %s LIMIT %d", step.table, step.where, scopeDeletionChunkSize)
+	}
+	for rows > 0 {
+		for ; rows > 0; rows -= scopeDeletionChunkSize {
+			err := gs.db.Exec(sql, step.params...)
+			if err != nil {
+				return err
+			}
+			deleted := rows
+			if deleted > scopeDeletionChunkSize {
+				deleted = scopeDeletionChunkSize
+			}
+			if err = onChunk(deleted); err != nil {
+				return err
+			}
+		}
+		var err errors.Error
+		rows, err = gs.db.Count(dal.From(step.table), dal.Where(step.where, step.params...))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
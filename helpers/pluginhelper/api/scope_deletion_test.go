@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildScopeDeletionSteps(t *testing.T) {
+	base := []*scopeDeletionStep{
+		{table: "pull_requests", where: "_raw_data_table LIKE ? AND _raw_data_params = ?", params: []interface{}{"_raw_github%", "p"}},
+		{table: "repos", where: "_raw_data_table LIKE ? AND _raw_data_params = ?", params: []interface{}{"_raw_github%", "p"}},
+	}
+	lineages := []plugin.TableLineage{
+		{Table: "project_pr_metrics", Column: "id", ParentTable: "pull_requests", ParentColumn: "id", Where: "_raw_data_table = 'pull_requests'"},
+		{Table: "repo_commits", Column: "_raw_data_params", ParentTable: "repos", ParentColumn: "id"},
+		{Table: "commits", Column: "sha", ParentTable: "repo_commits", ParentColumn: "commit_sha", Exclusive: true},
+		{Table: "issues", Column: "id", ParentTable: "issues", ParentColumn: "parent_issue_id"},
+	}
+	steps := buildScopeDeletionSteps(base, lineages)
+	var tables []string
+	for _, step := range steps {
+		tables = append(tables, step.table)
+	}
+	// derived tables go first, deepest first
+	assert.Equal(t, []string{"commits", "project_pr_metrics", "repo_commits", "pull_requests", "repos"}, tables)
+
+	assert.Equal(t, "repo_commits", steps[0].derivedFrom)
+	assert.Equal(t,
+		"sha IN (SELECT commit_sha FROM repo_commits WHERE _raw_data_params IN (SELECT id FROM repos WHERE _raw_data_table LIKE ? AND _raw_data_params = ?))"+
+			" AND sha NOT IN (SELECT commit_sha FROM repo_commits WHERE commit_sha IS NOT NULL AND NOT (_raw_data_params IN (SELECT id FROM repos WHERE _raw_data_table LIKE ? AND _raw_data_params = ?)))",
+		steps[0].where,
+	)
+	assert.Equal(t, []interface{}{"_raw_github%", "p", "_raw_github%", "p"}, steps[0].params)
+	assert.Equal(t,
+		"(_raw_data_table = 'pull_requests') AND id IN (SELECT id FROM pull_requests WHERE _raw_data_table LIKE ? AND _raw_data_params = ?)",
+		steps[1].where,
+	)
+	assert.Equal(t, []interface{}{"_raw_github%", "p"}, steps[1].params)
+}
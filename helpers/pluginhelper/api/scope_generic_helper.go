@@ -89,6 +89,7 @@ type (
 	deleteRequestParams struct {
 		requestParams
 		deleteDataOnly bool
+		dryRun         bool
 	}
 
 	getRequestParams struct {
@@ -297,42 +298,72 @@ func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) GetScope(input *plugi
 	return scopeRes, nil
 }
 
-func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) DeleteScope(input *plugin.ApiResourceInput) (*serviceHelper.BlueprintProjectPairs, errors.Error) {
+// DeleteScope starts a background job deleting the data of the scope, and the scope itself unless `delete_data_only`
+// was set. With `dry_run` the job only counts the rows to be deleted. The job could be followed through the
+// returned ScopeDeletion, the blueprints referring to the scope are returned along with a conflict error.
+func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) DeleteScope(input *plugin.ApiResourceInput) (*serviceHelper.BlueprintProjectPairs, *models.ScopeDeletion, errors.Error) {
 	params, err := gs.extractFromDeleteReqParam(input)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	err = gs.dbHelper.VerifyConnection(params.connectionId)
 	if err != nil {
-		return nil, errors.Default.Wrap(err, fmt.Sprintf("error verifying connection for connection ID %d", params.connectionId))
+		return nil, nil, errors.Default.Wrap(err, fmt.Sprintf("error verifying connection for connection ID %d", params.connectionId))
 	}
 	scope, err := gs.dbHelper.GetScope(params.connectionId, params.scopeId)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// now we can as scope to state its `Params` for data bloodline identification
 	if refs, err := gs.getScopeReferences(params.connectionId, params.scopeId); err != nil || refs != nil {
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		return refs, errors.Conflict.New("Found one or more references to this scope")
+		return refs, nil, errors.Conflict.New("Found one or more references to this scope")
 	}
-	if err = gs.deleteScopeData(*scope); err != nil {
-		return nil, err
+	active, err := gs.findActiveScopeDeletion(params.connectionId, params.scopeId)
+	if err != nil {
+		return nil, nil, err
 	}
-	if !params.deleteDataOnly {
-		// Delete the scope itself
-		err = gs.dbHelper.DeleteScope(scope)
-		//err = gs.db.Delete(scope)
-		if err != nil {
-			return nil, errors.Default.Wrap(err, fmt.Sprintf("error deleting scope %s", params.scopeId))
+	if active != nil {
+		return nil, active, errors.Conflict.New(fmt.Sprintf("the scope is being deleted by scope deletion #%d", active.ID))
+	}
+	// find all tables for this plugin
+	tables, err := gs.getAffectedTables(gs.plugin)
+	if err != nil {
+		return nil, nil, errors.Default.Wrap(err, fmt.Sprintf("error getting database tables managed by plugin %s", gs.plugin))
+	}
+	scopeParams := plugin.MarshalScopeParams((*scope).ScopeParams())
+	steps := gs.scopeDeletionSteps(tables, scopeParams)
+	deletion := &models.ScopeDeletion{
+		Plugin:         gs.plugin,
+		ConnectionId:   params.connectionId,
+		ScopeId:        params.scopeId,
+		RawDataParams:  scopeParams,
+		DryRun:         params.dryRun,
+		DeleteDataOnly: params.deleteDataOnly,
+		Status:         models.TASK_CREATED,
+		TotalTables:    len(steps),
+	}
+	for _, step := range steps {
+		deletion.Tables = append(deletion.Tables, &models.ScopeDeletionTable{Table: step.table, DerivedFrom: step.derivedFrom})
+	}
+	err = gs.db.Create(deletion)
+	if err != nil {
+		return nil, nil, errors.Default.Wrap(err, "error creating scope deletion")
+	}
+	go gs.runScopeDeletion(deletion, steps, func() errors.Error {
+		if params.deleteDataOnly {
+			return nil
 		}
-		err = gs.updateBlueprints(params.connectionId, params.plugin, params.scopeId)
+		// Delete the scope itself
+		err := gs.dbHelper.DeleteScope(scope)
 		if err != nil {
-			return nil, err
+			return errors.Default.Wrap(err, fmt.Sprintf("error deleting scope %s", params.scopeId))
 		}
-	}
-	return nil, nil
+		return gs.updateBlueprints(params.connectionId, params.plugin, params.scopeId)
+	})
+	return nil, deletion, nil
 }
 
 func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) addScopeConfig(scopes ...*Scope) ([]*ScopeRes[Scope, ScopeConfig], errors.Error) {
@@ -412,9 +443,17 @@ func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) extractFromDeleteReqP
 			}
 		}
 	}
+	var dryRun bool
+	if dr, ok := input.Query["dry_run"]; ok {
+		dryRun, err = errors.Convert01(strconv.ParseBool(dr[0]))
+		if err != nil {
+			dryRun = false
+		}
+	}
 	return &deleteRequestParams{
 		requestParams:  *params,
 		deleteDataOnly: deleteDataOnly,
+		dryRun:         dryRun,
 	}, nil
 }
 
@@ -586,62 +625,6 @@ func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) updateBlueprints(conn
 	return nil
 }
 
-func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) deleteScopeData(scope plugin.ToolLayerScope) errors.Error {
-	// find all tables for this plugin
-	tables, err := gs.getAffectedTables(gs.plugin)
-	if err != nil {
-		return errors.Default.Wrap(err, fmt.Sprintf("error getting database tables managed by plugin %s", gs.plugin))
-	}
-	scopeParams := plugin.MarshalScopeParams(scope.ScopeParams())
-	err = gs.transactionalDelete(tables, scopeParams)
-	if err != nil {
-		return errors.Default.Wrap(err, fmt.Sprintf("error deleting data bound to scope %s for plugin %s", scopeParams, gs.plugin))
-	}
-	return nil
-}
-
-func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) transactionalDelete(tables []string, rawDataParams string) errors.Error {
-	tx := gs.db.Begin()
-	for _, table := range tables {
-		var where string
-		var params []interface{}
-		if strings.HasPrefix(table, "_raw_") {
-			// raw table: should check connection and scope
-			where = "params = ?"
-			params = []interface{}{rawDataParams}
-		} else if strings.HasPrefix(table, "_tool_") {
-			// tool layer table: should check connection and scope
-			where = "_raw_data_params = ?"
-			params = []interface{}{rawDataParams}
-		} else {
-			// framework tables: should check plugin, connection and scope
-			if table == (models.CollectorLatestState{}.TableName()) {
-				// diff sync state
-				where = "raw_data_table LIKE ? AND raw_data_params = ?"
-			} else {
-				// domain layer table
-				where = "_raw_data_table LIKE ? AND _raw_data_params = ?"
-			}
-			rawDataTablePrefix := fmt.Sprintf("_raw_%s%%", gs.plugin)
-			params = []interface{}{rawDataTablePrefix, rawDataParams}
-		}
-		sql := fmt.Sprintf("DELETE FROM %s WHERE %s", table, where)
-		err := tx.Exec(sql, params...)
-		if err != nil {
-			err2 := tx.Rollback()
-			if err2 != nil {
-				gs.log.Warn(err2, fmt.Sprintf("error rolling back table data deletion transaction. sql: %s params: %v", sql, params))
-			}
-			return err
-		}
-	}
-	err := tx.Commit()
-	if err != nil {
-		return errors.Default.Wrap(err, "error committing delete transaction for plugin tables")
-	}
-	return nil
-}
-
 // Implement MarshalJSON method to flatten all fields
 func (sr *ScopeRes[T, Y]) MarshalJSON() ([]byte, error) {
 	var flatMap map[string]interface{}
@@ -95,9 +95,12 @@ func (c *ScopeApiHelper[Conn, Scope, Tr]) GetScope(input *plugin.ApiResourceInpu
 }
 
 func (c *ScopeApiHelper[Conn, Scope, Tr]) Delete(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	refs, err := c.DeleteScope(input)
+	refs, deletion, err := c.DeleteScope(input)
 	if err != nil {
-		return &plugin.ApiResourceOutput{Body: refs, Status: err.GetType().GetHttpCode()}, nil
+		if refs != nil {
+			return &plugin.ApiResourceOutput{Body: refs, Status: err.GetType().GetHttpCode()}, nil
+		}
+		return &plugin.ApiResourceOutput{Body: deletion, Status: err.GetType().GetHttpCode()}, nil
 	}
-	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	return &plugin.ApiResourceOutput{Body: deletion, Status: http.StatusAccepted}, nil
 }
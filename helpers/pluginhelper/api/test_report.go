@@ -0,0 +1,152 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+)
+
+// TestReportSuite is a platform neutral test suite, JUnit XML reports are parsed into it
+type TestReportSuite struct {
+	Name     string            `json:"name"`
+	Duration float64           `json:"duration"`
+	Cases    []*TestReportCase `json:"cases"`
+}
+
+// TestReportCase is a platform neutral test case, Result is one of devops.TEST_*
+type TestReportCase struct {
+	ClassName string  `json:"className"`
+	Name      string  `json:"name"`
+	Duration  float64 `json:"duration"`
+	Result    string  `json:"result"`
+	Message   string  `json:"message"`
+}
+
+// CountResult returns the number of cases of the suite with the given result
+func (suite *TestReportSuite) CountResult(result string) int {
+	count := 0
+	for _, c := range suite.Cases {
+		if c.Result == result {
+			count++
+		}
+	}
+	return count
+}
+
+// GenerateTestCaseId returns the id of a test case in `test_cases`, which stays the same across runs
+// of the cicd_scope so results of a test can be compared from one run to another
+func GenerateTestCaseId(cicdScopeId string, className string, name string) string {
+	return fmt.Sprintf("%s:%x", cicdScopeId, sha1.Sum([]byte(className+"\n"+name)))
+}
+
+type junitTestSuite struct {
+	XMLName xml.Name
+	Name    string           `xml:"name,attr"`
+	Time    string           `xml:"time,attr"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+	Cases   []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+	Skipped   *junitMessage `xml:"skipped"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (m *junitMessage) String() string {
+	if m.Message != "" {
+		return m.Message
+	}
+	text := strings.TrimSpace(m.Text)
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	return text
+}
+
+// ParseJunitReport parses a JUnit XML report, either a <testsuites> or a single <testsuite> document.
+// Nested suites are flattened, suites without test cases are left out.
+func ParseJunitReport(data []byte) ([]*TestReportSuite, errors.Error) {
+	root := &junitTestSuite{}
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(root); err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse JUnit report")
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return nil, errors.BadInput.New(fmt.Sprintf("unexpected root element <%s> in JUnit report", root.XMLName.Local))
+	}
+	var suites []*TestReportSuite
+	var flatten func(junitSuite *junitTestSuite)
+	flatten = func(junitSuite *junitTestSuite) {
+		if len(junitSuite.Cases) > 0 {
+			suite := &TestReportSuite{
+				Name:     junitSuite.Name,
+				Duration: parseJunitTime(junitSuite.Time),
+			}
+			for _, junitCase := range junitSuite.Cases {
+				c := &TestReportCase{
+					ClassName: junitCase.ClassName,
+					Name:      junitCase.Name,
+					Duration:  parseJunitTime(junitCase.Time),
+					Result:    devops.TEST_PASSED,
+				}
+				if junitCase.Failure != nil {
+					c.Result = devops.TEST_FAILED
+					c.Message = junitCase.Failure.String()
+				} else if junitCase.Error != nil {
+					c.Result = devops.TEST_ERROR
+					c.Message = junitCase.Error.String()
+				} else if junitCase.Skipped != nil {
+					c.Result = devops.TEST_SKIPPED
+					c.Message = junitCase.Skipped.String()
+				}
+				suite.Cases = append(suite.Cases, c)
+			}
+			suites = append(suites, suite)
+		}
+		for i := range junitSuite.Suites {
+			flatten(&junitSuite.Suites[i])
+		}
+	}
+	flatten(root)
+	return suites, nil
+}
+
+// parseJunitTime parses durations in seconds, some reporters format them with thousands separators
+func parseJunitTime(s string) float64 {
+	seconds, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	return seconds
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJunitReport(t *testing.T) {
+	report := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="all" time="3.5">
+  <testsuite name="com.example.CartTest" tests="4" failures="1" errors="1" skipped="1" time="1,201.5">
+    <testcase classname="com.example.CartTest" name="addsItem" time="0.5"/>
+    <testcase classname="com.example.CartTest" name="removesItem" time="1.25">
+      <failure message="expected 1 but was 2" type="AssertionError">stack trace</failure>
+    </testcase>
+    <testcase classname="com.example.CartTest" name="checksOut" time="0.1">
+      <error type="NullPointerException">java.lang.NullPointerException
+	at com.example.Cart.checkout(Cart.java:42)</error>
+    </testcase>
+    <testcase classname="com.example.CartTest" name="refunds">
+      <skipped/>
+    </testcase>
+  </testsuite>
+  <testsuite name="outer">
+    <testsuite name="inner">
+      <testcase classname="inner" name="works" time="2"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+	suites, err := ParseJunitReport([]byte(report))
+	assert.Nil(t, err)
+	assert.Len(t, suites, 2)
+
+	suite := suites[0]
+	assert.Equal(t, "com.example.CartTest", suite.Name)
+	assert.Equal(t, 1201.5, suite.Duration)
+	assert.Len(t, suite.Cases, 4)
+	assert.Equal(t, 1, suite.CountResult(devops.TEST_PASSED))
+	assert.Equal(t, 1, suite.CountResult(devops.TEST_FAILED))
+	assert.Equal(t, 1, suite.CountResult(devops.TEST_ERROR))
+	assert.Equal(t, 1, suite.CountResult(devops.TEST_SKIPPED))
+	assert.Equal(t, "expected 1 but was 2", suite.Cases[1].Message)
+	assert.Equal(t, 1.25, suite.Cases[1].Duration)
+	assert.Equal(t, "java.lang.NullPointerException", suite.Cases[2].Message)
+	assert.Equal(t, float64(0), suite.Cases[3].Duration)
+
+	assert.Equal(t, "inner", suites[1].Name)
+	assert.Equal(t, "works", suites[1].Cases[0].Name)
+
+	suites, err = ParseJunitReport([]byte(`<testsuite name="single"><testcase classname="a" name="b"/></testsuite>`))
+	assert.Nil(t, err)
+	assert.Len(t, suites, 1)
+	assert.Equal(t, devops.TEST_PASSED, suites[0].Cases[0].Result)
+
+	_, err = ParseJunitReport([]byte(`<html></html>`))
+	assert.NotNil(t, err)
+}
+
+func TestGenerateTestCaseId(t *testing.T) {
+	id := GenerateTestCaseId("jenkins:JenkinsJob:1:devlake", "com.example.CartTest", "addsItem")
+	assert.Equal(t, id, GenerateTestCaseId("jenkins:JenkinsJob:1:devlake", "com.example.CartTest", "addsItem"))
+	assert.NotEqual(t, id, GenerateTestCaseId("jenkins:JenkinsJob:1:devlake", "com.example.CartTest", "removesItem"))
+	assert.NotEqual(t, id, GenerateTestCaseId("jenkins:JenkinsJob:2:devlake", "com.example.CartTest", "addsItem"))
+}
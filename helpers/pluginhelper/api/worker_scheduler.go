@@ -53,6 +53,7 @@ type WorkerScheduler struct {
 	counter      int32
 	logger       log.Logger
 	tickInterval time.Duration
+	rateLimiter  *ConnectionRateLimiter
 }
 
 //var callframeEnabled = os.Getenv("ASYNC_CF") == "true"
@@ -108,6 +109,12 @@ func (s *WorkerScheduler) SubmitBlocking(task func() errors.Error) {
 		case <-s.ctx.Done():
 			panic(s.ctx.Err())
 		case <-s.ticker.C:
+			// the ticker paces this scheduler, the rate limiter paces all schedulers of the connection
+			if s.rateLimiter != nil {
+				if err := s.rateLimiter.Wait(s.ctx); err != nil {
+					panic(err)
+				}
+			}
 			err := task()
 			if err != nil {
 				panic(err)
@@ -116,6 +123,11 @@ func (s *WorkerScheduler) SubmitBlocking(task func() errors.Error) {
 	}))
 }
 
+// SetRateLimiter makes tasks wait for the rate limiter shared with other schedulers before being executed
+func (s *WorkerScheduler) SetRateLimiter(rateLimiter *ConnectionRateLimiter) {
+	s.rateLimiter = rateLimiter
+}
+
 /*
 func (s *WorkerScheduler) gatherCallFrames() string {
 	cf := "set Environment Varaible ASYNC_CF=true to enable callframes capturing"
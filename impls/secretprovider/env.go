@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretprovider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.SecretProvider = (*EnvProvider)(nil)
+
+// EnvProvider resolves `env://NAME` from environment variables,
+// only variables with the prefix are readable so references can't reveal the settings of devlake itself
+type EnvProvider struct {
+	prefix string
+}
+
+func NewEnvProvider(prefix string) *EnvProvider {
+	return &EnvProvider{prefix: prefix}
+}
+
+func (p *EnvProvider) Scheme() string {
+	return "env"
+}
+
+func (p *EnvProvider) Resolve(_ context.Context, ref *url.URL) (string, errors.Error) {
+	name := ref.Host
+	if name == "" || !strings.HasPrefix(name, p.prefix) {
+		return "", errors.BadInput.New(fmt.Sprintf("environment variable must be prefixed with %s", p.prefix))
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", errors.NotFound.New(fmt.Sprintf("environment variable %s is not set", name))
+	}
+	return value, nil
+}
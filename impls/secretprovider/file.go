@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretprovider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.SecretProvider = (*FileProvider)(nil)
+
+// FileProvider resolves `file:///run/secrets/x` from files under dir, e.g. docker or kubernetes secrets,
+// the trailing newline of the file is trimmed
+type FileProvider struct {
+	dir string
+}
+
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: filepath.Clean(dir)}
+}
+
+func (p *FileProvider) Scheme() string {
+	return "file"
+}
+
+func (p *FileProvider) Resolve(_ context.Context, ref *url.URL) (string, errors.Error) {
+	path := filepath.Clean(ref.Path)
+	if ref.Host != "" || !strings.HasPrefix(path, p.dir+string(filepath.Separator)) {
+		return "", errors.BadInput.New(fmt.Sprintf("secret file must be under %s", p.dir))
+	}
+	// the dir itself may be a symlink as kubernetes mounts secrets, files within it must not escape though
+	realDir, err := filepath.EvalSymlinks(p.dir)
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", errors.NotFound.Wrap(err, "secret file not found")
+	}
+	if !strings.HasPrefix(realPath, realDir+string(filepath.Separator)) {
+		return "", errors.BadInput.New(fmt.Sprintf("secret file must be under %s", p.dir))
+	}
+	content, err := os.ReadFile(realPath)
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
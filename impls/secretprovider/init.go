@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretprovider
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const (
+	DEFAULT_SECRET_ENV_PREFIX = "DEVLAKE_SECRET_"
+	DEFAULT_SECRET_FILE_DIR   = "/run/secrets"
+)
+
+// Init registers the secret providers, vault only if VAULT_ADDR was set
+func Init(cfg config.ConfigReader) {
+	if cfg.GetString("SECRET_CACHE_TTL") != "" {
+		plugin.SetSecretCacheTTL(time.Duration(cfg.GetInt("SECRET_CACHE_TTL")) * time.Second)
+	}
+	envPrefix := cfg.GetString("SECRET_ENV_PREFIX")
+	if envPrefix == "" {
+		envPrefix = DEFAULT_SECRET_ENV_PREFIX
+	}
+	plugin.RegisterSecretProvider(NewEnvProvider(envPrefix))
+	fileDir := cfg.GetString("SECRET_FILE_DIR")
+	if fileDir == "" {
+		fileDir = DEFAULT_SECRET_FILE_DIR
+	}
+	plugin.RegisterSecretProvider(NewFileProvider(fileDir))
+	if addr := cfg.GetString("VAULT_ADDR"); addr != "" {
+		plugin.RegisterSecretProvider(NewVaultProvider(addr, cfg.GetString("VAULT_TOKEN"), cfg.GetString("VAULT_NAMESPACE")))
+	}
+}
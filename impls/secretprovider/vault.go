@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.SecretProvider = (*VaultProvider)(nil)
+
+// VaultProvider resolves `vault://{mount}/{path}#{key}` from the HashiCorp Vault KV v2 secrets engine,
+// a specific version of the secret can be read with `vault://{mount}/{path}?version=2#{key}`
+type VaultProvider struct {
+	addr      string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+func NewVaultProvider(addr, token, namespace string) *VaultProvider {
+	return &VaultProvider{
+		addr:      strings.TrimSuffix(addr, "/"),
+		token:     token,
+		namespace: namespace,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (p *VaultProvider) Scheme() string {
+	return "vault"
+}
+
+func (p *VaultProvider) Resolve(ctx context.Context, ref *url.URL) (string, errors.Error) {
+	mount, path, key := ref.Host, strings.Trim(ref.Path, "/"), ref.Fragment
+	if mount == "" || path == "" || key == "" {
+		return "", errors.BadInput.New("vault reference must look like vault://{mount}/{path}#{key}")
+	}
+	apiUrl := fmt.Sprintf("%s/v1/%s/data/%s", p.addr, mount, path)
+	if version := ref.Query().Get("version"); version != "" {
+		apiUrl += "?version=" + url.QueryEscape(version)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiUrl, nil)
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "failed to request vault")
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return "", errors.NotFound.New(fmt.Sprintf("vault secret %s/%s not found", mount, path))
+	}
+	if res.StatusCode != http.StatusOK {
+		// vault puts the reasons into the body, never the secret
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return "", errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("vault responded %d: %s", res.StatusCode, string(body)))
+	}
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", errors.Default.Wrap(err, "failed to decode vault response")
+	}
+	value, ok := body.Data.Data[key]
+	if !ok {
+		return "", errors.NotFound.New(fmt.Sprintf("key %s not found in vault secret %s/%s", key, mount, path))
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	// non-string values are handed over as json
+	s, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	return string(s), nil
+}
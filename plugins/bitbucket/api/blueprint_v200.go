@@ -125,12 +125,12 @@ func makeDataSourcePipelinePlanV200(
 			cloneUrl.User = url.UserPassword(connection.Username, connection.Password)
 			stage = append(stage, &plugin.PipelineTask{
 				Plugin: "gitextractor",
-				Options: map[string]interface{}{
+				Options: connection.GitHostKeys.AddToGitExtractorOptions(map[string]interface{}{
 					"url":    cloneUrl.String(),
 					"name":   repo.BitbucketId,
 					"repoId": didgen.NewDomainIdGenerator(&models.BitbucketRepo{}).Generate(connection.ID, repo.BitbucketId),
 					"proxy":  connection.Proxy,
-				},
+				}),
 			})
 
 		}
@@ -55,7 +55,12 @@ func TestConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 	if err != nil {
 		return nil, err
 	}
-	res, err := apiClient.Get("user", nil, nil)
+	// server has no endpoint for the current user, listing users requires a valid login though
+	testPath := "user"
+	if connection.IsServer() {
+		testPath = "rest/api/1.0/users?limit=1"
+	}
+	res, err := apiClient.Get(testPath, nil, nil)
 	if err != nil {
 		return nil, err
 	}
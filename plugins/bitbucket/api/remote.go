@@ -55,6 +55,9 @@ func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 			if err != nil {
 				return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
 			}
+			if connection.IsServer() {
+				return listServerProjects(apiClient, queryData)
+			}
 			var res *http.Response
 			query.Set("sort", "workspace.slug")
 			query.Set("fields", "values.workspace.slug,values.workspace.name,pagelen,page,size")
@@ -81,6 +84,9 @@ func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 			if err != nil {
 				return nil, errors.BadInput.Wrap(err, "failed to get create apiClient")
 			}
+			if connection.IsServer() {
+				return listServerRepos(apiClient, fmt.Sprintf("rest/api/1.0/projects/%s/repos", gid), initialServerQuery(queryData))
+			}
 			var res *http.Response
 			query.Set("fields", "values.name,values.full_name,values.language,values.description,values.owner.display_name,values.created_on,values.updated_on,values.links.clone,values.links.html,pagelen,page,size")
 			// list projects part
@@ -119,8 +125,13 @@ func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutp
 			if err != nil {
 				return nil, err
 			}
-			query := initialQuery(queryData)
 			s := queryData.Search[0]
+			if connection.IsServer() {
+				query := initialServerQuery(queryData)
+				query.Set("name", s)
+				return listServerRepos(apiClient, "rest/api/1.0/repos", query)
+			}
+			query := initialQuery(queryData)
 
 			// request search
 			query.Set("sort", "name")
@@ -152,3 +163,48 @@ func initialQuery(queryData *api.RemoteQueryData) url.Values {
 	query.Set("pagelen", fmt.Sprintf("%v", queryData.PerPage))
 	return query
 }
+
+// initialServerQuery converts the page into the start/limit pagination of Bitbucket Server
+func initialServerQuery(queryData *api.RemoteQueryData) url.Values {
+	query := url.Values{}
+	query.Set("start", fmt.Sprintf("%v", (queryData.Page-1)*queryData.PerPage))
+	query.Set("limit", fmt.Sprintf("%v", queryData.PerPage))
+	return query
+}
+
+// listServerProjects lists the projects of Bitbucket Server as groups, they take the place of cloud workspaces
+func listServerProjects(apiClient *api.ApiClient, queryData *api.RemoteQueryData) ([]models.GroupResponse, errors.Error) {
+	res, err := apiClient.Get("rest/api/1.0/projects", initialServerQuery(queryData), nil)
+	if err != nil {
+		return nil, err
+	}
+	resBody := &models.ServerProjectsResponse{}
+	err = api.UnmarshalResponse(res, resBody)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]models.GroupResponse, 0, len(resBody.Values))
+	for _, project := range resBody.Values {
+		groups = append(groups, models.GroupResponse{
+			Workspace: models.WorkspaceItem{Slug: project.Key, Name: project.Name},
+		})
+	}
+	return groups, nil
+}
+
+func listServerRepos(apiClient *api.ApiClient, path string, query url.Values) ([]models.BitbucketApiRepo, errors.Error) {
+	res, err := apiClient.Get(path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	resBody := &models.ServerReposResponse{}
+	err = api.UnmarshalResponse(res, resBody)
+	if err != nil {
+		return nil, err
+	}
+	repos := make([]models.BitbucketApiRepo, 0, len(resBody.Values))
+	for _, repo := range resBody.Values {
+		repos = append(repos, repo.ToApiRepo())
+	}
+	return repos, nil
+}
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/impl"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/tasks"
+)
+
+func TestServerCommitDataFlow(t *testing.T) {
+	var plugin impl.Bitbucket
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket", plugin)
+
+	taskData := &tasks.BitbucketTaskData{
+		Options: &tasks.BitbucketOptions{
+			ConnectionId: 1,
+			FullName:     "PRJ/repo",
+		},
+		IsServer: true,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bitbucket_server_api_commits.csv", "_raw_bitbucket_server_api_commits")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.BitbucketCommit{})
+	dataflowTester.FlushTabler(&models.BitbucketRepoCommit{})
+	dataflowTester.Subtask(tasks.ExtractApiCommitsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.BitbucketCommit{},
+		"./snapshot_tables/_tool_bitbucket_server_commits.csv",
+		e2ehelper.ColumnWithRawData(
+			"sha",
+			"author_id",
+			"author_name",
+			"author_email",
+			"authored_date",
+			"committed_date",
+			"message",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.BitbucketRepoCommit{},
+		"./snapshot_tables/_tool_bitbucket_server_repo_commits.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"repo_id",
+			"commit_sha",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.Commit{})
+	dataflowTester.FlushTabler(&code.RepoCommit{})
+	dataflowTester.Subtask(tasks.ConvertCommitsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.Commit{},
+		"./snapshot_tables/server_commits.csv",
+		[]string{
+			"sha",
+			"message",
+			"author_id",
+			"author_name",
+			"author_email",
+			"authored_date",
+			"committed_date",
+		},
+	)
+	dataflowTester.VerifyTable(
+		code.RepoCommit{},
+		"./snapshot_tables/server_repo_commits.csv",
+		[]string{
+			"repo_id",
+			"commit_sha",
+		},
+	)
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/impl"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/tasks"
+)
+
+func TestServerPrCollector(t *testing.T) {
+	var bitbucket impl.Bitbucket
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket", bitbucket)
+
+	// created through the model so the password gets encrypted
+	dataflowTester.FlushTabler(&models.BitbucketConnection{})
+	connection := &models.BitbucketConnection{
+		BaseConnection: helper.BaseConnection{Name: "bitbucket-server", Model: common.Model{ID: 1}},
+		BitbucketConn: models.BitbucketConn{
+			RestConnection: helper.RestConnection{Endpoint: "https://bitbucket.example.com/"},
+			BasicAuth:      helper.BasicAuth{Username: "devlake", Password: "secret"},
+			DeploymentType: models.DEPLOYMENT_TYPE_SERVER,
+		},
+	}
+	if err := dataflowTester.Dal.Create(connection); err != nil {
+		panic(err)
+	}
+	// the repo is known so the options are enriched without requesting it
+	dataflowTester.FlushTabler(&models.BitbucketRepo{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_bitbucket_server_repos.csv", &models.BitbucketRepo{})
+
+	// the pull requests are listed by the latest update, the collection follows nextPageStart from the full
+	// first page and stops within the second one at the first pull request updated before timeAfter
+	dataflowTester.FlushTabler(&coreModels.CollectorLatestState{})
+	dataflowTester.FlushRawTable("_raw_bitbucket_server_api_pull_requests")
+	dataflowTester.SubtaskWithCassette(tasks.CollectApiPullRequestsMeta, "./cassettes/server_pull_requests.json", map[string]interface{}{
+		"connectionId": 1,
+		"fullName":     "PRJ/repo",
+		"timeAfter":    "2023-01-01T00:00:00Z",
+	})
+
+	dataflowTester.FlushTabler(&models.BitbucketPullRequest{})
+	dataflowTester.FlushTabler(&models.BitbucketAccount{})
+	dataflowTester.FlushTabler(&models.BitbucketRepoCommit{})
+	dataflowTester.Subtask(tasks.ExtractApiPullRequestsMeta, &tasks.BitbucketTaskData{
+		Options: &tasks.BitbucketOptions{
+			ConnectionId: 1,
+			FullName:     "PRJ/repo",
+		},
+		IsServer: true,
+	})
+	dataflowTester.VerifyTable(
+		models.BitbucketPullRequest{},
+		"./snapshot_tables/_tool_bitbucket_server_pull_requests_collected.csv",
+		[]string{
+			"connection_id",
+			"repo_id",
+			"bitbucket_id",
+			"state",
+			"title",
+			"bitbucket_updated_at",
+		},
+	)
+}
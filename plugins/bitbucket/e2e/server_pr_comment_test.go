@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/impl"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/tasks"
+)
+
+func TestServerPrCommentDataFlow(t *testing.T) {
+	var plugin impl.Bitbucket
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket", plugin)
+
+	taskData := &tasks.BitbucketTaskData{
+		Options: &tasks.BitbucketOptions{
+			ConnectionId: 1,
+			FullName:     "PRJ/repo",
+		},
+		IsServer: true,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bitbucket_server_api_pull_request_activities.csv", "_raw_bitbucket_server_api_pull_request_activities")
+
+	// verify extraction, only the added comments are kept and their replies are flattened
+	dataflowTester.FlushTabler(&models.BitbucketPrComment{})
+	dataflowTester.FlushTabler(&models.BitbucketAccount{})
+	dataflowTester.Subtask(tasks.ExtractApiPrCommentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.BitbucketPrComment{},
+		"./snapshot_tables/_tool_bitbucket_server_pull_request_comments.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"bitbucket_id",
+			"repo_id",
+			"pull_request_id",
+			"author_id",
+			"author_name",
+			"bitbucket_created_at",
+			"bitbucket_updated_at",
+			"type",
+			"body",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.Subtask(tasks.ConvertPrCommentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestComment{},
+		"./snapshot_tables/server_pull_request_comments.csv",
+		[]string{
+			"id",
+			"pull_request_id",
+			"body",
+			"account_id",
+			"type",
+		},
+	)
+}
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/impl"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/tasks"
+)
+
+func TestServerPrCommitDataFlow(t *testing.T) {
+	var plugin impl.Bitbucket
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket", plugin)
+
+	taskData := &tasks.BitbucketTaskData{
+		Options: &tasks.BitbucketOptions{
+			ConnectionId: 1,
+			FullName:     "PRJ/repo",
+		},
+		IsServer: true,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bitbucket_server_api_pull_request_commits.csv", "_raw_bitbucket_server_api_pull_request_commits")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.BitbucketPrCommit{})
+	dataflowTester.FlushTabler(&models.BitbucketCommit{})
+	dataflowTester.FlushTabler(&models.BitbucketRepoCommit{})
+	dataflowTester.Subtask(tasks.ExtractApiPrCommitsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.BitbucketPrCommit{},
+		"./snapshot_tables/_tool_bitbucket_server_pull_request_commits.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"repo_id",
+			"pull_request_id",
+			"commit_sha",
+			"commit_author_name",
+			"commit_author_email",
+			"commit_authored_date",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.BitbucketCommit{},
+		"./snapshot_tables/_tool_bitbucket_server_commits_in_pr.csv",
+		e2ehelper.ColumnWithRawData(
+			"sha",
+			"author_id",
+			"author_name",
+			"author_email",
+			"authored_date",
+			"committed_date",
+			"message",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.BitbucketRepoCommit{},
+		"./snapshot_tables/_tool_bitbucket_server_repo_commits_in_pr_commits.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"repo_id",
+			"commit_sha",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.PullRequestCommit{})
+	dataflowTester.Subtask(tasks.ConvertPrCommitsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestCommit{},
+		"./snapshot_tables/server_pull_request_commits.csv",
+		[]string{
+			"commit_sha",
+			"pull_request_id",
+			"commit_author_name",
+			"commit_author_email",
+			"commit_authored_date",
+		},
+	)
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/impl"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/tasks"
+)
+
+func TestServerPrDataFlow(t *testing.T) {
+	var plugin impl.Bitbucket
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "bitbucket", plugin)
+
+	taskData := &tasks.BitbucketTaskData{
+		Options: &tasks.BitbucketOptions{
+			ConnectionId: 1,
+			FullName:     "PRJ/repo",
+		},
+		IsServer: true,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_bitbucket_server_api_pull_requests.csv", "_raw_bitbucket_server_api_pull_requests")
+
+	// verify pr extraction
+	dataflowTester.FlushTabler(&models.BitbucketPullRequest{})
+	dataflowTester.FlushTabler(&models.BitbucketAccount{})
+	dataflowTester.FlushTabler(&models.BitbucketRepoCommit{})
+	dataflowTester.Subtask(tasks.ExtractApiPullRequestsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.BitbucketPullRequest{},
+		"./snapshot_tables/_tool_bitbucket_server_pull_requests.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"repo_id",
+			"bitbucket_id",
+			"number",
+			"base_repo_id",
+			"head_repo_id",
+			"state",
+			"title",
+			"description",
+			"bitbucket_created_at",
+			"bitbucket_updated_at",
+			"closed_at",
+			"comment_count",
+			"merged_at",
+			"merge_commit_sha",
+			"head_ref",
+			"base_ref",
+			"base_commit_sha",
+			"head_commit_sha",
+			"url",
+			"author_name",
+			"author_id",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.BitbucketAccount{},
+		"./snapshot_tables/_tool_bitbucket_server_accounts.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"account_id",
+			"user_name",
+			"account_status",
+			"display_name",
+			"html_url",
+		),
+	)
+	// the merge commit of a merged pull request is linked to the repo
+	dataflowTester.VerifyTable(
+		models.BitbucketRepoCommit{},
+		"./snapshot_tables/_tool_bitbucket_server_repo_commits_in_pr.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"repo_id",
+			"commit_sha",
+		),
+	)
+
+	// verify pr conversion
+	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.Subtask(tasks.ConvertPullRequestsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequest{},
+		"./snapshot_tables/server_pull_requests.csv",
+		[]string{
+			"id",
+			"base_repo_id",
+			"head_repo_id",
+			"status",
+			"original_status",
+			"title",
+			"description",
+			"url",
+			"author_name",
+			"author_id",
+			"pull_request_key",
+			"created_date",
+			"merged_date",
+			"closed_date",
+			"merge_commit_sha",
+			"head_ref",
+			"base_ref",
+			"base_commit_sha",
+			"head_commit_sha",
+		},
+	)
+}
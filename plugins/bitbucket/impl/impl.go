@@ -158,7 +158,7 @@ func (p Bitbucket) PrepareTaskData(taskCtx plugin.TaskContext, options map[strin
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "unable to get bitbucket API client instance")
 	}
-	err = EnrichOptions(taskCtx, op, apiClient.ApiClient)
+	err = EnrichOptions(taskCtx, op, connection, apiClient.ApiClient)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +181,7 @@ func (p Bitbucket) PrepareTaskData(taskCtx plugin.TaskContext, options map[strin
 		Options:       op,
 		ApiClient:     apiClient,
 		RegexEnricher: regexEnricher,
+		IsServer:      connection.IsServer(),
 	}
 	if !timeAfter.IsZero() {
 		taskData.TimeAfter = &timeAfter
@@ -254,6 +255,7 @@ func (p Bitbucket) Close(taskCtx plugin.TaskContext) errors.Error {
 
 func EnrichOptions(taskCtx plugin.TaskContext,
 	op *tasks.BitbucketOptions,
+	connection *models.BitbucketConnection,
 	apiClient *helper.ApiClient) errors.Error {
 	var repo models.BitbucketRepo
 	// validate the op and set name=owner/repo if this is from advanced mode or bpV100
@@ -272,7 +274,11 @@ func EnrichOptions(taskCtx plugin.TaskContext,
 	} else {
 		if taskCtx.GetDal().IsErrorNotFound(err) && op.FullName != "" {
 			var repo *models.BitbucketApiRepo
-			repo, err = tasks.GetApiRepo(op, apiClient)
+			if connection.IsServer() {
+				repo, err = tasks.GetServerApiRepo(op, apiClient)
+			} else {
+				repo, err = tasks.GetApiRepo(op, apiClient)
+			}
 			if err != nil {
 				return err
 			}
@@ -24,16 +24,30 @@ import (
 
 var _ plugin.ApiConnection = (*BitbucketConnection)(nil)
 
+const (
+	DEPLOYMENT_TYPE_CLOUD  = "cloud"
+	DEPLOYMENT_TYPE_SERVER = "server"
+)
+
 // BitbucketConn holds the essential information to connect to the Bitbucket API
 type BitbucketConn struct {
 	api.RestConnection `mapstructure:",squash"`
 	api.BasicAuth      `mapstructure:",squash"`
+	// DeploymentType is either cloud (Bitbucket Cloud 2.0 API, the default) or server (Bitbucket Server / Data Center),
+	// the Endpoint of a server connection is the root url of the instance, e.g. https://bitbucket.example.com/
+	DeploymentType string `mapstructure:"deploymentType" json:"deploymentType" validate:"omitempty,oneof=cloud server" gorm:"type:varchar(20)"`
+}
+
+// IsServer returns true when the connection points to a Bitbucket Server / Data Center instance
+func (conn *BitbucketConn) IsServer() bool {
+	return conn.DeploymentType == DEPLOYMENT_TYPE_SERVER
 }
 
 // BitbucketConnection holds BitbucketConn plus ID/Name for database storage
 type BitbucketConnection struct {
 	api.BaseConnection `mapstructure:",squash"`
 	BitbucketConn      `mapstructure:",squash"`
+	api.GitHostKeys    `mapstructure:",squash"`
 }
 
 func (BitbucketConnection) TableName() string {
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addDeploymentTypeToConnection)(nil)
+
+type bitbucketConnection20230621 struct {
+	DeploymentType string `gorm:"type:varchar(20)"`
+}
+
+func (bitbucketConnection20230621) TableName() string {
+	return "_tool_bitbucket_connections"
+}
+
+type addDeploymentTypeToConnection struct{}
+
+func (script *addDeploymentTypeToConnection) Up(basicRes context.BasicRes) errors.Error {
+	err := migrationhelper.AutoMigrateTables(basicRes, &bitbucketConnection20230621{})
+	if err != nil {
+		return err
+	}
+	// all the existing connections talk to Bitbucket Cloud
+	return basicRes.GetDal().UpdateColumn(
+		&bitbucketConnection20230621{},
+		"deployment_type", "cloud",
+		dal.Where("deployment_type IS NULL OR deployment_type = ''"),
+	)
+}
+
+func (*addDeploymentTypeToConnection) Version() uint64 {
+	return 20230621000001
+}
+
+func (*addDeploymentTypeToConnection) Name() string {
+	return "add deployment_type to _tool_bitbucket_connections"
+}
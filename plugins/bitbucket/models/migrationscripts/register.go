@@ -37,5 +37,7 @@ func All() []plugin.MigrationScript {
 		new(addRepoIdToPr),
 		new(addBitbucketCommitAuthorInfo),
 		new(renameTr2ScopeConfig),
+		new(addDeploymentTypeToConnection),
+		new(addHostKeysToConnection),
 	}
 }
@@ -18,6 +18,8 @@ limitations under the License.
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/models/common"
@@ -150,3 +152,61 @@ type BitbucketApiParams struct {
 	ConnectionId uint64
 	FullName     string
 }
+
+// BitbucketServerApiRepo is a repository returned by the Bitbucket Server / Data Center rest/api/1.0
+type BitbucketServerApiRepo struct {
+	Id          int    `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Project     struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	} `json:"project"`
+	Links struct {
+		Clone []struct {
+			Href string `json:"href"`
+			Name string `json:"name"`
+		} `json:"clone"`
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+// ToApiRepo converts the server repo into the cloud shape so both share the scope handling,
+// FullName is `{projectKey}/{repoSlug}` the same way cloud uses `{workspace}/{repoSlug}`
+func (b BitbucketServerApiRepo) ToApiRepo() BitbucketApiRepo {
+	repo := BitbucketApiRepo{
+		Name:        b.Name,
+		FullName:    fmt.Sprintf("%s/%s", b.Project.Key, b.Slug),
+		Description: b.Description,
+	}
+	repo.Owner.Displayname = b.Project.Name
+	if len(b.Links.Self) > 0 {
+		repo.Links.Html.Href = strings.TrimSuffix(b.Links.Self[0].Href, "/browse")
+	}
+	for _, u := range b.Links.Clone {
+		// server names its https clone link `http`
+		if u.Name == "http" || u.Name == "https" {
+			repo.Links.Clone = append(repo.Links.Clone, struct {
+				Href string `json:"href"`
+				Name string `json:"name"`
+			}{Href: u.Href, Name: "https"})
+		}
+	}
+	return repo
+}
+
+type ServerProjectsResponse struct {
+	Values []struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	} `json:"values"`
+	IsLastPage bool `json:"isLastPage"`
+}
+
+type ServerReposResponse struct {
+	Values     []BitbucketServerApiRepo `json:"values"`
+	IsLastPage bool                     `json:"isLastPage"`
+}
@@ -32,9 +32,13 @@ var CollectApiCommitsMeta = plugin.SubTaskMeta{
 	Required:         false,
 	Description:      "Collect commits data from Bitbucket api",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	if isServer(taskCtx) {
+		return collectServerCommits(taskCtx)
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMIT_TABLE)
 
 	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
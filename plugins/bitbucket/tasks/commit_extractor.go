@@ -59,6 +59,9 @@ type CommitsResponse struct {
 }
 
 func ExtractApiCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	if isServer(taskCtx) {
+		return extractServerCommits(taskCtx)
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMIT_TABLE)
 
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
@@ -31,9 +31,14 @@ var CollectApiDeploymentsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect deployment data from bitbucket api",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	// deployments are a Bitbucket Pipelines feature which server lacks
+	if isServer(taskCtx) {
+		return nil
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPLOYMENT_TABLE)
 
 	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
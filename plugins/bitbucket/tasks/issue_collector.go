@@ -31,9 +31,14 @@ var CollectApiIssuesMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect issues data from Bitbucket api",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
+	// issues are tracked outside of Bitbucket Server
+	if isServer(taskCtx) {
+		return nil
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ISSUE_TABLE)
 	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
 	if err != nil {
@@ -32,9 +32,14 @@ var CollectApiIssueCommentsMeta = plugin.SubTaskMeta{
 	Required:         false,
 	Description:      "Collect issue comments data from bitbucket api",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiIssueComments(taskCtx plugin.SubTaskContext) errors.Error {
+	// issues are tracked outside of Bitbucket Server
+	if isServer(taskCtx) {
+		return nil
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ISSUE_COMMENTS_TABLE)
 	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
 	if err != nil {
@@ -31,9 +31,13 @@ var CollectApiPipelinesMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect pipeline data from bitbucket api",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiPipelines(taskCtx plugin.SubTaskContext) errors.Error {
+	if isServer(taskCtx) {
+		return collectServerBuildStatuses(taskCtx)
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PIPELINE_TABLE)
 	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
 	if err != nil {
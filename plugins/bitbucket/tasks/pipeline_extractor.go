@@ -77,6 +77,9 @@ var ExtractApiPipelinesMeta = plugin.SubTaskMeta{
 }
 
 func ExtractApiPipelines(taskCtx plugin.SubTaskContext) errors.Error {
+	if isServer(taskCtx) {
+		return extractServerBuildStatuses(taskCtx)
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PIPELINE_TABLE)
 
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
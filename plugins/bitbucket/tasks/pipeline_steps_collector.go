@@ -33,9 +33,14 @@ var CollectPipelineStepsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect PipelineSteps data from Bitbucket api",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectPipelineSteps(taskCtx plugin.SubTaskContext) errors.Error {
+	// pipelines of server are build statuses, which have no steps
+	if isServer(taskCtx) {
+		return nil
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PIPELINE_STEPS_TABLE)
 
 	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
@@ -34,9 +34,13 @@ var CollectApiPullRequestsMeta = plugin.SubTaskMeta{
 	Required:         false,
 	Description:      "Collect PullRequests data from Bitbucket api",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	if isServer(taskCtx) {
+		return collectServerPullRequests(taskCtx)
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
 	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
 	if err != nil {
@@ -32,9 +32,13 @@ var CollectApiPrCommentsMeta = plugin.SubTaskMeta{
 	Required:         false,
 	Description:      "Collect pull requests comments data from bitbucket api",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiPullRequestsComments(taskCtx plugin.SubTaskContext) errors.Error {
+	if isServer(taskCtx) {
+		return collectServerPullRequestActivities(taskCtx)
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_COMMENTS_TABLE)
 	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
 	if err != nil {
@@ -51,6 +51,9 @@ type BitbucketPrCommentsResponse struct {
 }
 
 func ExtractApiPullRequestsComments(taskCtx plugin.SubTaskContext) errors.Error {
+	if isServer(taskCtx) {
+		return extractServerPullRequestActivities(taskCtx)
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_COMMENTS_TABLE)
 
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
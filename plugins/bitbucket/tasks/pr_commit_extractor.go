@@ -55,6 +55,9 @@ type ApiPrCommitsResponse struct {
 }
 
 func ExtractApiPullRequestCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	if isServer(taskCtx) {
+		return extractServerPullRequestCommits(taskCtx)
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_COMMITS_TABLE)
 	repoId := data.Options.FullName
 	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
@@ -81,6 +81,9 @@ type BitbucketApiPullRequest struct {
 }
 
 func ExtractApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	if isServer(taskCtx) {
+		return extractServerPullRequests(taskCtx)
+	}
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
 	var err errors.Error
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
@@ -0,0 +1,154 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	aha "github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+// BitbucketServerPagination is the paged response of Bitbucket Server / Data Center rest apis
+type BitbucketServerPagination struct {
+	Values        []json.RawMessage `json:"values"`
+	Size          int               `json:"size"`
+	Limit         int               `json:"limit"`
+	Start         int               `json:"start"`
+	IsLastPage    bool              `json:"isLastPage"`
+	NextPageStart *int              `json:"nextPageStart"`
+}
+
+type BitbucketServerUser struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+	Active       bool   `json:"active"`
+	Links        struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+func isServer(taskCtx plugin.SubTaskContext) bool {
+	return taskCtx.GetData().(*BitbucketTaskData).IsServer
+}
+
+// serverRepoPath returns the rest/api/1.0 path of a repo, fullName is `{projectKey}/{repoSlug}`
+func serverRepoPath(fullName string) string {
+	projectKey, repoSlug := fullName, ""
+	if i := strings.Index(fullName, "/"); i >= 0 {
+		projectKey, repoSlug = fullName[:i], fullName[i+1:]
+	}
+	return fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s", projectKey, repoSlug)
+}
+
+// serverTime converts the epoch milliseconds used by the server api
+func serverTime(millis int64) *time.Time {
+	if millis <= 0 {
+		return nil
+	}
+	t := time.UnixMilli(millis)
+	return &t
+}
+
+func GetServerQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+	if reqData.CustomData != nil {
+		query.Set("start", fmt.Sprintf("%v", reqData.CustomData))
+	}
+	return query, nil
+}
+
+func GetServerNextPageCustomData(_ *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+	page := &BitbucketServerPagination{}
+	err := decodeResponse(prevPageResponse, page)
+	if err != nil {
+		return nil, err
+	}
+	if page.IsLastPage || page.NextPageStart == nil {
+		return nil, api.ErrFinishCollect
+	}
+	return *page.NextPageStart, nil
+}
+
+func GetServerRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	page := &BitbucketServerPagination{}
+	err := decodeResponse(res, page)
+	if err != nil {
+		return nil, err
+	}
+	return page.Values, nil
+}
+
+func convertServerAccount(user *BitbucketServerUser, connId uint64) *models.BitbucketAccount {
+	account := &models.BitbucketAccount{
+		ConnectionId:  connId,
+		AccountId:     strconv.Itoa(user.Id),
+		UserName:      user.Name,
+		DisplayName:   user.DisplayName,
+		AccountStatus: "inactive",
+	}
+	if user.Active {
+		account.AccountStatus = "active"
+	}
+	if len(user.Links.Self) > 0 {
+		account.HtmlUrl = user.Links.Self[0].Href
+	}
+	return account
+}
+
+// GetServerApiRepo fetches the repo from a Bitbucket Server / Data Center instance
+func GetServerApiRepo(
+	op *BitbucketOptions,
+	apiClient aha.ApiClientAbstract,
+) (*models.BitbucketApiRepo, errors.Error) {
+	res, err := apiClient.Get(serverRepoPath(op.FullName), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf(
+			"unexpected status code when requesting repo detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	serverRepo := &models.BitbucketServerApiRepo{}
+	err = api.UnmarshalResponse(res, serverRepo)
+	if err != nil {
+		return nil, err
+	}
+	apiRepo := serverRepo.ToApiRepo()
+	if len(apiRepo.Links.Clone) == 0 {
+		return nil, errors.Default.New("no clone url")
+	}
+	return &apiRepo, nil
+}
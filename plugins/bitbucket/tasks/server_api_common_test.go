@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerRepoPath(t *testing.T) {
+	assert.Equal(t, "rest/api/1.0/projects/PRJ/repos/my-repo", serverRepoPath("PRJ/my-repo"))
+}
+
+func TestConvertServerPullRequest(t *testing.T) {
+	raw := `{
+		"id": 12, "title": "feat", "description": "desc", "state": "MERGED",
+		"createdDate": 1686787200000, "updatedDate": 1686873600000, "closedDate": 1686873600000,
+		"fromRef": {"id": "refs/heads/feature", "latestCommit": "aaa", "repository": {"slug": "repo", "project": {"key": "PRJ"}}},
+		"toRef": {"id": "refs/heads/main", "latestCommit": "bbb", "repository": {"slug": "repo", "project": {"key": "PRJ"}}},
+		"properties": {"commentCount": 3, "mergeCommit": {"id": "ccc"}},
+		"links": {"self": [{"href": "https://bitbucket.example.com/projects/PRJ/repos/repo/pull-requests/12"}]}
+	}`
+	apiPr := &BitbucketServerApiPullRequest{}
+	assert.Nil(t, json.Unmarshal([]byte(raw), apiPr))
+
+	pr := convertServerPullRequest(apiPr, 1, "PRJ/repo")
+	assert.Equal(t, 12, pr.Number)
+	assert.Equal(t, "MERGED", pr.State)
+	assert.Equal(t, "feature", pr.HeadRef)
+	assert.Equal(t, "main", pr.BaseRef)
+	assert.Equal(t, "PRJ/repo", pr.HeadRepoId)
+	assert.Equal(t, "aaa", pr.HeadCommitSha)
+	assert.Equal(t, "ccc", pr.MergeCommitSha)
+	assert.Equal(t, 3, pr.CommentCount)
+	assert.Equal(t, time.UnixMilli(1686787200000), pr.BitbucketCreatedAt)
+	assert.Equal(t, time.UnixMilli(1686873600000), *pr.MergedAt)
+	assert.Equal(t, "https://bitbucket.example.com/projects/PRJ/repos/repo/pull-requests/12", pr.Url)
+}
+
+func TestConvertServerBuildStatus(t *testing.T) {
+	pipeline := convertServerBuildStatus(&BitbucketServerApiBuildStatus{
+		State:     "SUCCESSFUL",
+		Key:       "PLAN-1",
+		Ref:       "refs/heads/main",
+		DateAdded: 1686787260000,
+		Duration:  60000,
+	}, "aaa", 1, "PRJ/repo")
+	assert.Equal(t, "aaa:PLAN-1", pipeline.BitbucketId)
+	assert.Equal(t, "main", pipeline.RefName)
+	assert.Equal(t, models.COMPLETED, pipeline.Status)
+	assert.Equal(t, models.SUCCESSFUL, pipeline.Result)
+	assert.Equal(t, uint64(60), pipeline.DurationInSeconds)
+	assert.Equal(t, time.UnixMilli(1686787200000), *pipeline.BitbucketCreatedOn)
+	assert.Equal(t, time.UnixMilli(1686787260000), *pipeline.BitbucketCompleteOn)
+
+	pipeline = convertServerBuildStatus(&BitbucketServerApiBuildStatus{State: "INPROGRESS", Key: "PLAN-1", DateAdded: 1686787260000}, "aaa", 1, "PRJ/repo")
+	assert.Equal(t, models.IN_PROGRESS, pipeline.Status)
+	assert.Nil(t, pipeline.BitbucketCompleteOn)
+}
+
+func TestServerApiRepoToApiRepo(t *testing.T) {
+	raw := `{
+		"slug": "repo", "name": "Repo", "project": {"key": "PRJ", "name": "Project"},
+		"links": {
+			"clone": [{"href": "ssh://git@bitbucket.example.com:7999/prj/repo.git", "name": "ssh"}, {"href": "https://bitbucket.example.com/scm/prj/repo.git", "name": "http"}],
+			"self": [{"href": "https://bitbucket.example.com/projects/PRJ/repos/repo/browse"}]
+		}
+	}`
+	serverRepo := &models.BitbucketServerApiRepo{}
+	assert.Nil(t, json.Unmarshal([]byte(raw), serverRepo))
+
+	scope := serverRepo.ToApiRepo().ConvertApiScope().(*models.BitbucketRepo)
+	assert.Equal(t, "PRJ/repo", scope.BitbucketId)
+	assert.Equal(t, "Project", scope.Owner)
+	assert.Equal(t, "https://bitbucket.example.com/projects/PRJ/repos/repo", scope.HTMLUrl)
+	assert.Equal(t, "https://bitbucket.example.com/scm/prj/repo.git", scope.CloneUrl)
+}
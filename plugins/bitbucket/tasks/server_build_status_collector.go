@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+const RAW_SERVER_BUILD_STATUS_TABLE = "bitbucket_server_api_build_statuses"
+
+type BitbucketCommitInput struct {
+	CommitSha string
+}
+
+// collectServerBuildStatuses collects the build statuses reported by CI servers for the known commits of the repo,
+// they take the place of Bitbucket Pipelines which do not exist on server. Statuses are requested per commit, so an
+// incremental collection only requests the commits committed since the last collection and those with builds in progress.
+func collectServerBuildStatuses(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SERVER_BUILD_STATUS_TABLE)
+	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	db := taskCtx.GetDal()
+	clauses := []dal.Clause{
+		dal.Select("rc.commit_sha"),
+		dal.From("_tool_bitbucket_repo_commits rc"),
+		dal.Join("LEFT JOIN _tool_bitbucket_commits c ON (c.sha = rc.commit_sha)"),
+		dal.Where("rc.connection_id = ? AND rc.repo_id = ?", data.Options.ConnectionId, data.Options.FullName),
+	}
+	if collectorWithState.IsIncremental() {
+		clauses = append(clauses, dal.Where(
+			`c.committed_date > ? OR rc.commit_sha IN (
+				SELECT commit_sha FROM _tool_bitbucket_pipelines WHERE connection_id = ? AND repo_id = ? AND status = ?
+			)`,
+			*collectorWithState.LatestState.LatestSuccessStart,
+			data.Options.ConnectionId, data.Options.FullName, models.IN_PROGRESS,
+		))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(BitbucketCommitInput{}))
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:             data.ApiClient,
+		PageSize:              100,
+		Incremental:           collectorWithState.IsIncremental(),
+		Input:                 iterator,
+		UrlTemplate:           "rest/build-status/1.0/commits/{{ .Input.CommitSha }}",
+		Query:                 GetServerQuery,
+		GetNextPageCustomData: GetServerNextPageCustomData,
+		ResponseParser:        GetServerRawMessageFromResponse,
+		AfterResponse:         ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+type BitbucketServerApiBuildStatus struct {
+	State     string `json:"state"`
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Url       string `json:"url"`
+	DateAdded int64  `json:"dateAdded"`
+	// ref and duration are only reported by Bitbucket Data Center 7.4+
+	Ref      string `json:"ref"`
+	Duration int64  `json:"duration"`
+}
+
+func extractServerBuildStatuses(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SERVER_BUILD_STATUS_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			buildStatus := &BitbucketServerApiBuildStatus{}
+			err := errors.Convert(json.Unmarshal(row.Data, buildStatus))
+			if err != nil {
+				return nil, err
+			}
+			input := &BitbucketCommitInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			bitbucketPipeline := convertServerBuildStatus(buildStatus, input.CommitSha, data.Options.ConnectionId, data.Options.FullName)
+			// builds on server are told apart by their name rather than the ref
+			bitbucketPipeline.Type = data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, buildStatus.Name)
+			bitbucketPipeline.Environment = data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, buildStatus.Name)
+			return []interface{}{bitbucketPipeline}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+// convertServerBuildStatus maps a build status onto the pipeline tool layer shared with cloud,
+// a commit holds the latest status of each build key so the pair identifies the pipeline
+func convertServerBuildStatus(buildStatus *BitbucketServerApiBuildStatus, commitSha string, connId uint64, repoId string) *models.BitbucketPipeline {
+	bitbucketPipeline := &models.BitbucketPipeline{
+		ConnectionId:       connId,
+		BitbucketId:        fmt.Sprintf("%s:%s", commitSha, buildStatus.Key),
+		RepoId:             repoId,
+		CommitSha:          commitSha,
+		RefName:            strings.TrimPrefix(buildStatus.Ref, "refs/heads/"),
+		WebUrl:             buildStatus.Url,
+		DurationInSeconds:  uint64(buildStatus.Duration / 1000),
+		BitbucketCreatedOn: serverTime(buildStatus.DateAdded),
+	}
+	switch buildStatus.State {
+	case "INPROGRESS":
+		bitbucketPipeline.Status = models.IN_PROGRESS
+	case "CANCELLED":
+		bitbucketPipeline.Status = models.COMPLETED
+		bitbucketPipeline.Result = models.STOPPED
+	default:
+		bitbucketPipeline.Status = models.COMPLETED
+		bitbucketPipeline.Result = buildStatus.State
+	}
+	// dateAdded is the time of the latest status, which is when the build finished once completed
+	if bitbucketPipeline.Status == models.COMPLETED && bitbucketPipeline.BitbucketCreatedOn != nil {
+		completeOn := *bitbucketPipeline.BitbucketCreatedOn
+		createdOn := completeOn.Add(-time.Duration(buildStatus.Duration) * time.Millisecond)
+		bitbucketPipeline.BitbucketCompleteOn = &completeOn
+		bitbucketPipeline.BitbucketCreatedOn = &createdOn
+	}
+	return bitbucketPipeline
+}
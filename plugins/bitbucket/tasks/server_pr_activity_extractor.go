@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+type BitbucketServerApiComment struct {
+	Id          int                  `json:"id"`
+	Text        string               `json:"text"`
+	Author      *BitbucketServerUser `json:"author"`
+	CreatedDate int64                `json:"createdDate"`
+	UpdatedDate int64                `json:"updatedDate"`
+	// replies are nested into the comment they reply to
+	Comments []*BitbucketServerApiComment `json:"comments"`
+}
+
+type BitbucketServerApiPrActivity struct {
+	Id            int                        `json:"id"`
+	Action        string                     `json:"action"`
+	CommentAction string                     `json:"commentAction"`
+	Comment       *BitbucketServerApiComment `json:"comment"`
+	CommentAnchor *struct {
+		Path string `json:"path"`
+	} `json:"commentAnchor"`
+}
+
+func extractServerPullRequestActivities(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SERVER_PULL_REQUEST_ACTIVITIES_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			activity := &BitbucketServerApiPrActivity{}
+			err := errors.Convert(json.Unmarshal(row.Data, activity))
+			if err != nil {
+				return nil, err
+			}
+			// only the activity adding a comment carries the comment thread
+			if activity.Action != "COMMENTED" || activity.CommentAction != "ADDED" || activity.Comment == nil {
+				return nil, nil
+			}
+			pull := &BitbucketInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, pull))
+			if err != nil {
+				return nil, err
+			}
+			commentType := ""
+			if activity.CommentAnchor != nil {
+				commentType = "diffNote"
+			}
+			return flattenServerComments(activity.Comment, commentType, pull.BitbucketId, data), nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func flattenServerComments(comment *BitbucketServerApiComment, commentType string, pullRequestId int, data *BitbucketTaskData) []interface{} {
+	results := make([]interface{}, 0, 2)
+	prComment := &models.BitbucketPrComment{
+		ConnectionId:       data.Options.ConnectionId,
+		BitbucketId:        comment.Id,
+		RepoId:             data.Options.FullName,
+		PullRequestId:      pullRequestId,
+		BitbucketUpdatedAt: serverTime(comment.UpdatedDate),
+		Type:               commentType,
+		Body:               comment.Text,
+	}
+	if createdAt := serverTime(comment.CreatedDate); createdAt != nil {
+		prComment.BitbucketCreatedAt = *createdAt
+	}
+	if comment.Author != nil {
+		bitbucketUser := convertServerAccount(comment.Author, data.Options.ConnectionId)
+		prComment.AuthorId = bitbucketUser.AccountId
+		prComment.AuthorName = bitbucketUser.DisplayName
+		results = append(results, bitbucketUser)
+	}
+	results = append(results, prComment)
+	for _, reply := range comment.Comments {
+		results = append(results, flattenServerComments(reply, commentType, pullRequestId, data)...)
+	}
+	return results
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_SERVER_PULL_REQUEST_TABLE = "bitbucket_server_api_pull_requests"
+
+type simpleServerPullRequest struct {
+	UpdatedDate int64 `json:"updatedDate"`
+}
+
+// collectServerPullRequests collects the pull requests of a Bitbucket Server repo updated since the last collection
+// or timeAfter. The server api can not filter pull requests by updated time but lists the most recently updated
+// ones first, so the collection stops at the first pull request updated before that.
+func collectServerPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SERVER_PULL_REQUEST_TABLE)
+	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+	updatedAfter := collectorWithState.TimeAfter
+	if collectorWithState.IsIncremental() {
+		updatedAfter = collectorWithState.LatestState.LatestSuccessStart
+	}
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		Incremental: collectorWithState.IsIncremental(),
+		UrlTemplate: serverRepoPath(data.Options.FullName) + "/pull-requests",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query, err := GetServerQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			query.Set("state", "ALL")
+			query.Set("order", "NEWEST")
+			return query, nil
+		},
+		GetNextPageCustomData: GetServerNextPageCustomData,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			items, err := GetServerRawMessageFromResponse(res)
+			if err != nil || updatedAfter == nil {
+				return items, err
+			}
+			for i, item := range items {
+				pr := &simpleServerPullRequest{}
+				err = errors.Convert(json.Unmarshal(item, pr))
+				if err != nil {
+					return nil, err
+				}
+				if updatedAt := serverTime(pr.UpdatedDate); updatedAt != nil && updatedAt.Before(*updatedAfter) {
+					return items[:i], helper.ErrFinishCollect
+				}
+			}
+			return items, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+type bitbucketServerApiPerson struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+}
+
+// BitbucketServerApiCommit is the commit returned by both the commits and the pull request commits apis
+type BitbucketServerApiCommit struct {
+	Id                 string                   `json:"id"`
+	Message            string                   `json:"message"`
+	Author             bitbucketServerApiPerson `json:"author"`
+	AuthorTimestamp    int64                    `json:"authorTimestamp"`
+	CommitterTimestamp int64                    `json:"committerTimestamp"`
+}
+
+func convertServerCommit(commit *BitbucketServerApiCommit) *models.BitbucketCommit {
+	bitbucketCommit := &models.BitbucketCommit{
+		Sha:         commit.Id,
+		Message:     commit.Message,
+		AuthorName:  commit.Author.DisplayName,
+		AuthorEmail: commit.Author.EmailAddress,
+	}
+	if bitbucketCommit.AuthorName == "" {
+		bitbucketCommit.AuthorName = commit.Author.Name
+	}
+	// the author is only linked to a user when the email matches a server account
+	if commit.Author.Id != 0 {
+		bitbucketCommit.AuthorId = strconv.Itoa(commit.Author.Id)
+	}
+	if authoredDate := serverTime(commit.AuthorTimestamp); authoredDate != nil {
+		bitbucketCommit.AuthoredDate = *authoredDate
+	}
+	if committedDate := serverTime(commit.CommitterTimestamp); committedDate != nil {
+		bitbucketCommit.CommittedDate = *committedDate
+	}
+	return bitbucketCommit
+}
+
+func extractServerPullRequestCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SERVER_PULL_REQUEST_COMMITS_TABLE)
+	repoId := data.Options.FullName
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *helper.RawData) ([]interface{}, errors.Error) {
+			apiCommit := &BitbucketServerApiCommit{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiCommit))
+			if err != nil {
+				return nil, err
+			}
+			if apiCommit.Id == "" {
+				return nil, nil
+			}
+			pull := &BitbucketInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, pull))
+			if err != nil {
+				return nil, err
+			}
+			bitbucketCommit := convertServerCommit(apiCommit)
+			return []interface{}{
+				&models.BitbucketRepoCommit{
+					ConnectionId: data.Options.ConnectionId,
+					RepoId:       repoId,
+					CommitSha:    apiCommit.Id,
+				},
+				bitbucketCommit,
+				&models.BitbucketPrCommit{
+					ConnectionId:       data.Options.ConnectionId,
+					RepoId:             repoId,
+					PullRequestId:      pull.BitbucketId,
+					CommitSha:          apiCommit.Id,
+					CommitAuthorName:   bitbucketCommit.AuthorName,
+					CommitAuthorEmail:  bitbucketCommit.AuthorEmail,
+					CommitAuthoredDate: bitbucketCommit.AuthoredDate,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
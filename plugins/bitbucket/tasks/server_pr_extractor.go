@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+)
+
+type bitbucketServerApiRef struct {
+	Id           string `json:"id"`
+	DisplayId    string `json:"displayId"`
+	LatestCommit string `json:"latestCommit"`
+	Repository   struct {
+		Slug    string `json:"slug"`
+		Project struct {
+			Key string `json:"key"`
+		} `json:"project"`
+	} `json:"repository"`
+}
+
+func (ref *bitbucketServerApiRef) repoId() string {
+	if ref.Repository.Slug == "" {
+		return ""
+	}
+	return ref.Repository.Project.Key + "/" + ref.Repository.Slug
+}
+
+type BitbucketServerApiPullRequest struct {
+	Id          int                   `json:"id"`
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	State       string                `json:"state"`
+	CreatedDate int64                 `json:"createdDate"`
+	UpdatedDate int64                 `json:"updatedDate"`
+	ClosedDate  int64                 `json:"closedDate"`
+	FromRef     bitbucketServerApiRef `json:"fromRef"`
+	ToRef       bitbucketServerApiRef `json:"toRef"`
+	Author      *struct {
+		User *BitbucketServerUser `json:"user"`
+	} `json:"author"`
+	Properties struct {
+		CommentCount int `json:"commentCount"`
+		MergeCommit  *struct {
+			Id string `json:"id"`
+		} `json:"mergeCommit"`
+	} `json:"properties"`
+	Links struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+func extractServerPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SERVER_PULL_REQUEST_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiPr := &BitbucketServerApiPullRequest{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiPr))
+			if err != nil {
+				return nil, err
+			}
+			if apiPr.Id == 0 {
+				return nil, nil
+			}
+			results := make([]interface{}, 0, 3)
+			bitbucketPr := convertServerPullRequest(apiPr, data.Options.ConnectionId, data.Options.FullName)
+			if apiPr.Author != nil && apiPr.Author.User != nil {
+				bitbucketUser := convertServerAccount(apiPr.Author.User, data.Options.ConnectionId)
+				results = append(results, bitbucketUser)
+				bitbucketPr.AuthorName = bitbucketUser.DisplayName
+				bitbucketPr.AuthorId = bitbucketUser.AccountId
+			}
+			if bitbucketPr.MergeCommitSha != "" {
+				results = append(results, &models.BitbucketRepoCommit{
+					ConnectionId: data.Options.ConnectionId,
+					RepoId:       data.Options.FullName,
+					CommitSha:    bitbucketPr.MergeCommitSha,
+				})
+			}
+			results = append(results, bitbucketPr)
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// convertServerPullRequest maps the server pull request onto the tool layer shared with cloud,
+// server uses the same OPEN/MERGED/DECLINED states so the pull request convertor works as is
+func convertServerPullRequest(pull *BitbucketServerApiPullRequest, connId uint64, repoId string) *models.BitbucketPullRequest {
+	bitbucketPull := &models.BitbucketPullRequest{
+		ConnectionId:  connId,
+		BitbucketId:   pull.Id,
+		Number:        pull.Id,
+		RepoId:        repoId,
+		State:         pull.State,
+		Title:         pull.Title,
+		Description:   pull.Description,
+		CommentCount:  pull.Properties.CommentCount,
+		BaseRepoId:    pull.ToRef.repoId(),
+		BaseRef:       strings.TrimPrefix(pull.ToRef.Id, "refs/heads/"),
+		BaseCommitSha: pull.ToRef.LatestCommit,
+		HeadRepoId:    pull.FromRef.repoId(),
+		HeadRef:       strings.TrimPrefix(pull.FromRef.Id, "refs/heads/"),
+		HeadCommitSha: pull.FromRef.LatestCommit,
+		ClosedAt:      serverTime(pull.ClosedDate),
+	}
+	if createdAt := serverTime(pull.CreatedDate); createdAt != nil {
+		bitbucketPull.BitbucketCreatedAt = *createdAt
+	}
+	if updatedAt := serverTime(pull.UpdatedDate); updatedAt != nil {
+		bitbucketPull.BitbucketUpdatedAt = *updatedAt
+	} else {
+		bitbucketPull.BitbucketUpdatedAt = bitbucketPull.BitbucketCreatedAt
+	}
+	if len(pull.Links.Self) > 0 {
+		bitbucketPull.Url = pull.Links.Self[0].Href
+	}
+	if pull.State == "MERGED" {
+		bitbucketPull.MergedAt = bitbucketPull.ClosedAt
+		if pull.Properties.MergeCommit != nil {
+			bitbucketPull.MergeCommitSha = pull.Properties.MergeCommit.Id
+		}
+	}
+	return bitbucketPull
+}
@@ -39,6 +39,8 @@ type BitbucketTaskData struct {
 	ApiClient     *api.ApiAsyncClient
 	TimeAfter     *time.Time
 	RegexEnricher *api.RegexEnricher
+	// IsServer is true when collecting from Bitbucket Server / Data Center instead of Bitbucket Cloud
+	IsServer bool
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*BitbucketOptions, errors.Error) {
@@ -32,6 +32,7 @@ var _ interface {
 	plugin.PluginMeta
 	plugin.PluginTask
 	plugin.PluginModel
+	plugin.PluginDataLineage
 	plugin.PluginMetric
 	plugin.PluginMigration
 	plugin.MetricPluginBlueprintV200
@@ -70,6 +71,34 @@ func (p Dora) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{}
 }
 
+// DataLineage declares the metrics derived from the domain data of scopes, the raw data table recorded
+// by the dora subtasks is the domain table they were converted from
+func (p Dora) DataLineage() []plugin.TableLineage {
+	return []plugin.TableLineage{
+		{
+			Table:        "project_pr_metrics",
+			Column:       "id",
+			ParentTable:  "pull_requests",
+			ParentColumn: "id",
+			Where:        "_raw_data_table = 'pull_requests'",
+		},
+		{
+			Table:        "project_issue_metrics",
+			Column:       "id",
+			ParentTable:  "issues",
+			ParentColumn: "id",
+			Where:        "_raw_data_table = 'issues'",
+		},
+		{
+			Table:        "cicd_deployment_commits",
+			Column:       "cicd_deployment_id",
+			ParentTable:  "cicd_pipelines",
+			ParentColumn: "id",
+			Where:        "_raw_data_table = 'cicd_pipeline_commits'",
+		},
+	}
+}
+
 func (p Dora) Name() string {
 	return "dora"
 }
@@ -93,6 +122,7 @@ func (p Dora) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.EnrichTaskEnvMeta,
 		tasks.CalculateChangeLeadTimeMeta,
 		tasks.ConnectIncidentToDeploymentMeta,
+		tasks.CalculateDoraMetricsMeta,
 	}
 }
 
@@ -154,6 +184,7 @@ func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.
 				Subtasks: []string{
 					"calculateChangeLeadTime",
 					"ConnectIncidentToDeployment",
+					"calculateDoraMetrics",
 				},
 			},
 		},
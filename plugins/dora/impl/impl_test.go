@@ -60,6 +60,7 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 				Subtasks: []string{
 					"calculateChangeLeadTime",
 					"ConnectIncidentToDeployment",
+					"calculateDoraMetrics",
 				},
 				Options: map[string]interface{}{"projectName": projectName},
 			},
@@ -0,0 +1,353 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// CalculateDoraMetricsMeta contains metadata for the CalculateDoraMetrics subtask.
+var CalculateDoraMetricsMeta = plugin.SubTaskMeta{
+	Name:             "calculateDoraMetrics",
+	EntryPoint:       CalculateDoraMetrics,
+	EnabledByDefault: true,
+	Description:      "Calculate weekly and monthly DORA metrics and performance levels for the project",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET},
+}
+
+type doraDeployment struct {
+	Id           string
+	FinishedDate *time.Time
+}
+
+type doraChange struct {
+	PrCycleTime  *int64
+	FinishedDate *time.Time
+}
+
+type doraIncident struct {
+	Id              string
+	DeploymentId    string
+	CreatedDate     *time.Time
+	ResolutionDate  *time.Time
+	LeadTimeMinutes int64
+}
+
+type doraPeriodKey struct {
+	Period      string
+	PeriodStart time.Time
+}
+
+// CalculateDoraMetrics rolls the deployments, pull requests and incidents of a project up into
+// project_dora_metrics, one row per week and per month, so that consumers other than grafana
+// can read the four key metrics and their performance levels directly.
+func CalculateDoraMetrics(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	projectName := data.Options.ProjectName
+
+	// step 1. load every successful production deployment of the project
+	deployments := make([]*doraDeployment, 0)
+	err := db.All(
+		&deployments,
+		dal.Select("dc.cicd_deployment_id AS id, MAX(dc.finished_date) AS finished_date"),
+		dal.From("cicd_deployment_commits dc"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = dc.cicd_scope_id)"),
+		dal.Where(
+			"pm.project_name = ? AND dc.result = ? AND dc.environment = ? AND dc.finished_date IS NOT NULL",
+			projectName, devops.SUCCESS, devops.PRODUCTION,
+		),
+		dal.Groupby("dc.cicd_deployment_id"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// step 2. load the cycle time of every deployed pull request
+	changes := make([]*doraChange, 0)
+	err = db.All(
+		&changes,
+		dal.Select("ppm.pr_cycle_time, dc.finished_date"),
+		dal.From("project_pr_metrics ppm"),
+		dal.Join("INNER JOIN cicd_deployment_commits dc ON (dc.id = ppm.deployment_commit_id)"),
+		dal.Where("ppm.project_name = ? AND ppm.pr_cycle_time IS NOT NULL AND dc.finished_date IS NOT NULL", projectName),
+	)
+	if err != nil {
+		return err
+	}
+
+	// step 3. load every incident of the project along with the deployment it was attributed to
+	incidents := make([]*doraIncident, 0)
+	err = db.All(
+		&incidents,
+		dal.Select("i.id, i.created_date, i.resolution_date, i.lead_time_minutes, pim.deployment_id"),
+		dal.From("issues i"),
+		dal.Join("INNER JOIN board_issues bi ON (bi.issue_id = i.id)"),
+		dal.Join("INNER JOIN project_mapping pm ON (pm.table = 'boards' AND pm.row_id = bi.board_id)"),
+		dal.Join("LEFT JOIN project_issue_metrics pim ON (pim.id = i.id AND pim.project_name = pm.project_name)"),
+		dal.Where("i.type = ? AND pm.project_name = ?", ticket.INCIDENT, projectName),
+	)
+	if err != nil {
+		return err
+	}
+
+	// step 4. aggregate them by week and month
+	metrics := calculateDoraMetrics(projectName, deployments, changes, incidents)
+
+	// step 5. replace the previous rollups of the project
+	err = db.Delete(&crossdomain.ProjectDoraMetric{}, dal.Where("project_name = ?", projectName))
+	if err != nil {
+		return err
+	}
+	batchSave, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.ProjectDoraMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, metric := range metrics {
+		err = batchSave.Add(metric)
+		if err != nil {
+			return err
+		}
+	}
+	return batchSave.Close()
+}
+
+func calculateDoraMetrics(
+	projectName string,
+	deployments []*doraDeployment,
+	changes []*doraChange,
+	incidents []*doraIncident,
+) []*crossdomain.ProjectDoraMetric {
+	metrics := make(map[doraPeriodKey]*crossdomain.ProjectDoraMetric)
+	deploymentDays := make(map[doraPeriodKey]map[string]bool)
+	leadTimes := make(map[doraPeriodKey][]int64)
+	restoreTimes := make(map[doraPeriodKey][]int64)
+	getMetrics := func(t time.Time) []*crossdomain.ProjectDoraMetric {
+		result := make([]*crossdomain.ProjectDoraMetric, 0, 2)
+		for _, period := range []string{crossdomain.DORA_PERIOD_WEEK, crossdomain.DORA_PERIOD_MONTH} {
+			start, end := crossdomain.DoraPeriodOf(period, t)
+			key := doraPeriodKey{Period: period, PeriodStart: start}
+			metric, ok := metrics[key]
+			if !ok {
+				metric = &crossdomain.ProjectDoraMetric{
+					ProjectName: projectName,
+					Period:      period,
+					PeriodStart: start,
+					PeriodEnd:   end,
+				}
+				metrics[key] = metric
+			}
+			result = append(result, metric)
+		}
+		return result
+	}
+
+	// deployment frequency
+	deploymentFinishedDates := make(map[string]time.Time, len(deployments))
+	for _, deployment := range deployments {
+		if deployment.FinishedDate == nil {
+			continue
+		}
+		finishedDate := deployment.FinishedDate.UTC()
+		deploymentFinishedDates[deployment.Id] = finishedDate
+		for _, metric := range getMetrics(finishedDate) {
+			key := doraPeriodKey{Period: metric.Period, PeriodStart: metric.PeriodStart}
+			metric.DeploymentCount++
+			if deploymentDays[key] == nil {
+				deploymentDays[key] = make(map[string]bool)
+			}
+			deploymentDays[key][finishedDate.Format("2006-01-02")] = true
+		}
+	}
+
+	// median change lead time
+	for _, change := range changes {
+		if change.PrCycleTime == nil || change.FinishedDate == nil {
+			continue
+		}
+		for _, metric := range getMetrics(change.FinishedDate.UTC()) {
+			key := doraPeriodKey{Period: metric.Period, PeriodStart: metric.PeriodStart}
+			metric.ChangeCount++
+			leadTimes[key] = append(leadTimes[key], *change.PrCycleTime)
+		}
+	}
+
+	// change failure rate and median time to restore service
+	failedDeployments := make(map[string]bool)
+	for _, incident := range incidents {
+		if incident.DeploymentId != "" && !failedDeployments[incident.DeploymentId] {
+			failedDeployments[incident.DeploymentId] = true
+			if finishedDate, ok := deploymentFinishedDates[incident.DeploymentId]; ok {
+				for _, metric := range getMetrics(finishedDate) {
+					metric.FailedDeploymentCount++
+				}
+			}
+		}
+		if incident.ResolutionDate == nil {
+			continue
+		}
+		restoreTime := incident.LeadTimeMinutes
+		if restoreTime <= 0 {
+			span := computeTimeSpan(incident.CreatedDate, incident.ResolutionDate)
+			if span == nil {
+				continue
+			}
+			restoreTime = *span
+		}
+		for _, metric := range getMetrics(incident.ResolutionDate.UTC()) {
+			key := doraPeriodKey{Period: metric.Period, PeriodStart: metric.PeriodStart}
+			metric.IncidentCount++
+			restoreTimes[key] = append(restoreTimes[key], restoreTime)
+		}
+	}
+
+	// a week or a month is too short to tell the lower levels apart, so they look back from the end of the period
+	finishedDates := make([]time.Time, 0, len(deploymentFinishedDates))
+	for _, finishedDate := range deploymentFinishedDates {
+		finishedDates = append(finishedDates, finishedDate)
+	}
+	sort.Slice(finishedDates, func(i, j int) bool {
+		return finishedDates[i].Before(finishedDates[j])
+	})
+
+	result := make([]*crossdomain.ProjectDoraMetric, 0, len(metrics))
+	for key, metric := range metrics {
+		metric.DeploymentDays = len(deploymentDays[key])
+		medianDeploymentDaysPerWeek := utils.Median(weeklyDeploymentDays(deploymentDays[key], metric.PeriodStart, metric.PeriodEnd))
+		daysSinceLastDeployment := -1
+		if i := sort.Search(len(finishedDates), func(i int) bool {
+			return !finishedDates[i].Before(metric.PeriodEnd)
+		}); i > 0 {
+			daysSinceLastDeployment = int(metric.PeriodEnd.Sub(finishedDates[i-1]).Hours() / 24)
+		}
+		metric.DeploymentFrequencyLevel = deploymentFrequencyLevel(*medianDeploymentDaysPerWeek, daysSinceLastDeployment)
+		metric.MedianChangeLeadTimeMinutes = utils.Median(leadTimes[key])
+		if metric.MedianChangeLeadTimeMinutes != nil {
+			metric.ChangeLeadTimeLevel = changeLeadTimeLevel(*metric.MedianChangeLeadTimeMinutes)
+		}
+		if metric.DeploymentCount > 0 {
+			rate := float64(metric.FailedDeploymentCount) / float64(metric.DeploymentCount)
+			metric.ChangeFailureRate = &rate
+			metric.ChangeFailureRateLevel = changeFailureRateLevel(rate)
+		}
+		metric.MedianTimeToRestoreMinutes = utils.Median(restoreTimes[key])
+		if metric.MedianTimeToRestoreMinutes != nil {
+			metric.TimeToRestoreLevel = timeToRestoreLevel(*metric.MedianTimeToRestoreMinutes)
+		}
+		result = append(result, metric)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Period != result[j].Period {
+			return result[i].Period < result[j].Period
+		}
+		return result[i].PeriodStart.Before(result[j].PeriodStart)
+	})
+	return result
+}
+
+// weeklyDeploymentDays counts the days with deployments in every full 7-day window of the period,
+// the days left over at the end of a month do not make up a week and are not counted
+func weeklyDeploymentDays(days map[string]bool, periodStart time.Time, periodEnd time.Time) []int64 {
+	result := make([]int64, 0, 5)
+	for weekStart := periodStart; !weekStart.AddDate(0, 0, 7).After(periodEnd); weekStart = weekStart.AddDate(0, 0, 7) {
+		count := int64(0)
+		for day := 0; day < 7; day++ {
+			if days[weekStart.AddDate(0, 0, day).Format("2006-01-02")] {
+				count++
+			}
+		}
+		result = append(result, count)
+	}
+	return result
+}
+
+// the levels below follow the benchmarks listed in the dora_benchmarks table
+
+// eliteDeploymentDaysPerWeek is the median number of days with deployments per week
+// from which a team deploys on demand rather than on a weekly cadence
+const eliteDeploymentDaysPerWeek = 2
+
+// daysSinceLastDeployment counts the days from the last deployment before the end of the period to the end,
+// -1 when nothing was ever deployed
+func deploymentFrequencyLevel(medianDeploymentDaysPerWeek int64, daysSinceLastDeployment int) string {
+	switch {
+	case medianDeploymentDaysPerWeek >= eliteDeploymentDaysPerWeek:
+		// on-demand, more than once per week
+		return crossdomain.DORA_LEVEL_ELITE
+	case daysSinceLastDeployment < 0:
+		return crossdomain.DORA_LEVEL_LOW
+	case daysSinceLastDeployment <= 31:
+		// between once per week and once per month
+		return crossdomain.DORA_LEVEL_HIGH
+	case daysSinceLastDeployment <= 183:
+		// between once per month and once every 6 months
+		return crossdomain.DORA_LEVEL_MEDIUM
+	default:
+		return crossdomain.DORA_LEVEL_LOW
+	}
+}
+
+func changeLeadTimeLevel(minutes int64) string {
+	switch {
+	case minutes < 60:
+		return crossdomain.DORA_LEVEL_ELITE
+	case minutes < 7*24*60:
+		return crossdomain.DORA_LEVEL_HIGH
+	case minutes < 180*24*60:
+		return crossdomain.DORA_LEVEL_MEDIUM
+	default:
+		return crossdomain.DORA_LEVEL_LOW
+	}
+}
+
+func changeFailureRateLevel(rate float64) string {
+	switch {
+	case rate <= 0.15:
+		return crossdomain.DORA_LEVEL_ELITE
+	case rate <= 0.20:
+		return crossdomain.DORA_LEVEL_HIGH
+	case rate <= 0.30:
+		return crossdomain.DORA_LEVEL_MEDIUM
+	default:
+		return crossdomain.DORA_LEVEL_LOW
+	}
+}
+
+func timeToRestoreLevel(minutes int64) string {
+	switch {
+	case minutes < 60:
+		return crossdomain.DORA_LEVEL_ELITE
+	case minutes < 24*60:
+		return crossdomain.DORA_LEVEL_HIGH
+	case minutes < 7*24*60:
+		return crossdomain.DORA_LEVEL_MEDIUM
+	default:
+		return crossdomain.DORA_LEVEL_LOW
+	}
+}
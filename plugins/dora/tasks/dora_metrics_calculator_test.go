@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoraLevels(t *testing.T) {
+	assert.Equal(t, crossdomain.DORA_LEVEL_ELITE, changeLeadTimeLevel(59))
+	assert.Equal(t, crossdomain.DORA_LEVEL_HIGH, changeLeadTimeLevel(60))
+	assert.Equal(t, crossdomain.DORA_LEVEL_LOW, changeLeadTimeLevel(200*24*60))
+	assert.Equal(t, crossdomain.DORA_LEVEL_ELITE, changeFailureRateLevel(0.15))
+	assert.Equal(t, crossdomain.DORA_LEVEL_MEDIUM, changeFailureRateLevel(0.25))
+	assert.Equal(t, crossdomain.DORA_LEVEL_LOW, changeFailureRateLevel(0.5))
+	assert.Equal(t, crossdomain.DORA_LEVEL_HIGH, timeToRestoreLevel(120))
+	assert.Equal(t, crossdomain.DORA_LEVEL_LOW, timeToRestoreLevel(8*24*60))
+}
+
+func TestDeploymentFrequencyLevel(t *testing.T) {
+	for _, tc := range []struct {
+		name                        string
+		medianDeploymentDaysPerWeek int64
+		daysSinceLastDeployment     int
+		level                       string
+	}{
+		{"twice a week", 2, 1, crossdomain.DORA_LEVEL_ELITE},
+		{"daily", 5, 0, crossdomain.DORA_LEVEL_ELITE},
+		{"once a week", 1, 3, crossdomain.DORA_LEVEL_HIGH},
+		{"once a month", 0, 30, crossdomain.DORA_LEVEL_HIGH},
+		{"none in a week, last one a month ago", 0, 31, crossdomain.DORA_LEVEL_HIGH},
+		{"none in a week, last one two months ago", 0, 60, crossdomain.DORA_LEVEL_MEDIUM},
+		{"none in a month, last one half a year ago", 0, 183, crossdomain.DORA_LEVEL_MEDIUM},
+		{"none in a month, last one a year ago", 0, 365, crossdomain.DORA_LEVEL_LOW},
+		{"never deployed", 0, -1, crossdomain.DORA_LEVEL_LOW},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.level, deploymentFrequencyLevel(tc.medianDeploymentDaysPerWeek, tc.daysSinceLastDeployment))
+		})
+	}
+}
+
+func TestWeeklyDeploymentFrequencyLevelOfMonths(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		month time.Time
+	}{
+		{"28-day month", time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"30-day month", time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"31-day month", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			deployments := make([]*doraDeployment, 0)
+			for day := tc.month; day.Month() == tc.month.Month(); day = day.AddDate(0, 0, 7) {
+				finishedDate := day.Add(10 * time.Hour)
+				deployments = append(deployments, &doraDeployment{Id: finishedDate.String(), FinishedDate: &finishedDate})
+			}
+			var month *crossdomain.ProjectDoraMetric
+			for _, metric := range calculateDoraMetrics("p", deployments, nil, nil) {
+				if metric.Period == crossdomain.DORA_PERIOD_MONTH && metric.PeriodStart.Equal(tc.month) {
+					month = metric
+				}
+			}
+			assert.NotNil(t, month)
+			assert.Equal(t, crossdomain.DORA_LEVEL_HIGH, month.DeploymentFrequencyLevel)
+		})
+	}
+}
+
+func TestCalculateDoraMetrics(t *testing.T) {
+	ptr := func(t time.Time) *time.Time { return &t }
+	minutes := func(m int64) *int64 { return &m }
+	deployments := []*doraDeployment{
+		{Id: "d1", FinishedDate: ptr(time.Date(2023, 5, 15, 10, 0, 0, 0, time.UTC))},
+		{Id: "d2", FinishedDate: ptr(time.Date(2023, 5, 15, 18, 0, 0, 0, time.UTC))},
+		{Id: "d3", FinishedDate: ptr(time.Date(2023, 5, 17, 10, 0, 0, 0, time.UTC))},
+		{Id: "d4", FinishedDate: ptr(time.Date(2023, 5, 24, 10, 0, 0, 0, time.UTC))},
+	}
+	changes := []*doraChange{
+		{PrCycleTime: minutes(30), FinishedDate: deployments[0].FinishedDate},
+		{PrCycleTime: minutes(90), FinishedDate: deployments[2].FinishedDate},
+		{PrCycleTime: minutes(3000), FinishedDate: deployments[3].FinishedDate},
+	}
+	incidents := []*doraIncident{
+		{
+			Id:             "i1",
+			DeploymentId:   "d1",
+			CreatedDate:    ptr(time.Date(2023, 5, 16, 0, 0, 0, 0, time.UTC)),
+			ResolutionDate: ptr(time.Date(2023, 5, 16, 2, 0, 0, 0, time.UTC)),
+		},
+		{
+			Id:              "i2",
+			DeploymentId:    "d1",
+			ResolutionDate:  ptr(time.Date(2023, 5, 25, 0, 0, 0, 0, time.UTC)),
+			LeadTimeMinutes: 30,
+		},
+	}
+	metrics := calculateDoraMetrics("p", deployments, changes, incidents)
+	assert.Len(t, metrics, 3)
+
+	month := metrics[0]
+	assert.Equal(t, crossdomain.DORA_PERIOD_MONTH, month.Period)
+	assert.Equal(t, 4, month.DeploymentCount)
+	assert.Equal(t, 3, month.DeploymentDays)
+	assert.Equal(t, crossdomain.DORA_LEVEL_HIGH, month.DeploymentFrequencyLevel)
+	assert.Equal(t, 3, month.ChangeCount)
+	assert.Equal(t, int64(90), *month.MedianChangeLeadTimeMinutes)
+	assert.Equal(t, crossdomain.DORA_LEVEL_HIGH, month.ChangeLeadTimeLevel)
+	assert.Equal(t, 1, month.FailedDeploymentCount)
+	assert.Equal(t, 0.25, *month.ChangeFailureRate)
+	assert.Equal(t, crossdomain.DORA_LEVEL_MEDIUM, month.ChangeFailureRateLevel)
+	assert.Equal(t, 2, month.IncidentCount)
+	assert.Equal(t, int64(75), *month.MedianTimeToRestoreMinutes)
+	assert.Equal(t, crossdomain.DORA_LEVEL_HIGH, month.TimeToRestoreLevel)
+
+	firstWeek := metrics[1]
+	assert.Equal(t, crossdomain.DORA_PERIOD_WEEK, firstWeek.Period)
+	assert.Equal(t, time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC), firstWeek.PeriodStart)
+	assert.Equal(t, 3, firstWeek.DeploymentCount)
+	assert.Equal(t, 2, firstWeek.DeploymentDays)
+	assert.Equal(t, crossdomain.DORA_LEVEL_ELITE, firstWeek.DeploymentFrequencyLevel)
+	assert.Equal(t, int64(60), *firstWeek.MedianChangeLeadTimeMinutes)
+	assert.Equal(t, 1, firstWeek.FailedDeploymentCount)
+	assert.Equal(t, int64(120), *firstWeek.MedianTimeToRestoreMinutes)
+
+	secondWeek := metrics[2]
+	assert.Equal(t, 1, secondWeek.DeploymentCount)
+	assert.Equal(t, crossdomain.DORA_LEVEL_HIGH, secondWeek.DeploymentFrequencyLevel)
+	assert.Equal(t, 0, secondWeek.FailedDeploymentCount)
+	assert.Equal(t, float64(0), *secondWeek.ChangeFailureRate)
+	assert.Equal(t, crossdomain.DORA_LEVEL_ELITE, secondWeek.ChangeFailureRateLevel)
+}
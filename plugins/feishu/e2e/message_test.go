@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/feishu/impl"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+	"github.com/apache/incubator-devlake/plugins/feishu/tasks"
+	"testing"
+)
+
+func TestMessageDataFlow(t *testing.T) {
+	var plugin impl.Feishu
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "feishu", plugin)
+
+	taskData := &tasks.FeishuTaskData{
+		Options: &tasks.FeishuOptions{
+			ConnectionId: 1,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_feishu_chat_member.csv", "_raw_feishu_chat_member")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_feishu_message.csv", "_raw_feishu_message")
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_feishu_chats.csv", &models.FeishuChatItem{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.FeishuUser{})
+	dataflowTester.FlushTabler(&models.FeishuMessage{})
+	dataflowTester.Subtask(tasks.ExtractChatMemberMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractMessageMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.FeishuUser{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_feishu_users.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&crossdomain.Account{})
+	dataflowTester.FlushTabler(&chat.ChatChannel{})
+	dataflowTester.FlushTabler(&chat.ChatMessage{})
+	dataflowTester.Subtask(tasks.ConvertUserMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertChatMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertMessageMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(crossdomain.Account{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/accounts.csv",
+		TargetFields: []string{"id", "full_name", "user_name"},
+	})
+	dataflowTester.VerifyTableWithOptions(chat.ChatChannel{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/chat_channels.csv",
+		TargetFields: []string{"id", "name", "description", "type", "is_private", "is_archived", "creator_id"},
+	})
+	dataflowTester.VerifyTableWithOptions(chat.ChatMessage{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/chat_messages.csv",
+		TargetFields: []string{
+			"id", "channel_id", "thread_id", "author_id", "type", "content",
+			"reply_count", "is_deleted", "created_date", "updated_date",
+		},
+	})
+
+	// verify threads and participants
+	dataflowTester.FlushTabler(&chat.ChatThread{})
+	dataflowTester.FlushTabler(&chat.ChatParticipant{})
+	dataflowTester.Subtask(tasks.GenerateChatActivityMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(chat.ChatThread{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/chat_threads.csv",
+		TargetFields: []string{
+			"id", "channel_id", "author_id", "reply_count", "participant_count",
+			"created_date", "first_response_date", "last_reply_date",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(chat.ChatParticipant{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/chat_participants.csv",
+		TargetFields: []string{"channel_id", "account_id", "message_count", "first_message_date", "last_message_date"},
+	})
+}
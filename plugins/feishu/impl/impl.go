@@ -56,6 +56,7 @@ func (p Feishu) GetTablesInfo() []dal.Tabler {
 		&models.FeishuMeetingTopUserItem{},
 		&models.FeishuChatItem{},
 		&models.FeishuMessage{},
+		&models.FeishuUser{},
 	}
 }
 
@@ -84,9 +85,17 @@ func (p Feishu) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectChatMeta,
 		tasks.ExtractChatItemMeta,
 
+		tasks.CollectChatMemberMeta,
+		tasks.ExtractChatMemberMeta,
+
 		tasks.CollectMessageMeta,
 		tasks.ExtractMessageMeta,
 
+		tasks.ConvertUserMeta,
+		tasks.ConvertChatMeta,
+		tasks.ConvertMessageMeta,
+		tasks.GenerateChatActivityMeta,
+
 		tasks.CollectMeetingTopUserItemMeta,
 		tasks.ExtractMeetingTopUserItemMeta,
 	}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+)
+
+var _ plugin.SubTaskEntryPoint = GenerateChatActivity
+
+func GenerateChatActivity(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*FeishuTaskData)
+	chatIdGen := didgen.NewDomainIdGenerator(&models.FeishuChatItem{})
+	generator, err := api.NewChatActivityGenerator(api.ChatActivityGeneratorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: FeishuApiParams{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_MESSAGE_TABLE,
+		},
+		ChannelIdPattern: chatIdGen.Generate(data.Options.ConnectionId, didgen.WILDCARD),
+	})
+	if err != nil {
+		return err
+	}
+
+	return generator.Execute()
+}
+
+var GenerateChatActivityMeta = plugin.SubTaskMeta{
+	Name:             "generateChatActivity",
+	EntryPoint:       GenerateChatActivity,
+	EnabledByDefault: true,
+	Description:      "Generate domain layer tables chat_threads and chat_participants from chat_messages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CHAT},
+}
@@ -89,4 +89,5 @@ var CollectChatMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectChat,
 	EnabledByDefault: true,
 	Description:      "Collect chats from Feishu api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CHAT},
 }
@@ -116,4 +116,5 @@ var CollectMessageMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectMessage,
 	EnabledByDefault: true,
 	Description:      "Collect message from Feishu api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CHAT},
 }
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+	"reflect"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertMessage
+
+type threadReplyCount struct {
+	RootId     string
+	ReplyCount int
+}
+
+func ConvertMessage(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*FeishuTaskData)
+	db := taskCtx.GetDal()
+
+	// replies point to the root of their thread, but roots don't tell whether they were replied to
+	var replyCounts []threadReplyCount
+	err := db.All(
+		&replyCounts,
+		dal.Select("root_id, COUNT(*) AS reply_count"),
+		dal.From(&models.FeishuMessage{}),
+		dal.Where("connection_id = ? AND root_id != ''", data.Options.ConnectionId),
+		dal.Groupby("root_id"),
+	)
+	if err != nil {
+		return err
+	}
+	replyCountByRoot := make(map[string]int, len(replyCounts))
+	for _, count := range replyCounts {
+		replyCountByRoot[count.RootId] = count.ReplyCount
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.FeishuMessage{}),
+		dal.Where("connection_id = ?", data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	chatIdGen := didgen.NewDomainIdGenerator(&models.FeishuChatItem{})
+	messageIdGen := didgen.NewDomainIdGenerator(&models.FeishuMessage{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.FeishuUser{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: FeishuApiParams{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_MESSAGE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.FeishuMessage{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			feishuMessage := inputRow.(*models.FeishuMessage)
+			message := &chat.ChatMessage{
+				DomainEntity: domainlayer.DomainEntity{Id: messageIdGen.Generate(data.Options.ConnectionId, feishuMessage.MessageId)},
+				ChannelId:    chatIdGen.Generate(data.Options.ConnectionId, feishuMessage.ChatId),
+				Type:         feishuMessage.MsgType,
+				Content:      feishuMessage.Content,
+				ReplyCount:   replyCountByRoot[feishuMessage.MessageId],
+				IsDeleted:    feishuMessage.Deleted,
+				CreatedDate:  feishuMessage.CreateTime,
+			}
+			if feishuMessage.RootId != "" {
+				message.ThreadId = messageIdGen.Generate(data.Options.ConnectionId, feishuMessage.RootId)
+			} else if message.ReplyCount > 0 {
+				message.ThreadId = message.Id
+			}
+			// messages sent by apps are left without author
+			if feishuMessage.SenderType == "user" && feishuMessage.SenderIdType == "open_id" {
+				message.AuthorId = userIdGen.Generate(data.Options.ConnectionId, feishuMessage.SenderId)
+			}
+			if feishuMessage.Updated {
+				updatedDate := feishuMessage.UpdateTime
+				message.UpdatedDate = &updatedDate
+			}
+			return []interface{}{message}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+var ConvertMessageMeta = plugin.SubTaskMeta{
+	Name:             "convertMessage",
+	EntryPoint:       ConvertMessage,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_feishu_messages into domain layer table chat_messages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CHAT},
+}
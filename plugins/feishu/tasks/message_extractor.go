@@ -80,8 +80,9 @@ func ExtractMessage(taskCtx plugin.SubTaskContext) errors.Error {
 }
 
 var ExtractMessageMeta = plugin.SubTaskMeta{
-	Name:             "extractChatItem",
+	Name:             "extractMessage",
 	EntryPoint:       ExtractMessage,
 	EnabledByDefault: true,
-	Description:      "Extract raw messages data into tool layer table feishu_meeting_top_user_item",
+	Description:      "Extract raw messages data into tool layer table _tool_feishu_messages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CHAT},
 }
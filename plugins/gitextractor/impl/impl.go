@@ -19,6 +19,12 @@ package impl
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
@@ -28,13 +34,13 @@ import (
 	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/store"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/tasks"
-	"strings"
 )
 
 var _ interface {
 	plugin.PluginMeta
 	plugin.PluginTask
 	plugin.PluginModel
+	plugin.PluginDataLineage
 } = (*GitExtractor)(nil)
 
 type GitExtractor struct{}
@@ -43,6 +49,36 @@ func (p GitExtractor) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{}
 }
 
+// DataLineage declares the data extracted from the repos of scopes, which are recorded with the repo id as
+// their `_raw_data_params`. Commits don't keep it, they are deleted unless another repo still contains them.
+func (p GitExtractor) DataLineage() []plugin.TableLineage {
+	var lineage []plugin.TableLineage
+	for _, table := range []string{
+		"repo_commits",
+		"refs",
+		"commit_files",
+		"commit_file_components",
+		"commit_line_change",
+		"commit_parents",
+		"repo_snapshot",
+	} {
+		lineage = append(lineage, plugin.TableLineage{
+			Table:        table,
+			Column:       "_raw_data_params",
+			ParentTable:  "repos",
+			ParentColumn: "id",
+			Where:        "_raw_data_table = 'gitextractor'",
+		})
+	}
+	return append(lineage, plugin.TableLineage{
+		Table:        "commits",
+		Column:       "sha",
+		ParentTable:  "repo_commits",
+		ParentColumn: "commit_sha",
+		Exclusive:    true,
+	})
+}
+
 func (p GitExtractor) Description() string {
 	return "extract infos from git repository"
 }
@@ -70,8 +106,21 @@ func (p GitExtractor) PrepareTaskData(taskCtx plugin.TaskContext, options map[st
 	if err := op.Valid(); err != nil {
 		return nil, err
 	}
-	storage := store.NewDatabase(taskCtx, op.RepoId)
-	repo, err := NewGitRepo(taskCtx.GetLogger(), storage, op)
+	if err := op.ResolveSecrets(taskCtx.GetContext()); err != nil {
+		return nil, err
+	}
+	mirrorCacheDir := ""
+	if !strings.HasPrefix(op.Url, "/") {
+		mirrorCacheDir = strings.TrimSpace(taskCtx.GetConfig("GIT_MIRROR_CACHE_DIR"))
+	}
+	var storage models.Store
+	if mirrorCacheDir != "" {
+		evictMirrors(taskCtx, mirrorCacheDir)
+		storage = store.NewIncrementalDatabase(taskCtx, op.RepoId)
+	} else {
+		storage = store.NewDatabase(taskCtx, op.RepoId)
+	}
+	repo, err := NewGitRepo(taskCtx.GetLogger(), storage, op, mirrorCacheDir, KnownHostsFiles(taskCtx.GetConfig("GIT_SSH_KNOWN_HOSTS")))
 	if err != nil {
 		return nil, err
 	}
@@ -91,15 +140,40 @@ func (p GitExtractor) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/gitextractor"
 }
 
-// NewGitRepo create and return a new parser git repo
-func NewGitRepo(logger log.Logger, storage models.Store, op tasks.GitExtractorOptions) (*parser.GitRepo, errors.Error) {
+// NewGitRepo create and return a new parser git repo, remote repos would be fetched into bare mirrors under
+// mirrorCacheDir instead of being cloned into temporary directories if mirrorCacheDir was not empty.
+// SSH host keys are verified against knownHostsFiles along with the knownHosts and hostKeyFingerprints options
+func NewGitRepo(
+	logger log.Logger,
+	storage models.Store,
+	op tasks.GitExtractorOptions,
+	mirrorCacheDir string,
+	knownHostsFiles []string,
+) (*parser.GitRepo, errors.Error) {
 	var err errors.Error
 	var repo *parser.GitRepo
-	p := parser.NewGitRepoCreator(storage, logger)
+	p := parser.NewGitRepoCreator(storage, logger).WithMirrorCache(mirrorCacheDir)
 	if strings.HasPrefix(op.Url, "http") {
-		repo, err = p.CloneOverHTTP(op.RepoId, op.Url, op.User, op.Password, op.Proxy)
+		if mirrorCacheDir != "" {
+			repo, err = p.MirrorOverHTTP(op.RepoId, op.Url, op.User, op.Password, op.Proxy)
+		} else {
+			repo, err = p.CloneOverHTTP(op.RepoId, op.Url, op.User, op.Password, op.Proxy)
+		}
 	} else if url := strings.TrimPrefix(op.Url, "ssh://"); strings.HasPrefix(url, "git@") {
-		repo, err = p.CloneOverSSH(op.RepoId, url, op.PrivateKey, op.Passphrase)
+		hostKeyCallback, callbackErr := parser.NewHostKeyCallback(parser.HostKeyOptions{
+			KnownHostsFiles: knownHostsFiles,
+			KnownHosts:      op.KnownHosts,
+			Fingerprints:    op.HostKeyFingerprints,
+		})
+		if callbackErr != nil {
+			return nil, callbackErr
+		}
+		p.WithHostKeyCallback(hostKeyCallback)
+		if mirrorCacheDir != "" {
+			repo, err = p.MirrorOverSSH(op.RepoId, url, op.PrivateKey, op.Passphrase)
+		} else {
+			repo, err = p.CloneOverSSH(op.RepoId, url, op.PrivateKey, op.Passphrase)
+		}
 	} else if strings.HasPrefix(op.Url, "/") {
 		repo, err = p.LocalRepo(op.Url, op.RepoId)
 	} else {
@@ -107,3 +181,43 @@ func NewGitRepo(logger log.Logger, storage models.Store, op tasks.GitExtractorOp
 	}
 	return repo, err
 }
+
+// evictMirrors removes the mirrors not used for GIT_MIRROR_CACHE_RETENTION_DAYS, 30 days by default and 0 keeps them forever.
+// Failing to evict doesn't fail the task, the mirrors would be evicted by the following tasks
+func evictMirrors(taskCtx plugin.TaskContext, mirrorCacheDir string) {
+	retentionDays := 30
+	if days := strings.TrimSpace(taskCtx.GetConfig("GIT_MIRROR_CACHE_RETENTION_DAYS")); days != "" {
+		var err error
+		retentionDays, err = strconv.Atoi(days)
+		if err != nil {
+			taskCtx.GetLogger().Warn(err, "invalid GIT_MIRROR_CACHE_RETENTION_DAYS %s", days)
+			return
+		}
+	}
+	if retentionDays <= 0 {
+		return
+	}
+	evicted, err := parser.EvictMirrors(mirrorCacheDir, time.Duration(retentionDays)*24*time.Hour)
+	if err != nil {
+		taskCtx.GetLogger().Warn(err, "failed to evict git mirrors")
+	}
+	if len(evicted) > 0 {
+		taskCtx.GetLogger().Info("evicted %d git mirrors not used for %d days", len(evicted), retentionDays)
+	}
+}
+
+// KnownHostsFiles splits the comma separated GIT_SSH_KNOWN_HOSTS, which defaults to ~/.ssh/known_hosts
+func KnownHostsFiles(knownHosts string) []string {
+	files := make([]string, 0)
+	for _, file := range strings.Split(knownHosts, ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			files = append(files, file)
+		}
+	}
+	if len(files) == 0 {
+		if home, err := os.UserHomeDir(); err == nil {
+			files = append(files, filepath.Join(home, ".ssh", "known_hosts"))
+		}
+	}
+	return files
+}
@@ -20,6 +20,8 @@ package main
 import (
 	"context"
 	"flag"
+	"strings"
+
 	"github.com/apache/incubator-devlake/core/config"
 	"github.com/apache/incubator-devlake/core/runner"
 	contextimpl "github.com/apache/incubator-devlake/impls/context"
@@ -42,6 +44,8 @@ func main() {
 	password := flag.String("password", "", "-password")
 	output := flag.String("output", "", "-output")
 	dbUrl := flag.String("db", "", "-db")
+	mirror := flag.String("mirror", "", "-mirror")
+	knownHosts := flag.String("known_hosts", "", "-known_hosts")
 	flag.Parse()
 	cfg := config.GetConfig()
 	logger := logruslog.Global.Nested("git extractor")
@@ -53,6 +57,10 @@ func main() {
 	if *id == "" {
 		panic("id is missing")
 	}
+	if strings.HasPrefix(*url, "/") {
+		// local repos are read in place
+		*mirror = ""
+	}
 	db, err := runner.NewGormDb(cfg, logger)
 	if err != nil {
 		panic(err)
@@ -68,7 +76,14 @@ func main() {
 	}
 	// If we didn't specify output or dburl, we will use db by default
 	if storage == nil {
-		storage = store.NewDatabase(basicRes, *id)
+		if *mirror != "" {
+			storage = store.NewIncrementalDatabase(basicRes, *id)
+		} else {
+			storage = store.NewDatabase(basicRes, *id)
+		}
+	} else {
+		// commits in the csv output can not be skipped
+		*mirror = ""
 	}
 	defer storage.Close()
 	ctx := context.Background()
@@ -84,7 +99,7 @@ func main() {
 		User:     *user,
 		Password: *password,
 		Proxy:    *proxy,
-	})
+	}, *mirror, impl.KnownHostsFiles(*knownHosts))
 	if err != nil {
 		panic(err)
 	}
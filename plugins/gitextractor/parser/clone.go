@@ -19,9 +19,7 @@ package parser
 
 import (
 	"encoding/base64"
-	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
-	"net"
 	"os"
 
 	gogit "github.com/go-git/go-git/v5"
@@ -35,17 +33,26 @@ import (
 
 const DefaultUser = "git"
 
-func cloneOverSSH(url, dir, passphrase string, pk []byte) errors.Error {
+func newPublicKeys(passphrase string, pk []byte, hostKeyCallback ssh2.HostKeyCallback) (*ssh.PublicKeys, errors.Error) {
+	if hostKeyCallback == nil {
+		return nil, errors.Default.New("ssh host key verification is not configured")
+	}
 	key, err := ssh.NewPublicKeys(DefaultUser, pk, passphrase)
 	if err != nil {
-		return errors.Convert(err)
+		return nil, errors.Convert(err)
 	}
 	key.HostKeyCallbackHelper = ssh.HostKeyCallbackHelper{
-		HostKeyCallback: func(hostname string, remote net.Addr, key ssh2.PublicKey) error {
-			return nil
-		},
+		HostKeyCallback: hostKeyCallback,
+	}
+	return key, nil
+}
+
+func cloneOverSSH(url, dir, passphrase string, pk []byte, hostKeyCallback ssh2.HostKeyCallback) errors.Error {
+	key, err1 := newPublicKeys(passphrase, pk, hostKeyCallback)
+	if err1 != nil {
+		return err1
 	}
-	_, err = gogit.PlainClone(dir, true, &gogit.CloneOptions{
+	_, err := gogit.PlainClone(dir, true, &gogit.CloneOptions{
 		URL:  url,
 		Auth: key,
 	})
@@ -57,15 +64,7 @@ func cloneOverSSH(url, dir, passphrase string, pk []byte) errors.Error {
 
 func (l *GitRepoCreator) CloneOverHTTP(repoId, url, user, password, proxy string) (*GitRepo, errors.Error) {
 	return withTempDirectory(func(dir string) (*GitRepo, error) {
-		cloneOptions := &git.CloneOptions{Bare: true}
-		if proxy != "" {
-			cloneOptions.FetchOptions.ProxyOptions.Type = git.ProxyTypeAuto
-			cloneOptions.FetchOptions.ProxyOptions.Url = proxy
-		}
-		if user != "" {
-			auth := fmt.Sprintf("Authorization: Basic %s", base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
-			cloneOptions.FetchOptions.Headers = []string{auth}
-		}
+		cloneOptions := &git.CloneOptions{Bare: true, FetchOptions: l.fetchOptionsOverHTTP(user, password, proxy)}
 		clonedRepo, err := git.Clone(url, dir, cloneOptions)
 		if err != nil {
 			return nil, err
@@ -80,7 +79,7 @@ func (l *GitRepoCreator) CloneOverSSH(repoId, url, privateKey, passphrase string
 		if err != nil {
 			return nil, err
 		}
-		err = cloneOverSSH(url, dir, passphrase, pk)
+		err = cloneOverSSH(url, dir, passphrase, pk, l.hostKeyCallback)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	goerror "errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	ssh2 "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// HostKeyOptions decides which ssh host keys are trusted, pinned fingerprints take precedence over known_hosts.
+// The host keys of a connection are scoped to it: once KnownHosts or Fingerprints is set, the global
+// KnownHostsFiles are ignored, so that a host trusted by one connection can't be trusted by another by accident
+type HostKeyOptions struct {
+	// KnownHostsFiles are paths of known_hosts files, i.e. the global GIT_SSH_KNOWN_HOSTS, used when
+	// the connection has no host keys of its own
+	KnownHostsFiles []string
+	// KnownHosts is the content of a known_hosts file, i.e. the one specified along with the private key
+	KnownHosts string
+	// Fingerprints are SHA256 fingerprints of the trusted host keys, formatted like `ssh-keygen -l`: SHA256:xxxx
+	Fingerprints []string
+}
+
+// NewHostKeyCallback creates a callback rejecting unknown or mismatched host keys
+func NewHostKeyCallback(options HostKeyOptions) (ssh2.HostKeyCallback, errors.Error) {
+	if len(options.Fingerprints) > 0 {
+		return pinnedHostKeyCallback(options.Fingerprints), nil
+	}
+	files := make([]string, 0, len(options.KnownHostsFiles))
+	if strings.TrimSpace(options.KnownHosts) != "" {
+		file, err := writeKnownHosts(options.KnownHosts)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(file)
+		files = append(files, file)
+	} else {
+		for _, file := range options.KnownHostsFiles {
+			if _, err := os.Stat(file); err == nil {
+				files = append(files, file)
+			}
+		}
+	}
+	if len(files) == 0 {
+		return func(hostname string, remote net.Addr, key ssh2.PublicKey) error {
+			return errors.BadInput.New(fmt.Sprintf(
+				"unknown ssh host key %s for %s: no known_hosts were configured, please set knownHosts/hostKeyFingerprints or GIT_SSH_KNOWN_HOSTS",
+				ssh2.FingerprintSHA256(key), hostname,
+			))
+		}, nil
+	}
+	// knownhosts loads all files into memory, so the temporary file is free to be removed afterwards
+	callback, err := knownhosts.New(files...)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse known_hosts")
+	}
+	return func(hostname string, remote net.Addr, key ssh2.PublicKey) error {
+		err := callback(hostname, remote, key)
+		if err == nil {
+			return nil
+		}
+		var keyErr *knownhosts.KeyError
+		if goerror.As(err, &keyErr) {
+			if len(keyErr.Want) == 0 {
+				return errors.BadInput.New(fmt.Sprintf(
+					"unknown ssh host key %s for %s, please add it to known_hosts",
+					ssh2.FingerprintSHA256(key), hostname,
+				))
+			}
+			return errors.BadInput.New(fmt.Sprintf(
+				"ssh host key %s for %s does not match the one in %s:%d, the connection might be intercepted",
+				ssh2.FingerprintSHA256(key), hostname, keyErr.Want[0].Filename, keyErr.Want[0].Line,
+			))
+		}
+		var revokedErr *knownhosts.RevokedError
+		if goerror.As(err, &revokedErr) {
+			return errors.BadInput.New(fmt.Sprintf("ssh host key %s for %s was revoked", ssh2.FingerprintSHA256(key), hostname))
+		}
+		return err
+	}, nil
+}
+
+func pinnedHostKeyCallback(fingerprints []string) ssh2.HostKeyCallback {
+	pinned := make(map[string]bool, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		fingerprint = strings.TrimSpace(fingerprint)
+		if !strings.HasPrefix(fingerprint, "SHA256:") {
+			fingerprint = "SHA256:" + fingerprint
+		}
+		pinned[strings.TrimRight(fingerprint, "=")] = true
+	}
+	return func(hostname string, remote net.Addr, key ssh2.PublicKey) error {
+		fingerprint := ssh2.FingerprintSHA256(key)
+		if pinned[fingerprint] {
+			return nil
+		}
+		return errors.BadInput.New(fmt.Sprintf(
+			"ssh host key %s for %s does not match any pinned fingerprint, the connection might be intercepted",
+			fingerprint, hostname,
+		))
+	}
+}
+
+func writeKnownHosts(content string) (string, errors.Error) {
+	file, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return "", errors.Convert(err)
+	}
+	defer file.Close()
+	_, err = file.WriteString(content)
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", errors.Convert(err)
+	}
+	return file.Name(), nil
+}
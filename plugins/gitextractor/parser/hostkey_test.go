@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ssh2 "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func newTestHostKey(t *testing.T) ssh2.PublicKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	key, err := ssh2.NewPublicKey(pub)
+	assert.Nil(t, err)
+	return key
+}
+
+func TestNewHostKeyCallback(t *testing.T) {
+	trusted := newTestHostKey(t)
+	other := newTestHostKey(t)
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+	knownHosts := knownhosts.Line([]string{"github.com"}, trusted)
+
+	callback, err := NewHostKeyCallback(HostKeyOptions{KnownHosts: knownHosts})
+	assert.Nil(t, err)
+	assert.Nil(t, callback("github.com:22", remote, trusted))
+	assert.ErrorContains(t, callback("github.com:22", remote, other), "does not match")
+	assert.ErrorContains(t, callback("gitlab.com:22", remote, trusted), "unknown ssh host key")
+
+	// the global known_hosts are not trusted once the connection has its own
+	globalFile, err := writeKnownHosts(knownhosts.Line([]string{"github.com"}, other))
+	assert.Nil(t, err)
+	defer os.Remove(globalFile)
+	callback, err = NewHostKeyCallback(HostKeyOptions{KnownHostsFiles: []string{globalFile}})
+	assert.Nil(t, err)
+	assert.Nil(t, callback("github.com:22", remote, other))
+	callback, err = NewHostKeyCallback(HostKeyOptions{KnownHostsFiles: []string{globalFile}, KnownHosts: knownHosts})
+	assert.Nil(t, err)
+	assert.Nil(t, callback("github.com:22", remote, trusted))
+	assert.ErrorContains(t, callback("github.com:22", remote, other), "does not match")
+
+	callback, err = NewHostKeyCallback(HostKeyOptions{KnownHostsFiles: []string{"/not/exists"}})
+	assert.Nil(t, err)
+	assert.ErrorContains(t, callback("github.com:22", remote, trusted), "no known_hosts were configured")
+
+	callback, err = NewHostKeyCallback(HostKeyOptions{
+		KnownHosts:   knownHosts,
+		Fingerprints: []string{ssh2.FingerprintSHA256(other)},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, callback("github.com:22", remote, other))
+	assert.ErrorContains(t, callback("github.com:22", remote, trusted), "pinned fingerprint")
+}
@@ -0,0 +1,279 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	git "github.com/libgit2/git2go/v33"
+	ssh2 "golang.org/x/crypto/ssh"
+)
+
+// mirrorRefSpec mirrors all refs of the remote, including branches and tags
+const mirrorRefSpec = "+refs/*:refs/*"
+
+// mirrorLocks serializes the usage of the same mirror, a mirror is held from fetching till the GitRepo is closed
+var mirrorLocks sync.Map
+
+func lockMirror(dir string) func() {
+	lock, _ := mirrorLocks.LoadOrStore(dir, &sync.Mutex{})
+	mutex := lock.(*sync.Mutex)
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+// mirrorDirOf returns the cache directory of the repo, credentials embedded in the url are not part of the key
+func mirrorDirOf(cacheDir, repoUrl string) string {
+	key := repoUrl
+	if u, err := url.Parse(repoUrl); err == nil && u.User != nil {
+		u.User = nil
+		key = u.String()
+	}
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:]))
+}
+
+// EvictMirrors removes the mirrors under the cache directory which have not been fetched for maxIdle,
+// mirrors being used are kept. It returns the directories removed
+func EvictMirrors(cacheDir string, maxIdle time.Duration) ([]string, errors.Error) {
+	entries, err := os.ReadDir(cacheDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	evicted := make([]string, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(cacheDir, entry.Name())
+		lock, _ := mirrorLocks.LoadOrStore(dir, &sync.Mutex{})
+		mutex := lock.(*sync.Mutex)
+		if !mutex.TryLock() {
+			continue
+		}
+		info, err := os.Stat(dir)
+		if err == nil && time.Since(info.ModTime()) > maxIdle {
+			err = os.RemoveAll(dir)
+			if err == nil {
+				evicted = append(evicted, dir)
+			}
+		}
+		mutex.Unlock()
+		if err != nil && !os.IsNotExist(err) {
+			return evicted, errors.Convert(err)
+		}
+	}
+	return evicted, nil
+}
+
+func (l *GitRepoCreator) fetchOptionsOverHTTP(user, password, proxy string) git.FetchOptions {
+	fetchOptions := git.FetchOptions{}
+	if proxy != "" {
+		fetchOptions.ProxyOptions.Type = git.ProxyTypeAuto
+		fetchOptions.ProxyOptions.Url = proxy
+	}
+	if user != "" {
+		auth := fmt.Sprintf("Authorization: Basic %s", base64.StdEncoding.EncodeToString([]byte(user+":"+password)))
+		fetchOptions.Headers = []string{auth}
+	}
+	return fetchOptions
+}
+
+// MirrorOverHTTP fetches the repo into the bare mirror under the cache directory, the mirror would be cloned
+// if it does not exist or is broken. Credentials are never written into the mirror, they are sent as headers only,
+// so that a rotated token takes effect on the next fetch
+func (l *GitRepoCreator) MirrorOverHTTP(repoId, repoUrl, user, password, proxy string) (*GitRepo, errors.Error) {
+	repoUrl, user, password = stripCredentials(repoUrl, user, password)
+	return l.withMirrorDirectory(repoUrl, func(dir string, exists bool) (*GitRepo, error) {
+		fetchOptions := l.fetchOptionsOverHTTP(user, password, proxy)
+		if exists {
+			repo, err := git.OpenRepository(dir)
+			if err == nil {
+				err = fetchMirror(repo, repoUrl, fetchOptions)
+				if err == nil {
+					return l.newGitRepo(repoId, repo), nil
+				}
+				repo.Free()
+			}
+			l.logger.Warn(err, "failed to fetch the mirror of %s, cloning it again", repoId)
+			if err = resetDirectory(dir); err != nil {
+				return nil, err
+			}
+		}
+		clonedRepo, err := git.Clone(repoUrl, dir, &git.CloneOptions{Bare: true, FetchOptions: fetchOptions})
+		if err != nil {
+			return nil, err
+		}
+		// the clone only maps the branches, all refs are mirrored from now on
+		err = fetchMirror(clonedRepo, repoUrl, fetchOptions)
+		if err != nil {
+			clonedRepo.Free()
+			return nil, err
+		}
+		return l.newGitRepo(repoId, clonedRepo), nil
+	})
+}
+
+// fetchMirror updates all refs of the mirror through an anonymous remote, the stored origin is left untouched
+func fetchMirror(repo *git.Repository, repoUrl string, fetchOptions git.FetchOptions) error {
+	remote, err := repo.Remotes.CreateAnonymous(repoUrl)
+	if err != nil {
+		return err
+	}
+	defer remote.Free()
+	fetchOptions.Prune = git.FetchPruneOn
+	fetchOptions.DownloadTags = git.DownloadTagsAll
+	return remote.Fetch([]string{mirrorRefSpec}, &fetchOptions, "")
+}
+
+// stripCredentials removes the credentials from the url, they are returned as the user and password
+// unless those were given explicitly
+func stripCredentials(repoUrl, user, password string) (string, string, string) {
+	u, err := url.Parse(repoUrl)
+	if err != nil || u.User == nil {
+		return repoUrl, user, password
+	}
+	if user == "" {
+		user = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	u.User = nil
+	return u.String(), user, password
+}
+
+// MirrorOverSSH is the ssh counterpart of MirrorOverHTTP
+func (l *GitRepoCreator) MirrorOverSSH(repoId, repoUrl, privateKey, passphrase string) (*GitRepo, errors.Error) {
+	return l.withMirrorDirectory(repoUrl, func(dir string, exists bool) (*GitRepo, error) {
+		pk, err := base64.StdEncoding.DecodeString(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			err = fetchOverSSH(dir, passphrase, pk, l.hostKeyCallback)
+			if err == nil {
+				return l.LocalRepo(dir, repoId)
+			}
+			l.logger.Warn(err, "failed to fetch the mirror of %s, cloning it again", repoId)
+			if err = resetDirectory(dir); err != nil {
+				return nil, err
+			}
+		}
+		err = cloneOverSSH(repoUrl, dir, passphrase, pk, l.hostKeyCallback)
+		if err != nil {
+			return nil, err
+		}
+		return l.LocalRepo(dir, repoId)
+	})
+}
+
+func fetchOverSSH(dir, passphrase string, pk []byte, hostKeyCallback ssh2.HostKeyCallback) errors.Error {
+	key, err := newPublicKeys(passphrase, pk, hostKeyCallback)
+	if err != nil {
+		return err
+	}
+	repo, err1 := gogit.PlainOpen(dir)
+	if err1 != nil {
+		return errors.Convert(err1)
+	}
+	err1 = repo.Fetch(&gogit.FetchOptions{
+		Auth:     key,
+		RefSpecs: []config.RefSpec{mirrorRefSpec},
+		Tags:     gogit.AllTags,
+		Force:    true,
+	})
+	if err1 != nil && err1 != gogit.NoErrAlreadyUpToDate {
+		return errors.Convert(err1)
+	}
+	return pruneOverSSH(repo, key)
+}
+
+// pruneOverSSH removes the refs deleted from the remote, go-git doesn't prune on fetching
+func pruneOverSSH(repo *gogit.Repository, key transport.AuthMethod) errors.Error {
+	remote, err := repo.Remote(gogit.DefaultRemoteName)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	remoteRefs, err := remote.List(&gogit.ListOptions{Auth: key})
+	if err != nil {
+		return errors.Convert(err)
+	}
+	exists := make(map[plumbing.ReferenceName]bool, len(remoteRefs))
+	for _, ref := range remoteRefs {
+		exists[ref.Name()] = true
+	}
+	refs, err := repo.References()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	defer refs.Close()
+	return errors.Convert(refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Name() == plumbing.HEAD || ref.Type() != plumbing.HashReference || exists[ref.Name()] {
+			return nil
+		}
+		return repo.Storer.RemoveReference(ref.Name())
+	}))
+}
+
+func resetDirectory(dir string) error {
+	err := os.RemoveAll(dir)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+func (l *GitRepoCreator) withMirrorDirectory(repoUrl string, f func(dir string, exists bool) (*GitRepo, error)) (*GitRepo, errors.Error) {
+	dir := mirrorDirOf(l.mirrorCacheDir, repoUrl)
+	unlock := lockMirror(dir)
+	_, err := os.Stat(filepath.Join(dir, "HEAD"))
+	exists := err == nil
+	if !exists {
+		err = resetDirectory(dir)
+		if err != nil {
+			unlock()
+			return nil, errors.Convert(err)
+		}
+	}
+	repo, err := f(dir, exists)
+	if err != nil {
+		unlock()
+		return nil, errors.Convert(err)
+	}
+	// the modification time of the directory tells when the mirror was used lastly, see EvictMirrors
+	now := time.Now()
+	_ = os.Chtimes(dir, now, now)
+	repo.cleanup = unlock
+	repo.incremental = true
+	return repo, nil
+}
@@ -44,6 +44,8 @@ type GitRepo struct {
 	id      string
 	repo    *git.Repository
 	cleanup func()
+	// incremental means commits already collected for the repo would be skipped
+	incremental bool
 }
 
 // CollectAll The main parser subtask
@@ -229,6 +231,10 @@ func (r *GitRepo) CollectCommits(subtaskCtx plugin.SubTaskContext) errors.Error
 	for _, component := range components {
 		componentMap[component.Name] = regexp.MustCompile(component.PathRegex)
 	}
+	existingCommits, err := r.getExistingCommits(db)
+	if err != nil {
+		return err
+	}
 	odb, err := errors.Convert01(r.repo.Odb())
 	if err != nil {
 		return err
@@ -239,6 +245,10 @@ func (r *GitRepo) CollectCommits(subtaskCtx plugin.SubTaskContext) errors.Error
 			return subtaskCtx.GetContext().Err()
 		default:
 		}
+		if _, ok := existingCommits[id.String()]; ok {
+			subtaskCtx.IncProgress(1)
+			return nil
+		}
 		commit, err1 := r.repo.LookupCommit(id)
 		if err1 != nil && err1.Error() != TypeNotMatchError {
 			return errors.Convert(err1)
@@ -297,6 +307,30 @@ func (r *GitRepo) CollectCommits(subtaskCtx plugin.SubTaskContext) errors.Error
 	}))
 }
 
+// getExistingCommits returns the shas collected by previous runs, which is empty unless the repo is incremental
+func (r *GitRepo) getExistingCommits(db dal.Dal) (map[string]struct{}, errors.Error) {
+	existingCommits := make(map[string]struct{})
+	if !r.incremental {
+		return existingCommits, nil
+	}
+	var shas []string
+	err := db.Pluck(
+		"rc.commit_sha",
+		&shas,
+		dal.From("repo_commits rc"),
+		dal.Join("INNER JOIN commits c ON (c.sha = rc.commit_sha)"),
+		dal.Where("rc.repo_id = ?", r.id),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, sha := range shas {
+		existingCommits[sha] = struct{}{}
+	}
+	r.logger.Info("%d commits of %s were collected before, skipping them", len(existingCommits), r.id)
+	return existingCommits, nil
+}
+
 func (r *GitRepo) storeParentCommits(commitSha string, commit *git.Commit) errors.Error {
 	var commitParents []*code.CommitParent
 	for i := uint(0); i < commit.ParentCount(); i++ {
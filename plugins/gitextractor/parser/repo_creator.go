@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
 	git "github.com/libgit2/git2go/v33"
+	ssh2 "golang.org/x/crypto/ssh"
 )
 
 const (
@@ -30,8 +31,10 @@ const (
 )
 
 type GitRepoCreator struct {
-	store  models.Store
-	logger log.Logger
+	store           models.Store
+	logger          log.Logger
+	mirrorCacheDir  string
+	hostKeyCallback ssh2.HostKeyCallback
 }
 
 func NewGitRepoCreator(store models.Store, logger log.Logger) *GitRepoCreator {
@@ -41,6 +44,18 @@ func NewGitRepoCreator(store models.Store, logger log.Logger) *GitRepoCreator {
 	}
 }
 
+// WithMirrorCache makes MirrorOverHTTP and MirrorOverSSH keep bare mirrors under the dir
+func (l *GitRepoCreator) WithMirrorCache(dir string) *GitRepoCreator {
+	l.mirrorCacheDir = dir
+	return l
+}
+
+// WithHostKeyCallback sets the callback verifying ssh host keys, CloneOverSSH and MirrorOverSSH fail without it
+func (l *GitRepoCreator) WithHostKeyCallback(hostKeyCallback ssh2.HostKeyCallback) *GitRepoCreator {
+	l.hostKeyCallback = hostKeyCallback
+	return l
+}
+
 // LocalRepo open a local repository
 func (l *GitRepoCreator) LocalRepo(repoPath, repoId string) (*GitRepo, errors.Error) {
 	repo, err := git.OpenRepository(repoPath)
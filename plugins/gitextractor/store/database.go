@@ -32,8 +32,10 @@ const BathSize = 100
 
 type Database struct {
 	driver *helper.BatchSaveDivider
-	table  string
-	params string
+	// commitDriver saves commits and their related records, it is the driver itself unless the store is incremental
+	commitDriver *helper.BatchSaveDivider
+	table        string
+	params       string
 }
 
 func NewDatabase(basicRes context.BasicRes, repoId string) *Database {
@@ -47,6 +49,16 @@ func NewDatabase(basicRes context.BasicRes, repoId string) *Database {
 		database.table,
 		database.params,
 	)
+	database.commitDriver = database.driver
+	return database
+}
+
+// NewIncrementalDatabase creates a Database which keeps commits and their related records saved by previous runs,
+// refs and line changes are still replaced as a whole
+func NewIncrementalDatabase(basicRes context.BasicRes, repoId string) *Database {
+	database := NewDatabase(basicRes, repoId)
+	// an empty table prevents the divider from deleting outdated records
+	database.commitDriver = helper.NewBatchSaveDivider(basicRes, BathSize, "", "")
 	return database
 }
 
@@ -56,7 +68,7 @@ func (d *Database) updateRawDataFields(rawData *common.RawDataOrigin) {
 }
 
 func (d *Database) RepoCommits(repoCommit *code.RepoCommit) errors.Error {
-	batch, err := d.driver.ForType(reflect.TypeOf(repoCommit))
+	batch, err := d.commitDriver.ForType(reflect.TypeOf(repoCommit))
 	if err != nil {
 		return err
 	}
@@ -71,7 +83,7 @@ func (d *Database) Commits(commit *code.Commit) errors.Error {
 		FullName:     commit.AuthorName,
 		UserName:     commit.AuthorName,
 	}
-	accountBatch, err := d.driver.ForType(reflect.TypeOf(account))
+	accountBatch, err := d.commitDriver.ForType(reflect.TypeOf(account))
 	if err != nil {
 		return err
 	}
@@ -80,7 +92,7 @@ func (d *Database) Commits(commit *code.Commit) errors.Error {
 	if err != nil {
 		return err
 	}
-	commitBatch, err := d.driver.ForType(reflect.TypeOf(commit))
+	commitBatch, err := d.commitDriver.ForType(reflect.TypeOf(commit))
 	if err != nil {
 		return err
 	}
@@ -98,7 +110,7 @@ func (d *Database) Refs(ref *code.Ref) errors.Error {
 }
 
 func (d *Database) CommitFiles(file *code.CommitFile) errors.Error {
-	batch, err := d.driver.ForType(reflect.TypeOf(file))
+	batch, err := d.commitDriver.ForType(reflect.TypeOf(file))
 	if err != nil {
 		return err
 	}
@@ -107,7 +119,7 @@ func (d *Database) CommitFiles(file *code.CommitFile) errors.Error {
 }
 
 func (d *Database) CommitFileComponents(commitFileComponent *code.CommitFileComponent) errors.Error {
-	batch, err := d.driver.ForType(reflect.TypeOf(commitFileComponent))
+	batch, err := d.commitDriver.ForType(reflect.TypeOf(commitFileComponent))
 	if err != nil {
 		return err
 	}
@@ -137,7 +149,7 @@ func (d *Database) CommitParents(pp []*code.CommitParent) errors.Error {
 	if len(pp) == 0 {
 		return nil
 	}
-	batch, err := d.driver.ForType(reflect.TypeOf(pp[0]))
+	batch, err := d.commitDriver.ForType(reflect.TypeOf(pp[0]))
 	if err != nil {
 		return err
 	}
@@ -152,5 +164,11 @@ func (d *Database) CommitParents(pp []*code.CommitParent) errors.Error {
 }
 
 func (d *Database) Close() errors.Error {
+	if d.commitDriver != d.driver {
+		err := d.commitDriver.Close()
+		if err != nil {
+			return err
+		}
+	}
 	return d.driver.Close()
 }
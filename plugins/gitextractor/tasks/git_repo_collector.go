@@ -18,6 +18,8 @@ limitations under the License.
 package tasks
 
 import (
+	"context"
+	"net/url"
 	"strings"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -34,6 +36,10 @@ type GitExtractorOptions struct {
 	PrivateKey string `json:"privateKey"`
 	Passphrase string `json:"passphrase"`
 	Proxy      string `json:"proxy"`
+	// KnownHosts is the content of the known_hosts file of the connection, GIT_SSH_KNOWN_HOSTS is ignored once set
+	KnownHosts string `json:"knownHosts"`
+	// HostKeyFingerprints pins the SHA256 fingerprints of ssh host keys, known_hosts are ignored once set
+	HostKeyFingerprints []string `json:"hostKeyFingerprints"`
 }
 
 func (o GitExtractorOptions) Valid() errors.Error {
@@ -50,6 +56,33 @@ func (o GitExtractorOptions) Valid() errors.Error {
 	return nil
 }
 
+// ResolveSecrets resolves the credentials kept by external secret providers, including the password within Url
+func (o *GitExtractorOptions) ResolveSecrets(ctx context.Context) errors.Error {
+	for _, secret := range []*string{&o.Password, &o.PrivateKey, &o.Passphrase} {
+		resolved, err := plugin.ResolveSecret(ctx, *secret)
+		if err != nil {
+			return err
+		}
+		*secret = resolved
+	}
+	if !strings.HasPrefix(o.Url, "http") {
+		return nil
+	}
+	u, err := url.Parse(o.Url)
+	if err != nil {
+		return errors.BadInput.Wrap(err, "wrong url")
+	}
+	if password, ok := u.User.Password(); ok && plugin.IsSecretReference(password) {
+		resolved, err := plugin.ResolveSecret(ctx, password)
+		if err != nil {
+			return err
+		}
+		u.User = url.UserPassword(u.User.Username(), resolved)
+		o.Url = u.String()
+	}
+	return nil
+}
+
 func CollectGitCommits(subTaskCtx plugin.SubTaskContext) errors.Error {
 	repo := getGitRepo(subTaskCtx)
 	if count, err := repo.CountCommits(subTaskCtx.GetContext()); err != nil {
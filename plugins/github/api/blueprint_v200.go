@@ -52,10 +52,15 @@ func MakeDataSourcePipelinePlanV200(subtaskMetas []plugin.SubTaskMeta, connectio
 
 	// needed for the connection to populate its access tokens
 	// if AppKey authentication method is selected
-	_, err = helper.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	_, resolvedConnection, err := helper.NewApiClientWithResolvedConnection(context.TODO(), basicRes, connection)
 	if err != nil {
 		return nil, nil, err
 	}
+	// the references to the secrets kept by external providers go into the plan, gitextractor resolves them
+	// on its own, while the installation tokens of Apps are not stored anywhere but fetched right here
+	if connection.AuthMethod == "AppKey" {
+		connection.Token = resolvedConnection.Token
+	}
 
 	plan := make(plugin.PipelinePlan, len(bpScopes))
 	plan, err = makeDataSourcePipelinePlanV200(subtaskMetas, plan, bpScopes, connection, syncPolicy)
@@ -133,12 +138,12 @@ func makeDataSourcePipelinePlanV200(
 			cloneUrl.User = url.UserPassword("git", token)
 			stage = append(stage, &plugin.PipelineTask{
 				Plugin: "gitextractor",
-				Options: map[string]interface{}{
+				Options: connection.GitHostKeys.AddToGitExtractorOptions(map[string]interface{}{
 					"url":    cloneUrl.String(),
 					"name":   githubRepo.FullName,
 					"repoId": didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(connection.ID, githubRepo.GithubId),
 					"proxy":  connection.Proxy,
-				},
+				}),
 			})
 
 		}
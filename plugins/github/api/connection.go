@@ -81,10 +81,12 @@ func TestConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 		return nil, errors.Convert(e)
 	}
 
-	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, &conn)
+	apiClient, resolvedConn, err := api.NewApiClientWithResolvedConnection(context.TODO(), basicRes, &conn)
 	if err != nil {
 		return nil, err
 	}
+	// the connection under test is never saved, so it is fine to go on with the secrets resolved
+	conn = *resolvedConn
 
 	githubApiResponse := &GithubTestConnResponse{}
 
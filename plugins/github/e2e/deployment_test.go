@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestGithubDeploymentDataFlow(t *testing.T) {
+	var github impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", github)
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+		RegexEnricher: helper.NewRegexEnricher(),
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_deployments.csv", "_raw_github_api_deployments")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_deployment_statuses.csv", "_raw_github_api_deployment_statuses")
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_repos.csv", &models.GithubRepo{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubDeployment{})
+	dataflowTester.FlushTabler(&models.GithubDeploymentStatus{})
+	dataflowTester.Subtask(tasks.ExtractDeploymentsMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractDeploymentStatusesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GithubDeployment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_deployments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GithubDeploymentStatus{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_github_deployment_statuses.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion, environments are mapped by their names when productionPattern is omitted and
+	// the deployment without a creation date is skipped
+	dataflowTester.FlushTabler(&models.GithubRun{})
+	dataflowTester.FlushTabler(&models.GithubJob{})
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"branch",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		),
+	)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"type",
+			"environment",
+			"duration_sec",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+		),
+	)
+	dataflowTester.VerifyTable(
+		devops.CiCDPipelineCommit{},
+		"./snapshot_tables/cicd_pipeline_commits_deployments.csv",
+		e2ehelper.ColumnWithRawData(
+			"pipeline_id",
+			"commit_sha",
+			"branch",
+			"repo_id",
+			"repo_url",
+		),
+	)
+
+	// verify conversion with productionPattern
+	taskData.Options.ScopeConfig = &models.GithubScopeConfig{ProductionPattern: "^production$"}
+	_ = taskData.RegexEnricher.TryAdd(devops.PRODUCTION, taskData.Options.ScopeConfig.ProductionPattern)
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks_deployments_prod_regex.csv",
+		e2ehelper.ColumnWithRawData("environment"),
+	)
+
+	// verify the deployment made by a workflow run already marked as a deployment is left to the run
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_runs_deployments.csv", &models.GithubRun{})
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.Subtask(tasks.ConvertDeploymentsMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines_deployments_skip_runs.csv",
+		e2ehelper.ColumnWithRawData("name"),
+	)
+}
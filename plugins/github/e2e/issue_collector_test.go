@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestIssueCollector(t *testing.T) {
+	var github impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", github)
+
+	// created through the model so the token gets encrypted, the rate limit is set so no request is made to probe it
+	dataflowTester.FlushTabler(&models.GithubConnection{})
+	connection := &models.GithubConnection{
+		BaseConnection: helper.BaseConnection{Name: "github", Model: common.Model{ID: 1}},
+		GithubConn: models.GithubConn{
+			RestConnection: helper.RestConnection{Endpoint: "https://api.github.com/", RateLimitPerHour: 5000},
+			MultiAuth:      helper.MultiAuth{AuthMethod: "AccessToken"},
+			GithubAccessToken: models.GithubAccessToken{
+				AccessToken: helper.AccessToken{Token: "ghp_test"},
+			},
+		},
+	}
+	if err := dataflowTester.Dal.Create(connection); err != nil {
+		panic(err)
+	}
+	// the repo is known so the options are enriched without requesting it
+	dataflowTester.FlushTabler(&models.GithubRepo{})
+	repo := &models.GithubRepo{
+		ConnectionId: 1,
+		GithubId:     270661512,
+		Name:         "incubator-devlake",
+		FullName:     "apache/incubator-devlake",
+	}
+	if err := dataflowTester.Dal.Create(repo); err != nil {
+		panic(err)
+	}
+
+	// the issues since timeAfter are listed on two pages told by the link header of the first one,
+	// the pull request listed along with them is skipped by the extractor
+	dataflowTester.FlushTabler(&coreModels.CollectorLatestState{})
+	dataflowTester.FlushRawTable("_raw_github_api_issues")
+	options := map[string]interface{}{
+		"connectionId": 1,
+		"name":         "apache/incubator-devlake",
+		"timeAfter":    "2023-01-01T00:00:00Z",
+	}
+	dataflowTester.SubtaskWithCassette(tasks.CollectApiIssuesMeta, "./cassettes/issues.json", options)
+
+	dataflowTester.FlushTabler(&models.GithubIssue{})
+	dataflowTester.FlushTabler(&models.GithubIssueLabel{})
+	dataflowTester.FlushTabler(&models.GithubRepoAccount{})
+	dataflowTester.FlushTabler(&models.GithubIssueAssignee{})
+	dataflowTester.Subtask(tasks.ExtractApiIssuesMeta, &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "apache/incubator-devlake",
+			GithubId:     270661512,
+			ScopeConfig:  new(models.GithubScopeConfig),
+		},
+	})
+	dataflowTester.VerifyTable(
+		models.GithubIssue{},
+		"./snapshot_tables/_tool_github_issues_collected.csv",
+		[]string{
+			"connection_id",
+			"github_id",
+			"repo_id",
+			"number",
+			"state",
+			"title",
+			"author_id",
+			"author_name",
+			"url",
+			"lead_time_minutes",
+			"closed_at",
+			"github_created_at",
+			"github_updated_at",
+		},
+	)
+}
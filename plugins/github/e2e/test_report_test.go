@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+func TestGithubTestReportDataFlow(t *testing.T) {
+	var github impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", github)
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+			ScopeConfig: &models.GithubScopeConfig{
+				TestReportPattern: "^junit",
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_run_artifacts.csv", "_raw_github_api_run_artifacts")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_github_api_test_reports.csv", "_raw_github_api_test_reports")
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_github_runs.csv", &models.GithubRun{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GithubRunArtifact{})
+	dataflowTester.Subtask(tasks.ExtractRunArtifactsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.GithubRunArtifact{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_github_run_artifacts.csv",
+		TargetFields: []string{
+			"connection_id", "repo_id", "id", "run_id", "name", "size_in_bytes",
+			"expired", "github_created_at", "github_expires_at",
+		},
+	})
+
+	dataflowTester.FlushTabler(&models.GithubTestSuite{})
+	dataflowTester.FlushTabler(&models.GithubTestCase{})
+	dataflowTester.Subtask(tasks.ExtractTestReportsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.GithubTestSuite{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_github_test_suites.csv",
+		TargetFields: []string{
+			"connection_id", "repo_id", "artifact_id", "suite_index", "run_id", "name", "duration",
+			"total_count", "passed_count", "failed_count", "error_count", "skipped_count",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(models.GithubTestCase{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_github_test_cases.csv",
+		TargetFields: []string{
+			"connection_id", "repo_id", "artifact_id", "suite_index", "case_index", "run_id",
+			"class_name", "name", "result", "duration", "message",
+		},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.TestSuite{})
+	dataflowTester.FlushTabler(&devops.TestCase{})
+	dataflowTester.FlushTabler(&devops.TestCaseRun{})
+	dataflowTester.Subtask(tasks.ConvertTestReportsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(devops.TestSuite{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/test_suites.csv",
+		TargetFields: []string{
+			"id", "name", "cicd_scope_id", "cicd_pipeline_id", "cicd_task_id", "duration_sec", "total_count",
+			"passed_count", "failed_count", "error_count", "skipped_count", "finished_date",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(devops.TestCase{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/test_cases.csv",
+		TargetFields: []string{"id", "cicd_scope_id", "class_name", "name"},
+	})
+	dataflowTester.VerifyTableWithOptions(devops.TestCaseRun{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/test_case_runs.csv",
+		TargetFields: []string{
+			"id", "test_case_id", "test_suite_id", "cicd_scope_id", "cicd_pipeline_id", "cicd_task_id",
+			"result", "duration_sec", "message", "finished_date",
+		},
+	})
+}
@@ -48,6 +48,7 @@ func main() {
 	issueTypeRequirement := cmd.Flags().String("issueTypeRequirement", "^(feat|feature|proposal|requirement)$", "issue type requirement")
 	deploymentPattern := cmd.Flags().StringP("deployment", "", "", "deployment pattern")
 	productionPattern := cmd.Flags().StringP("production", "", "", "production pattern")
+	testReportPattern := cmd.Flags().StringP("testReport", "", "", "pattern of artifacts holding JUnit XML test reports")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
@@ -67,6 +68,7 @@ func main() {
 				"issueTypeRequirement": *issueTypeRequirement,
 				"deploymentPattern":    *deploymentPattern,
 				"productionPattern":    *productionPattern,
+				"testReportPattern":    *testReportPattern,
 			},
 		})
 	}
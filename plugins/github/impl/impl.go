@@ -72,6 +72,8 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubAccountOrg{},
 		&models.GithubCommit{},
 		&models.GithubCommitStat{},
+		&models.GithubDeployment{},
+		&models.GithubDeploymentStatus{},
 		&models.GithubIssue{},
 		&models.GithubIssueComment{},
 		&models.GithubIssueEvent{},
@@ -89,6 +91,9 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubRepoCommit{},
 		&models.GithubReviewer{},
 		&models.GithubRun{},
+		&models.GithubRunArtifact{},
+		&models.GithubTestSuite{},
+		&models.GithubTestCase{},
 		&models.GithubIssueAssignee{},
 		&models.GithubScopeConfig{},
 	}
@@ -134,10 +139,21 @@ func (p Github) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectJobsMeta,
 		tasks.ExtractJobsMeta,
 		tasks.ConvertJobsMeta,
+		tasks.CollectRunArtifactsMeta,
+		tasks.ExtractRunArtifactsMeta,
+		tasks.CollectTestReportsMeta,
+		tasks.ExtractTestReportsMeta,
+		tasks.ConvertTestReportsMeta,
+		tasks.CollectDeploymentsMeta,
+		tasks.ExtractDeploymentsMeta,
+		tasks.CollectDeploymentStatusesMeta,
+		tasks.ExtractDeploymentStatusesMeta,
+		tasks.ConvertDeploymentsMeta,
 		tasks.EnrichPullRequestIssuesMeta,
 		tasks.ConvertRepoMeta,
 		tasks.ConvertIssuesMeta,
 		tasks.ConvertIssueAssigneeMeta,
+		tasks.GenerateIssueSnapshotsMeta,
 		tasks.ConvertCommitsMeta,
 		tasks.ConvertIssueLabelsMeta,
 		tasks.ConvertPullRequestCommitsMeta,
@@ -22,13 +22,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api/apihelperabstract"
 	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
 )
 
 // GithubAccessToken supports fetching data with multiple tokens
@@ -41,6 +44,9 @@ type GithubAccessToken struct {
 type GithubAppKey struct {
 	helper.AppKey  `mapstructure:",squash"`
 	InstallationID int `mapstructure:"installationId" validate:"required" json:"installationId"`
+	// InstallationIDs are comma separated IDs of other installations of the App, their tokens are used in turns
+	// with the one of InstallationID so their rate limits are pooled
+	InstallationIDs string `mapstructure:"installationIds" json:"installationIds"`
 }
 
 // GithubConn holds the essential information to connect to the Github API
@@ -49,6 +55,7 @@ type GithubConn struct {
 	helper.MultiAuth      `mapstructure:",squash"`
 	GithubAccessToken     `mapstructure:",squash" authMethod:"AccessToken"`
 	GithubAppKey          `mapstructure:",squash" authMethod:"AppKey"`
+	appTokens             *githubAppTokens
 }
 
 // PrepareApiClient splits Token to tokens for SetupAuthentication to utilize
@@ -59,13 +66,27 @@ func (conn *GithubConn) PrepareApiClient(apiClient apihelperabstract.ApiClientAb
 	}
 
 	if conn.AuthMethod == "AppKey" && conn.InstallationID != 0 {
-		token, err := conn.getInstallationAccessToken(apiClient)
+		installationIds, err := conn.GetInstallationIds()
 		if err != nil {
 			return err
 		}
-
-		conn.Token = token.Token
-		conn.tokens = []string{token.Token}
+		appTokens := &githubAppTokens{
+			appKey:          &conn.GithubAppKey,
+			apiClient:       apiClient,
+			installationIds: installationIds,
+		}
+		tokens := make([]string, 0, len(installationIds))
+		for _, installationId := range installationIds {
+			token, err := conn.getInstallationAccessToken(apiClient, installationId)
+			if err != nil {
+				return err
+			}
+			appTokens.tokens = append(appTokens.tokens, token)
+			tokens = append(tokens, token.Token)
+		}
+		conn.appTokens = appTokens
+		conn.Token = tokens[0]
+		conn.tokens = tokens
 	}
 
 	return nil
@@ -73,6 +94,19 @@ func (conn *GithubConn) PrepareApiClient(apiClient apihelperabstract.ApiClientAb
 
 // SetupAuthentication sets up the HTTP Request Authentication
 func (conn *GithubConn) SetupAuthentication(req *http.Request) errors.Error {
+	// requests authenticated on their own, i.e. by the JWT of the App, are left as they are
+	if req.Header.Get("Authorization") != "" {
+		return nil
+	}
+	// installation tokens are refreshed before they expire, so long collections keep going
+	if conn.appTokens != nil {
+		token, err := conn.appTokens.next()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token.Token))
+		return nil
+	}
 	// Rotates token on each request.
 	if len(conn.tokens) > 0 {
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", conn.tokens[conn.tokenIndex]))
@@ -87,10 +121,39 @@ func (gat *GithubAccessToken) GetTokensCount() int {
 	return len(gat.tokens)
 }
 
+// GetTokenSource returns the token source for oauth2 clients, i.e. the graphql one, which authenticates the same way
+// as SetupAuthentication does
+func (conn *GithubConn) GetTokenSource() oauth2.TokenSource {
+	if conn.appTokens != nil {
+		return conn.appTokens
+	}
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: strings.Split(conn.Token, ",")[0]})
+}
+
+// GetInstallationIds returns InstallationID followed by InstallationIDs
+func (gak *GithubAppKey) GetInstallationIds() ([]int, errors.Error) {
+	installationIds := []int{gak.InstallationID}
+	for _, id := range strings.Split(gak.InstallationIDs, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		installationId, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid installation id %s", id))
+		}
+		if installationId != gak.InstallationID {
+			installationIds = append(installationIds, installationId)
+		}
+	}
+	return installationIds, nil
+}
+
 // GithubConnection holds GithubConn plus ID/Name for database storage
 type GithubConnection struct {
 	helper.BaseConnection `mapstructure:",squash"`
 	GithubConn            `mapstructure:",squash"`
+	helper.GitHostKeys    `mapstructure:",squash"`
 	EnableGraphql         bool `mapstructure:"enableGraphql" json:"enableGraphql"`
 }
 
@@ -104,7 +167,45 @@ type GithubUserOfToken struct {
 }
 
 type InstallationToken struct {
-	Token string `json:"token"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// installationTokenRefreshAhead is how long before its expiry an installation token gets refreshed
+const installationTokenRefreshAhead = 5 * time.Minute
+
+// githubAppTokens hands out the installation tokens of an App in turns, and refreshes them before they expire
+type githubAppTokens struct {
+	sync.Mutex
+	appKey          *GithubAppKey
+	apiClient       apihelperabstract.ApiClientAbstract
+	installationIds []int
+	tokens          []*InstallationToken
+	index           int
+}
+
+func (t *githubAppTokens) next() (*InstallationToken, errors.Error) {
+	t.Lock()
+	defer t.Unlock()
+	i := t.index
+	t.index = (t.index + 1) % len(t.tokens)
+	if time.Until(t.tokens[i].ExpiresAt) < installationTokenRefreshAhead {
+		token, err := t.appKey.getInstallationAccessToken(t.apiClient, t.installationIds[i])
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to refresh the token of installation %d", t.installationIds[i]))
+		}
+		t.tokens[i] = token
+	}
+	return t.tokens[i], nil
+}
+
+// Token implements oauth2.TokenSource
+func (t *githubAppTokens) Token() (*oauth2.Token, error) {
+	token, err := t.next()
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{AccessToken: token.Token, TokenType: "Bearer", Expiry: token.ExpiresAt}, nil
 }
 
 type GithubApp struct {
@@ -149,6 +250,7 @@ func (gak *GithubAppKey) CreateJwt() (string, errors.Error) {
 
 func (gak *GithubAppKey) getInstallationAccessToken(
 	apiClient apihelperabstract.ApiClientAbstract,
+	installationId int,
 ) (*InstallationToken, errors.Error) {
 
 	jwt, err := gak.CreateJwt()
@@ -156,7 +258,7 @@ func (gak *GithubAppKey) getInstallationAccessToken(
 		return nil, err
 	}
 
-	resp, err := apiClient.Post(fmt.Sprintf("/app/installations/%d/access_tokens", gak.InstallationID), nil, nil, http.Header{
+	resp, err := apiClient.Post(fmt.Sprintf("/app/installations/%d/access_tokens", installationId), nil, nil, http.Header{
 		"Authorization": []string{fmt.Sprintf("Bearer %s", jwt)},
 	})
 	if err != nil {
@@ -167,12 +269,19 @@ func (gak *GithubAppKey) getInstallationAccessToken(
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode != http.StatusCreated {
+		return nil, errors.HttpStatus(resp.StatusCode).New(fmt.Sprintf("failed to get the access token of installation %d: %s", installationId, string(body)))
+	}
 
 	var installationToken InstallationToken
 	err = errors.Convert(json.Unmarshal(body, &installationToken))
 	if err != nil {
 		return nil, err
 	}
+	// tokens are valid for an hour according to the GitHub docs
+	if installationToken.ExpiresAt.IsZero() {
+		installationToken.ExpiresAt = time.Now().Add(time.Hour)
+	}
 
 	return &installationToken, nil
 }
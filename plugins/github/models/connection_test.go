@@ -18,8 +18,20 @@ limitations under the License.
 package models
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
+	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 )
@@ -59,3 +71,94 @@ func TestAppKeyFail(t *testing.T) {
 		println()
 	}
 }
+
+func TestGetInstallationIds(t *testing.T) {
+	appKey := &GithubAppKey{InstallationID: 1, InstallationIDs: "2, 3,,1"}
+	installationIds, err := appKey.GetInstallationIds()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, installationIds)
+
+	appKey.InstallationIDs = "2,x"
+	_, err = appKey.GetInstallationIds()
+	assert.NotNil(t, err)
+}
+
+// fakeAppApiClient issues installation tokens expiring after expiresIn
+type fakeAppApiClient struct {
+	expiresIn time.Duration
+	issued    []string
+}
+
+func (c *fakeAppApiClient) SetData(name string, data interface{}) {}
+
+func (c *fakeAppApiClient) GetData(name string) interface{} { return nil }
+
+func (c *fakeAppApiClient) SetHeaders(headers map[string]string) {}
+
+func (c *fakeAppApiClient) Get(path string, query url.Values, headers http.Header) (*http.Response, errors.Error) {
+	return nil, errors.Default.New("unexpected get")
+}
+
+func (c *fakeAppApiClient) Post(path string, query url.Values, body interface{}, headers http.Header) (*http.Response, errors.Error) {
+	token := fmt.Sprintf("%s#%d", path, len(c.issued))
+	c.issued = append(c.issued, token)
+	resBody, _ := json.Marshal(&InstallationToken{Token: token, ExpiresAt: time.Now().Add(c.expiresIn)})
+	return &http.Response{StatusCode: http.StatusCreated, Body: io.NopCloser(bytes.NewReader(resBody))}, nil
+}
+
+func newAppKeyConnection(t *testing.T) *GithubConn {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	conn := &GithubConn{}
+	conn.AuthMethod = "AppKey"
+	conn.AppId = "1"
+	conn.SecretKey = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}))
+	conn.InstallationID = 1
+	conn.InstallationIDs = "2"
+	return conn
+}
+
+func TestAppKeyInstallationTokensRotate(t *testing.T) {
+	conn := newAppKeyConnection(t)
+	apiClient := &fakeAppApiClient{expiresIn: time.Hour}
+	assert.Nil(t, conn.PrepareApiClient(apiClient))
+	assert.Equal(t, 2, conn.GetTokensCount())
+
+	var authorizations []string
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos", nil)
+		assert.Nil(t, conn.SetupAuthentication(req))
+		authorizations = append(authorizations, req.Header.Get("Authorization"))
+	}
+	assert.Equal(t, []string{
+		"Bearer /app/installations/1/access_tokens#0",
+		"Bearer /app/installations/2/access_tokens#1",
+		"Bearer /app/installations/1/access_tokens#0",
+	}, authorizations)
+	assert.Len(t, apiClient.issued, 2)
+
+	// requests authenticated by the JWT are left as they are
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/app", nil)
+	req.Header.Set("Authorization", "Bearer jwt")
+	assert.Nil(t, conn.SetupAuthentication(req))
+	assert.Equal(t, "Bearer jwt", req.Header.Get("Authorization"))
+}
+
+func TestAppKeyInstallationTokensRefresh(t *testing.T) {
+	conn := newAppKeyConnection(t)
+	conn.InstallationIDs = ""
+	apiClient := &fakeAppApiClient{expiresIn: time.Minute}
+	assert.Nil(t, conn.PrepareApiClient(apiClient))
+
+	// the token expires in a minute, it gets refreshed before being used
+	apiClient.expiresIn = time.Hour
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos", nil)
+	assert.Nil(t, conn.SetupAuthentication(req))
+	assert.Equal(t, "Bearer /app/installations/1/access_tokens#1", req.Header.Get("Authorization"))
+
+	token, err := conn.GetTokenSource().Token()
+	assert.Nil(t, err)
+	assert.Equal(t, "/app/installations/1/access_tokens#1", token.AccessToken)
+	assert.True(t, token.Expiry.After(time.Now().Add(50*time.Minute)))
+	assert.Len(t, apiClient.issued, 2)
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type GithubDeployment struct {
+	common.NoPKModel
+	ConnectionId          uint64     `gorm:"primaryKey"`
+	RepoId                int        `gorm:"primaryKey"`
+	ID                    int64      `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	NodeID                string     `json:"node_id" gorm:"type:varchar(255)"`
+	Sha                   string     `json:"sha" gorm:"type:varchar(255)"`
+	Ref                   string     `json:"ref" gorm:"type:varchar(255)"`
+	Task                  string     `json:"task" gorm:"type:varchar(255)"`
+	Environment           string     `json:"environment" gorm:"type:varchar(255)"`
+	Description           string     `json:"description"`
+	ProductionEnvironment bool       `json:"production_environment"`
+	TransientEnvironment  bool       `json:"transient_environment"`
+	GithubCreatedAt       *time.Time `json:"created_at"`
+	GithubUpdatedAt       *time.Time `json:"updated_at"`
+}
+
+func (GithubDeployment) TableName() string {
+	return "_tool_github_deployments"
+}
+
+type GithubDeploymentStatus struct {
+	common.NoPKModel
+	ConnectionId    uint64     `gorm:"primaryKey"`
+	RepoId          int        `gorm:"primaryKey"`
+	ID              int64      `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	DeploymentId    int64      `gorm:"index"`
+	State           string     `json:"state" gorm:"type:varchar(100)"`
+	Description     string     `json:"description"`
+	Environment     string     `json:"environment" gorm:"type:varchar(255)"`
+	LogUrl          string     `json:"log_url"`
+	EnvironmentUrl  string     `json:"environment_url"`
+	GithubCreatedAt *time.Time `json:"created_at"`
+	GithubUpdatedAt *time.Time `json:"updated_at"`
+}
+
+func (GithubDeploymentStatus) TableName() string {
+	return "_tool_github_deployment_statuses"
+}
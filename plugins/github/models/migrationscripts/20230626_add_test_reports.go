@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type githubScopeConfig20230626 struct {
+	TestReportPattern string `gorm:"type:varchar(255)"`
+}
+
+func (githubScopeConfig20230626) TableName() string {
+	return "_tool_github_scope_configs"
+}
+
+type githubRunArtifact20230626 struct {
+	archived.NoPKModel
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	RepoId          int    `gorm:"primaryKey"`
+	ID              int64  `gorm:"primaryKey;autoIncrement:false"`
+	RunID           int64  `gorm:"index"`
+	Name            string `gorm:"type:varchar(255)"`
+	SizeInBytes     int64
+	Expired         bool
+	GithubCreatedAt *time.Time
+	GithubExpiresAt *time.Time
+}
+
+func (githubRunArtifact20230626) TableName() string {
+	return "_tool_github_run_artifacts"
+}
+
+type githubTestSuite20230626 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       int    `gorm:"primaryKey"`
+	ArtifactId   int64  `gorm:"primaryKey;autoIncrement:false"`
+	SuiteIndex   int    `gorm:"primaryKey;autoIncrement:false"`
+	RunID        int64  `gorm:"index"`
+	Name         string `gorm:"type:varchar(255)"`
+	Duration     float64
+	TotalCount   int
+	PassedCount  int
+	FailedCount  int
+	ErrorCount   int
+	SkippedCount int
+}
+
+func (githubTestSuite20230626) TableName() string {
+	return "_tool_github_test_suites"
+}
+
+type githubTestCase20230626 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       int    `gorm:"primaryKey"`
+	ArtifactId   int64  `gorm:"primaryKey;autoIncrement:false"`
+	SuiteIndex   int    `gorm:"primaryKey;autoIncrement:false"`
+	CaseIndex    int    `gorm:"primaryKey;autoIncrement:false"`
+	RunID        int64  `gorm:"index"`
+	ClassName    string `gorm:"type:varchar(255)"`
+	Name         string
+	Result       string `gorm:"type:varchar(100)"`
+	Duration     float64
+	Message      string
+}
+
+func (githubTestCase20230626) TableName() string {
+	return "_tool_github_test_cases"
+}
+
+type addTestReports struct{}
+
+func (*addTestReports) Up(res context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		res,
+		&githubScopeConfig20230626{},
+		&githubRunArtifact20230626{},
+		&githubTestSuite20230626{},
+		&githubTestCase20230626{},
+	)
+}
+
+func (*addTestReports) Version() uint64 {
+	return 20230626000001
+}
+
+func (*addTestReports) Name() string {
+	return "add test_report_pattern to scope configs and tables for test reports uploaded as run artifacts"
+}
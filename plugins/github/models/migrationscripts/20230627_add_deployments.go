@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type githubDeployment20230627 struct {
+	archived.NoPKModel
+	ConnectionId          uint64 `gorm:"primaryKey"`
+	RepoId                int    `gorm:"primaryKey"`
+	ID                    int64  `gorm:"primaryKey;autoIncrement:false"`
+	NodeID                string `gorm:"type:varchar(255)"`
+	Sha                   string `gorm:"type:varchar(255)"`
+	Ref                   string `gorm:"type:varchar(255)"`
+	Task                  string `gorm:"type:varchar(255)"`
+	Environment           string `gorm:"type:varchar(255)"`
+	Description           string
+	ProductionEnvironment bool
+	TransientEnvironment  bool
+	GithubCreatedAt       *time.Time
+	GithubUpdatedAt       *time.Time
+}
+
+func (githubDeployment20230627) TableName() string {
+	return "_tool_github_deployments"
+}
+
+type githubDeploymentStatus20230627 struct {
+	archived.NoPKModel
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	RepoId          int    `gorm:"primaryKey"`
+	ID              int64  `gorm:"primaryKey;autoIncrement:false"`
+	DeploymentId    int64  `gorm:"index"`
+	State           string `gorm:"type:varchar(100)"`
+	Description     string
+	Environment     string `gorm:"type:varchar(255)"`
+	LogUrl          string
+	EnvironmentUrl  string
+	GithubCreatedAt *time.Time
+	GithubUpdatedAt *time.Time
+}
+
+func (githubDeploymentStatus20230627) TableName() string {
+	return "_tool_github_deployment_statuses"
+}
+
+type addDeployments struct{}
+
+func (*addDeployments) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&githubDeployment20230627{},
+		&githubDeploymentStatus20230627{},
+	)
+}
+
+func (*addDeployments) Version() uint64 {
+	return 20230627000001
+}
+
+func (*addDeployments) Name() string {
+	return "add deployments and deployment statuses"
+}
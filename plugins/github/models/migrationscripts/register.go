@@ -42,5 +42,9 @@ func All() []plugin.MigrationScript {
 		new(renameTr2ScopeConfig),
 		new(addGithubIssueAssignee),
 		new(addFullName),
+		new(addInstallationIds),
+		new(addTestReports),
+		new(addDeployments),
+		new(addHostKeysToConnection),
 	}
 }
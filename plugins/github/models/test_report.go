@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GithubTestSuite is a suite of a JUnit report uploaded as an artifact of a workflow run
+type GithubTestSuite struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       int    `gorm:"primaryKey"`
+	ArtifactId   int64  `gorm:"primaryKey;autoIncrement:false"`
+	SuiteIndex   int    `gorm:"primaryKey;autoIncrement:false"`
+	RunID        int64  `gorm:"index"`
+	Name         string `gorm:"type:varchar(255)"`
+	Duration     float64
+	TotalCount   int
+	PassedCount  int
+	FailedCount  int
+	ErrorCount   int
+	SkippedCount int
+}
+
+func (GithubTestSuite) TableName() string {
+	return "_tool_github_test_suites"
+}
+
+type GithubTestCase struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       int    `gorm:"primaryKey"`
+	ArtifactId   int64  `gorm:"primaryKey;autoIncrement:false"`
+	SuiteIndex   int    `gorm:"primaryKey;autoIncrement:false"`
+	CaseIndex    int    `gorm:"primaryKey;autoIncrement:false"`
+	RunID        int64  `gorm:"index"`
+	ClassName    string `gorm:"type:varchar(255)"`
+	Name         string
+	Result       string `gorm:"type:varchar(100)"`
+	Duration     float64
+	Message      string
+}
+
+func (GithubTestCase) TableName() string {
+	return "_tool_github_test_cases"
+}
@@ -92,4 +92,5 @@ var CollectAccountOrgMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect accounts org data from Github api, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+	Retry:            plugin.CollectorRetry,
 }
@@ -29,9 +29,16 @@ import (
 )
 
 func CreateApiClient(taskCtx plugin.TaskContext, connection *models.GithubConnection) (*api.ApiAsyncClient, errors.Error) {
-	apiClient, err := api.NewApiClientFromConnection(taskCtx.GetContext(), taskCtx, connection)
+	asyncApiClient, _, err := CreateApiClientWithResolvedConnection(taskCtx, connection)
+	return asyncApiClient, err
+}
+
+// CreateApiClientWithResolvedConnection creates the api client along with the copy of the connection it authenticates with,
+// the copy holds the resolved secrets and the installation tokens, so it must never be saved or put into plans
+func CreateApiClientWithResolvedConnection(taskCtx plugin.TaskContext, original *models.GithubConnection) (*api.ApiAsyncClient, *models.GithubConnection, errors.Error) {
+	apiClient, connection, err := api.NewApiClientWithResolvedConnection(taskCtx.GetContext(), taskCtx, original)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// create rate limit calculator
@@ -79,9 +86,9 @@ func CreateApiClient(taskCtx plugin.TaskContext, connection *models.GithubConnec
 		rateLimiter,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return asyncApiClient, nil
+	return asyncApiClient, connection, nil
 }
 
 func ignoreHTTPStatus422(res *http.Response) errors.Error {
@@ -39,6 +39,7 @@ var CollectJobsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect Jobs data from Github action api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectJobs(taskCtx plugin.SubTaskContext) errors.Error {
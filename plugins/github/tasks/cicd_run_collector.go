@@ -56,6 +56,7 @@ var CollectRunsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect Runs data from Github action api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectRuns(taskCtx plugin.SubTaskContext) errors.Error {
@@ -94,4 +94,5 @@ var CollectApiCommentsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect comments data from Github api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW, plugin.DOMAIN_TYPE_TICKET},
+	Retry:            plugin.CollectorRetry,
 }
@@ -36,6 +36,7 @@ var CollectApiCommitsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: false,
 	Description:      "Collect commits data from Github api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiCommits(taskCtx plugin.SubTaskContext) errors.Error {
@@ -40,6 +40,7 @@ var CollectApiCommitStatsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: false,
 	Description:      "Collect commitStats data from Github api, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiCommitStats(taskCtx plugin.SubTaskContext) errors.Error {
@@ -0,0 +1,214 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "convertDeployments",
+	EntryPoint:       ConvertDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_deployments into domain layer table cicd_pipelines, cicd_tasks and cicd_pipeline_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	repo := &models.GithubRepo{}
+	err := db.First(repo, dal.Where("connection_id = ? AND github_id = ?", data.Options.ConnectionId, data.Options.GithubId))
+	if err != nil {
+		return err
+	}
+
+	// deployments made by workflows the deploymentPattern already picks out are converted from their runs and jobs,
+	// converting them once more would count every release twice in the dora metrics
+	deployedShas := make(map[string]bool)
+	for _, table := range []dal.Tabler{&models.GithubRun{}, &models.GithubJob{}} {
+		var shas []string
+		err = db.Pluck("head_sha", &shas,
+			dal.From(table),
+			dal.Where("connection_id = ? AND repo_id = ? AND type = ?", data.Options.ConnectionId, data.Options.GithubId, devops.DEPLOYMENT),
+		)
+		if err != nil {
+			return err
+		}
+		for _, sha := range shas {
+			deployedShas[sha] = true
+		}
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubDeployment{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	productionPattern := ""
+	if data.Options.ScopeConfig != nil {
+		productionPattern = data.Options.ScopeConfig.ProductionPattern
+	}
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.GithubDeployment{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_DEPLOYMENT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubDeployment{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deployment := inputRow.(*models.GithubDeployment)
+			// cicd pipelines and tasks need a creation date, deployments without one can't be placed in time
+			if deployment.GithubCreatedAt == nil {
+				return nil, nil
+			}
+			if deployedShas[deployment.Sha] {
+				return nil, nil
+			}
+			var statuses []models.GithubDeploymentStatus
+			err := db.All(&statuses,
+				dal.Where("connection_id = ? AND repo_id = ? AND deployment_id = ?",
+					deployment.ConnectionId, deployment.RepoId, deployment.ID),
+				dal.Orderby("github_created_at DESC, id DESC"),
+			)
+			if err != nil {
+				return nil, err
+			}
+			result, status, finishedDate := getDeploymentResult(statuses)
+			environment := getDeploymentEnvironment(data.RegexEnricher, productionPattern, deployment)
+			deploymentId := deploymentIdGen.Generate(data.Options.ConnectionId, deployment.RepoId, deployment.ID)
+			cicdScopeId := repoIdGen.Generate(data.Options.ConnectionId, deployment.RepoId)
+			var durationSec uint64
+			if finishedDate != nil {
+				durationSec = uint64(finishedDate.Sub(*deployment.GithubCreatedAt).Seconds())
+			}
+
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         deployment.Environment,
+				Result:       result,
+				Status:       status,
+				Type:         devops.DEPLOYMENT,
+				DurationSec:  durationSec,
+				Environment:  environment,
+				Branch:       deployment.Ref,
+				CreatedDate:  *deployment.GithubCreatedAt,
+				FinishedDate: finishedDate,
+				CicdScopeId:  cicdScopeId,
+			}
+			domainTask := &devops.CICDTask{
+				DomainEntity: domainlayer.DomainEntity{Id: deploymentId},
+				Name:         deployment.Environment,
+				PipelineId:   deploymentId,
+				Result:       result,
+				Status:       status,
+				Type:         devops.DEPLOYMENT,
+				DurationSec:  durationSec,
+				Environment:  environment,
+				StartedDate:  *deployment.GithubCreatedAt,
+				FinishedDate: finishedDate,
+				CicdScopeId:  cicdScopeId,
+			}
+			domainPipelineCommit := &devops.CiCDPipelineCommit{
+				PipelineId: deploymentId,
+				CommitSha:  deployment.Sha,
+				Branch:     deployment.Ref,
+				RepoId:     cicdScopeId,
+				RepoUrl:    repo.HTMLUrl,
+			}
+
+			return []interface{}{
+				domainPipeline,
+				domainTask,
+				domainPipelineCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getDeploymentResult tells the result by the latest status, statuses are sorted from the newest one.
+// `inactive` only marks a deployment superseded by a newer one to the same environment, so it is skipped
+func getDeploymentResult(statuses []models.GithubDeploymentStatus) (string, string, *time.Time) {
+	for _, status := range statuses {
+		switch status.State {
+		case "success":
+			return devops.SUCCESS, devops.DONE, status.GithubCreatedAt
+		case "failure", "error":
+			return devops.FAILURE, devops.DONE, status.GithubCreatedAt
+		case "inactive":
+			continue
+		default:
+			// queued, pending or in_progress
+			return "", devops.IN_PROGRESS, nil
+		}
+	}
+	if len(statuses) > 0 {
+		// superseded before it ever finished
+		return devops.ABORT, devops.DONE, statuses[0].GithubCreatedAt
+	}
+	return "", devops.IN_PROGRESS, nil
+}
+
+// getDeploymentEnvironment maps the github environment to the domain layer one. productionPattern decides
+// which environments are production once it is set, otherwise the flag given by the deployer and the name are used.
+// Every deployment carries the name of its environment and the production flag, /repos/{repo}/environments only
+// adds protection rules and reviewers which the domain layer has no place for, so it is not collected
+func getDeploymentEnvironment(regexEnricher *api.RegexEnricher, productionPattern string, deployment *models.GithubDeployment) string {
+	name := strings.ToLower(deployment.Environment)
+	if productionPattern != "" {
+		if regexEnricher.ReturnNameIfMatched(devops.PRODUCTION, deployment.Environment) != "" {
+			return devops.PRODUCTION
+		}
+	} else if deployment.ProductionEnvironment || strings.HasPrefix(name, "prod") {
+		return devops.PRODUCTION
+	}
+	switch {
+	case strings.HasPrefix(name, "stag"):
+		return devops.STAGING
+	case strings.HasPrefix(name, "test"), strings.HasPrefix(name, "qa"):
+		return devops.TESTING
+	}
+	return ""
+}
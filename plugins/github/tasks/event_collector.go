@@ -46,6 +46,7 @@ var CollectApiEventsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect Events data from Github api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiEvents(taskCtx plugin.SubTaskContext) errors.Error {
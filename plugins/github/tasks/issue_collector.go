@@ -36,6 +36,7 @@ var CollectApiIssuesMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect issues data from Github api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
@@ -36,6 +36,7 @@ var CollectMilestonesMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect milestone data from Github api, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiMilestones(taskCtx plugin.SubTaskContext) errors.Error {
@@ -95,4 +95,5 @@ var CollectApiPrReviewCommentsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect pr review comments data from Github api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+	Retry:            plugin.CollectorRetry,
 }
@@ -0,0 +1,203 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+const RAW_TEST_REPORT_TABLE = "github_api_test_reports"
+
+// artifacts and the reports inside are loaded into memory, so oversized ones are skipped instead of bringing the server down
+const (
+	maxTestReportArtifactSize  = 100 << 20 // 100 MB, the size of the zip archive
+	maxTestReportFileSize      = 50 << 20  // 50 MB, the size of a single decompressed report
+	maxTestReportExtractedSize = 200 << 20 // 200 MB, the size of all the decompressed reports of an artifact
+)
+
+var CollectTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "collectTestReports",
+	EntryPoint:       CollectTestReports,
+	EnabledByDefault: true,
+	Description:      "Download run artifacts matching testReportPattern and parse the JUnit XML reports inside, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Retry:            plugin.CollectorRetry,
+}
+
+type SimpleGithubArtifact struct {
+	ID    int64
+	RunID int64
+}
+
+func CollectTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*GithubTaskData)
+	if data.Options.ScopeConfig == nil || data.Options.ScopeConfig.TestReportPattern == "" {
+		logger.Info("testReportPattern is not set, skip collecting test reports")
+		return nil
+	}
+	testReportRegex, err := errors.Convert01(regexp.Compile(data.Options.ScopeConfig.TestReportPattern))
+	if err != nil {
+		return errors.BadInput.Wrap(err, "invalid value for `testReportPattern`")
+	}
+
+	rawDataSubTaskArgs := api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: GithubApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Name:         data.Options.Name,
+		},
+		Table: RAW_TEST_REPORT_TABLE,
+	}
+	collectorWithState, err := api.NewStatefulApiCollector(rawDataSubTaskArgs, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	// expired artifacts can not be downloaded anymore
+	clauses := []dal.Clause{
+		dal.From(&models.GithubRunArtifact{}),
+		dal.Where(
+			"repo_id = ? AND connection_id = ? AND expired = ?",
+			data.Options.GithubId, data.Options.ConnectionId, false,
+		),
+	}
+	incremental := collectorWithState.IsIncremental()
+	if incremental {
+		clauses = append(
+			clauses,
+			dal.Where("github_created_at > ?", collectorWithState.LatestState.LatestSuccessStart),
+		)
+	}
+	var artifacts []models.GithubRunArtifact
+	err = db.All(&artifacts, clauses...)
+	if err != nil {
+		return err
+	}
+	iterator := api.NewQueueIterator()
+	for _, artifact := range artifacts {
+		if !testReportRegex.MatchString(artifact.Name) {
+			continue
+		}
+		if artifact.SizeInBytes > maxTestReportArtifactSize {
+			logger.Warn(nil, "skip artifact %s of run %d which is larger than %d bytes", artifact.Name, artifact.RunID, maxTestReportArtifactSize)
+			continue
+		}
+		iterator.Push(&SimpleGithubArtifact{ID: artifact.ID, RunID: artifact.RunID})
+	}
+
+	err = collectorWithState.InitCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		Incremental:        incremental,
+		UrlTemplate:        "repos/{{ .Params.Name }}/actions/artifacts/{{ .Input.ID }}/zip",
+		// the artifact is a zip archive, all the reports inside are saved as one row
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			defer res.Body.Close()
+			body, err := readLimited(res.Body, maxTestReportArtifactSize)
+			if err != nil {
+				return nil, err
+			}
+			suites, parseErr := parseJunitArchive(body, logger)
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			report, e := json.Marshal(suites)
+			if e != nil {
+				return nil, errors.Convert(e)
+			}
+			return []json.RawMessage{report}, nil
+		},
+		// artifacts expired since the listing answer 410
+		AfterResponse: func(res *http.Response) errors.Error {
+			if res.StatusCode == http.StatusGone {
+				return api.ErrIgnoreAndContinue
+			}
+			return ignoreHTTPStatus404(res)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return collectorWithState.Execute()
+}
+
+// parseJunitArchive parses every xml file of a zip archive as a JUnit report, files of other formats are skipped
+func parseJunitArchive(archive []byte, logger log.Logger) ([]*api.TestReportSuite, errors.Error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "artifact is not a zip archive")
+	}
+	suites := make([]*api.TestReportSuite, 0)
+	extracted := 0
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() || !strings.HasSuffix(strings.ToLower(file.Name), ".xml") {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, errors.Convert(err)
+		}
+		// the sizes in the headers could be forged, so the decompressed content gets limited as well
+		content, readErr := readLimited(reader, maxTestReportFileSize)
+		reader.Close()
+		if readErr != nil {
+			logger.Warn(readErr, "skip %s", file.Name)
+			continue
+		}
+		extracted += len(content)
+		if extracted > maxTestReportExtractedSize {
+			return nil, errors.BadInput.New(fmt.Sprintf("reports in the artifact are larger than %d bytes", maxTestReportExtractedSize))
+		}
+		fileSuites, parseErr := api.ParseJunitReport(content)
+		if parseErr != nil {
+			logger.Warn(parseErr, "skip %s which is not a JUnit report", file.Name)
+			continue
+		}
+		suites = append(suites, fileSuites...)
+	}
+	return suites, nil
+}
+
+// readLimited reads all of reader unless it is larger than limit
+func readLimited(reader io.Reader, limit int64) ([]byte, errors.Error) {
+	content, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	if int64(len(content)) > limit {
+		return nil, errors.BadInput.New(fmt.Sprintf("content is larger than %d bytes", limit))
+	}
+	return content, nil
+}
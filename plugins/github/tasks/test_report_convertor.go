@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ConvertTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "convertTestReports",
+	EntryPoint:       ConvertTestReports,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_test_suites and github_test_cases into domain layer table test_suites, test_cases and test_case_runs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type githubTestSuiteWithRun struct {
+	models.GithubTestSuite
+	GithubUpdatedAt *time.Time
+}
+
+func ConvertTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+	repoId := data.Options.GithubId
+
+	cursor, err := db.Cursor(
+		dal.Select("ts.*, r.github_updated_at"),
+		dal.From("_tool_github_test_suites ts"),
+		dal.Join(`JOIN _tool_github_runs r ON r.connection_id = ts.connection_id AND r.repo_id = ts.repo_id AND r.id = ts.run_id`),
+		dal.Where("ts.repo_id = ? AND ts.connection_id = ?", repoId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GithubRepo{})
+	runIdGen := didgen.NewDomainIdGenerator(&models.GithubRun{})
+	suiteIdGen := didgen.NewDomainIdGenerator(&models.GithubTestSuite{})
+	caseIdGen := didgen.NewDomainIdGenerator(&models.GithubTestCase{})
+	cicdScopeId := repoIdGen.Generate(data.Options.ConnectionId, repoId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(githubTestSuiteWithRun{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			githubSuite := inputRow.(*githubTestSuiteWithRun)
+			// artifacts belong to the run rather than to one of its jobs, so cicd_task_id is left empty
+			pipelineId := runIdGen.Generate(githubSuite.ConnectionId, githubSuite.RepoId, githubSuite.RunID)
+			suite := &devops.TestSuite{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: suiteIdGen.Generate(githubSuite.ConnectionId, githubSuite.RepoId, githubSuite.ArtifactId, githubSuite.SuiteIndex),
+				},
+				Name:           githubSuite.Name,
+				CicdScopeId:    cicdScopeId,
+				CicdPipelineId: pipelineId,
+				DurationSec:    githubSuite.Duration,
+				TotalCount:     githubSuite.TotalCount,
+				PassedCount:    githubSuite.PassedCount,
+				FailedCount:    githubSuite.FailedCount,
+				ErrorCount:     githubSuite.ErrorCount,
+				SkippedCount:   githubSuite.SkippedCount,
+				FinishedDate:   githubSuite.GithubUpdatedAt,
+			}
+			results := []interface{}{suite}
+
+			var githubCases []models.GithubTestCase
+			err := db.All(&githubCases, dal.Where("connection_id = ? AND repo_id = ? AND artifact_id = ? AND suite_index = ?",
+				githubSuite.ConnectionId, githubSuite.RepoId, githubSuite.ArtifactId, githubSuite.SuiteIndex))
+			if err != nil {
+				return nil, err
+			}
+			for _, githubCase := range githubCases {
+				testCase := &devops.TestCase{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: api.GenerateTestCaseId(cicdScopeId, githubCase.ClassName, githubCase.Name),
+					},
+					CicdScopeId: cicdScopeId,
+					ClassName:   githubCase.ClassName,
+					Name:        githubCase.Name,
+				}
+				run := &devops.TestCaseRun{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: caseIdGen.Generate(githubCase.ConnectionId, githubCase.RepoId, githubCase.ArtifactId, githubCase.SuiteIndex, githubCase.CaseIndex),
+					},
+					TestCaseId:     testCase.Id,
+					TestSuiteId:    suite.Id,
+					CicdScopeId:    cicdScopeId,
+					CicdPipelineId: pipelineId,
+					Result:         githubCase.Result,
+					DurationSec:    githubCase.Duration,
+					Message:        githubCase.Message,
+					FinishedDate:   githubSuite.GithubUpdatedAt,
+				}
+				results = append(results, testCase, run)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
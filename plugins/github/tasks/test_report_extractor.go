@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+var ExtractTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "extractTestReports",
+	EntryPoint:       ExtractTestReports,
+	EnabledByDefault: true,
+	Description:      "Extract raw test reports data into tool layer table github_test_suites and github_test_cases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ExtractTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*GithubTaskData)
+	repoId := data.Options.GithubId
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			var suites []*api.TestReportSuite
+			err := errors.Convert(json.Unmarshal(row.Data, &suites))
+			if err != nil {
+				return nil, err
+			}
+			artifact := &SimpleGithubArtifact{}
+			err = errors.Convert(json.Unmarshal(row.Input, artifact))
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]interface{}, 0)
+			for suiteIndex, suite := range suites {
+				results = append(results, &models.GithubTestSuite{
+					ConnectionId: data.Options.ConnectionId,
+					RepoId:       repoId,
+					ArtifactId:   artifact.ID,
+					SuiteIndex:   suiteIndex,
+					RunID:        artifact.RunID,
+					Name:         suite.Name,
+					Duration:     suite.Duration,
+					TotalCount:   len(suite.Cases),
+					PassedCount:  suite.CountResult(devops.TEST_PASSED),
+					FailedCount:  suite.CountResult(devops.TEST_FAILED),
+					ErrorCount:   suite.CountResult(devops.TEST_ERROR),
+					SkippedCount: suite.CountResult(devops.TEST_SKIPPED),
+				})
+				for caseIndex, c := range suite.Cases {
+					results = append(results, &models.GithubTestCase{
+						ConnectionId: data.Options.ConnectionId,
+						RepoId:       repoId,
+						ArtifactId:   artifact.ID,
+						SuiteIndex:   suiteIndex,
+						CaseIndex:    caseIndex,
+						RunID:        artifact.RunID,
+						ClassName:    c.ClassName,
+						Name:         c.Name,
+						Result:       c.Result,
+						Duration:     c.Duration,
+						Message:      c.Message,
+					})
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
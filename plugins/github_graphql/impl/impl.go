@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
-	"strings"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
@@ -92,6 +91,14 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		githubTasks.CollectRunsMeta,
 		githubTasks.ExtractRunsMeta,
 		tasks.CollectGraphqlJobsMeta,
+		githubTasks.CollectRunArtifactsMeta,
+		githubTasks.ExtractRunArtifactsMeta,
+		githubTasks.CollectTestReportsMeta,
+		githubTasks.ExtractTestReportsMeta,
+		githubTasks.CollectDeploymentsMeta,
+		githubTasks.ExtractDeploymentsMeta,
+		githubTasks.CollectDeploymentStatusesMeta,
+		githubTasks.ExtractDeploymentStatusesMeta,
 
 		// collect others
 		githubTasks.CollectApiCommentsMeta,
@@ -107,6 +114,8 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		// convert to domain layer
 		githubTasks.ConvertRunsMeta,
 		githubTasks.ConvertJobsMeta,
+		githubTasks.ConvertTestReportsMeta,
+		githubTasks.ConvertDeploymentsMeta,
 		githubTasks.EnrichPullRequestIssuesMeta,
 		githubTasks.ConvertRepoMeta,
 		githubTasks.ConvertIssuesMeta,
@@ -118,6 +127,7 @@ func (p GithubGraphql) SubTaskMetas() []plugin.SubTaskMeta {
 		githubTasks.ConvertPullRequestLabelsMeta,
 		githubTasks.ConvertPullRequestIssuesMeta,
 		githubTasks.ConvertIssueAssigneeMeta,
+		githubTasks.GenerateIssueSnapshotsMeta,
 		githubTasks.ConvertIssueCommentsMeta,
 		githubTasks.ConvertPullRequestCommentsMeta,
 		githubTasks.ConvertMilestonesMeta,
@@ -152,7 +162,7 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 		return nil, errors.Default.Wrap(err, "unable to get github connection by the given connection ID: %v")
 	}
 
-	apiClient, err := githubTasks.CreateApiClient(taskCtx, connection)
+	apiClient, resolvedConnection, err := githubTasks.CreateApiClientWithResolvedConnection(taskCtx, connection)
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "unable to get github API client instance")
 	}
@@ -162,11 +172,12 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 		return nil, err
 	}
 
-	tokens := strings.Split(connection.Token, ",")
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: tokens[0]},
-	)
-	httpClient := oauth2.NewClient(taskCtx.GetContext(), src)
+	// installation tokens of GitHub Apps are refreshed by the token source before they expire
+	httpClient := oauth2.NewClient(taskCtx.GetContext(), resolvedConnection.GetTokenSource())
+	httpClient.Transport, err = helper.WrapTransportWithCassette(taskCtx, httpClient.Transport)
+	if err != nil {
+		return nil, err
+	}
 	endpoint, err := errors.Convert01(url.JoinPath(connection.Endpoint, `graphql`))
 	if err != nil {
 		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("malformed connection endpoint supplied: %s", connection.Endpoint))
@@ -161,12 +161,12 @@ func makePipelinePlanV200(
 			cloneUrl.User = url.UserPassword("git", connection.Token)
 			stage = append(stage, &plugin.PipelineTask{
 				Plugin: "gitextractor",
-				Options: map[string]interface{}{
+				Options: connection.GitHostKeys.AddToGitExtractorOptions(map[string]interface{}{
 					"url":    cloneUrl.String(),
 					"name":   gitlabProject.Name,
 					"repoId": didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(connection.ID, gitlabProject.GitlabId),
 					"proxy":  connection.Proxy,
-				},
+				}),
 			})
 		}
 
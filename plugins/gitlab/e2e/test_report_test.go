@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabTestReportDataFlow(t *testing.T) {
+
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId: 1,
+			ProjectId:    44,
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_test_reports.csv", "_raw_gitlab_api_test_reports")
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_gitlab_pipelines.csv", &models.GitlabPipeline{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_gitlab_jobs.csv", &models.GitlabJob{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GitlabTestSuite{})
+	dataflowTester.FlushTabler(&models.GitlabTestCase{})
+	dataflowTester.Subtask(tasks.ExtractApiTestReportsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.GitlabTestSuite{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_gitlab_test_suites.csv",
+		TargetFields: []string{
+			"connection_id", "pipeline_id", "suite_index", "project_id", "name", "total_time",
+			"total_count", "success_count", "failed_count", "skipped_count", "error_count",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(models.GitlabTestCase{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_gitlab_test_cases.csv",
+		TargetFields: []string{
+			"connection_id", "pipeline_id", "suite_index", "case_index", "project_id",
+			"classname", "name", "status", "execution_time", "stack_trace",
+		},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.TestSuite{})
+	dataflowTester.FlushTabler(&devops.TestCase{})
+	dataflowTester.FlushTabler(&devops.TestCaseRun{})
+	dataflowTester.Subtask(tasks.ConvertTestReportsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(devops.TestSuite{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/test_suites.csv",
+		TargetFields: []string{
+			"id", "name", "cicd_scope_id", "cicd_pipeline_id", "cicd_task_id", "duration_sec", "total_count",
+			"passed_count", "failed_count", "error_count", "skipped_count", "finished_date",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(devops.TestCase{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/test_cases.csv",
+		TargetFields: []string{"id", "cicd_scope_id", "class_name", "name"},
+	})
+	dataflowTester.VerifyTableWithOptions(devops.TestCaseRun{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/test_case_runs.csv",
+		TargetFields: []string{
+			"id", "test_case_id", "test_suite_id", "cicd_scope_id", "cicd_pipeline_id", "cicd_task_id",
+			"result", "duration_sec", "message", "finished_date",
+		},
+	})
+}
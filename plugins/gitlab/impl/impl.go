@@ -97,6 +97,8 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 		&models.GitlabTag{},
 		&models.GitlabIssueAssignee{},
 		&models.GitlabScopeConfig{},
+		&models.GitlabTestSuite{},
+		&models.GitlabTestCase{},
 	}
 }
 
@@ -115,6 +115,7 @@ var _ plugin.ApiConnection = (*GitlabConnection)(nil)
 type GitlabConnection struct {
 	api.BaseConnection `mapstructure:",squash"`
 	GitlabConn         `mapstructure:",squash"`
+	api.GitHostKeys    `mapstructure:",squash"`
 }
 
 // This object conforms to what the frontend currently expects.
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addTestReports struct{}
+
+type gitlabTestSuite20230626 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	PipelineId   int    `gorm:"primaryKey;autoIncrement:false"`
+	SuiteIndex   int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId    int    `gorm:"index"`
+	Name         string `gorm:"type:varchar(255)"`
+	TotalTime    float64
+	TotalCount   int
+	SuccessCount int
+	FailedCount  int
+	SkippedCount int
+	ErrorCount   int
+
+	archived.NoPKModel
+}
+
+func (gitlabTestSuite20230626) TableName() string {
+	return "_tool_gitlab_test_suites"
+}
+
+type gitlabTestCase20230626 struct {
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	PipelineId    int    `gorm:"primaryKey;autoIncrement:false"`
+	SuiteIndex    int    `gorm:"primaryKey;autoIncrement:false"`
+	CaseIndex     int    `gorm:"primaryKey;autoIncrement:false"`
+	ProjectId     int    `gorm:"index"`
+	Classname     string `gorm:"type:varchar(255)"`
+	Name          string
+	Status        string `gorm:"type:varchar(100)"`
+	ExecutionTime float64
+	StackTrace    string
+
+	archived.NoPKModel
+}
+
+func (gitlabTestCase20230626) TableName() string {
+	return "_tool_gitlab_test_cases"
+}
+
+func (*addTestReports) Up(baseRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		baseRes,
+		&gitlabTestSuite20230626{},
+		&gitlabTestCase20230626{},
+	)
+}
+
+func (*addTestReports) Version() uint64 {
+	return 20230626000001
+}
+
+func (*addTestReports) Name() string {
+	return "add test suites and cases collected from pipeline test reports"
+}
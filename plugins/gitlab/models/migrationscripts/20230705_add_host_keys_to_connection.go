@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addHostKeysToConnection)(nil)
+
+type gitlabConnection20230705 struct {
+	KnownHosts          string `gorm:"type:text"`
+	HostKeyFingerprints string `gorm:"type:text"`
+}
+
+func (gitlabConnection20230705) TableName() string {
+	return "_tool_gitlab_connections"
+}
+
+type addHostKeysToConnection struct{}
+
+func (script *addHostKeysToConnection) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &gitlabConnection20230705{})
+}
+
+func (*addHostKeysToConnection) Version() uint64 {
+	return 20230705000001
+}
+
+func (*addHostKeysToConnection) Name() string {
+	return "add known_hosts and host_key_fingerprints to _tool_gitlab_connections"
+}
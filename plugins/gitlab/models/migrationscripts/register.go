@@ -38,5 +38,7 @@ func All() []plugin.MigrationScript {
 		new(renameTr2ScopeConfig),
 		new(addGitlabIssueAssignee),
 		new(addMrCommitSha),
+		new(addTestReports),
+		new(addHostKeysToConnection),
 	}
 }
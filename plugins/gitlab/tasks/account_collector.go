@@ -43,6 +43,7 @@ var CollectAccountsMeta = plugin.SubTaskMeta{
 	Description:      "collect gitlab users, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
 	Dependencies:     []*plugin.SubTaskMeta{&EnrichMergeRequestsMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectAccounts(taskCtx plugin.SubTaskContext) errors.Error {
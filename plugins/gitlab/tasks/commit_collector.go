@@ -36,6 +36,7 @@ var CollectApiCommitsMeta = plugin.SubTaskMeta{
 	Description:      "Collect commit data from gitlab api, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
 	Dependencies:     []*plugin.SubTaskMeta{&ConvertJobMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 type GitlabApiCommit struct {
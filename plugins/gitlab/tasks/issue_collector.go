@@ -42,6 +42,7 @@ var CollectApiIssuesMeta = plugin.SubTaskMeta{
 	Description:      "Collect issues data from Gitlab api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 	Dependencies:     []*plugin.SubTaskMeta{},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
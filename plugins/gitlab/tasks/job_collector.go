@@ -51,6 +51,7 @@ var CollectApiJobsMeta = plugin.SubTaskMeta{
 	Description:      "Collect job data from gitlab api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
 	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiPipelineDetailsMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiJobs(taskCtx plugin.SubTaskContext) errors.Error {
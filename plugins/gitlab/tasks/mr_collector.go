@@ -39,6 +39,7 @@ var CollectApiMergeRequestsMeta = plugin.SubTaskMeta{
 	Description:      "Collect merge requests data from gitlab api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
 	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiIssuesMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
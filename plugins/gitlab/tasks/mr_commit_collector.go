@@ -36,6 +36,7 @@ var CollectApiMrCommitsMeta = plugin.SubTaskMeta{
 	Description:      "Collect merge requests commits data from gitlab api, supports timeFilter but not diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
 	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiMrNotesMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiMergeRequestsCommits(taskCtx plugin.SubTaskContext) errors.Error {
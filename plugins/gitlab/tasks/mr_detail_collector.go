@@ -40,6 +40,7 @@ var CollectApiMergeRequestDetailsMeta = plugin.SubTaskMeta{
 	Description:      "Collect merge request Details data from gitlab api, supports timeFilter but not diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
 	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiMergeRequestsMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiMergeRequestDetails(taskCtx plugin.SubTaskContext) errors.Error {
@@ -36,6 +36,7 @@ var CollectApiMrNotesMeta = plugin.SubTaskMeta{
 	Description:      "Collect merge requests notes data from gitlab api, supports timeFilter but not diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
 	Dependencies:     []*plugin.SubTaskMeta{&CollectApiMergeRequestDetailsMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiMergeRequestsNotes(taskCtx plugin.SubTaskContext) errors.Error {
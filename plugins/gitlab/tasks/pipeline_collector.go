@@ -40,6 +40,7 @@ var CollectApiPipelinesMeta = plugin.SubTaskMeta{
 	Description:      "Collect pipeline data from gitlab api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
 	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiMrCommitsMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiPipelines(taskCtx plugin.SubTaskContext) errors.Error {
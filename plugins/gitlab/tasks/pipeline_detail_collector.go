@@ -41,6 +41,7 @@ var CollectApiPipelineDetailsMeta = plugin.SubTaskMeta{
 	Description:      "Collect pipeline details data from gitlab api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
 	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiPipelinesMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiPipelineDetails(taskCtx plugin.SubTaskContext) errors.Error {
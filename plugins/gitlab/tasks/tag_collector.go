@@ -36,6 +36,7 @@ var CollectTagMeta = plugin.SubTaskMeta{
 	Description:      "Collect tag data from gitlab api, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
 	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiMergeRequestDetailsMeta},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectApiTag(taskCtx plugin.SubTaskContext) errors.Error {
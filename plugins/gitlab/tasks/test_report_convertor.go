@@ -0,0 +1,175 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertTestReportsMeta)
+}
+
+var ConvertTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "convertTestReports",
+	EntryPoint:       ConvertTestReports,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_test_suites and gitlab_test_cases into domain layer table test_suites, test_cases and test_case_runs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertJobMeta, &ExtractApiTestReportsMeta},
+}
+
+type gitlabTestSuiteWithPipeline struct {
+	models.GitlabTestSuite
+	FinishedAt *time.Time
+}
+
+func ConvertTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TEST_REPORT_TABLE)
+	db := taskCtx.GetDal()
+
+	// gitlab names a suite after the job producing it, the latest retry of the job wins
+	var jobs []models.GitlabJob
+	err := db.All(&jobs,
+		dal.Select("gitlab_id, pipeline_id, name"),
+		dal.Where("project_id = ? AND connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+		dal.Orderby("gitlab_id"),
+	)
+	if err != nil {
+		return err
+	}
+	jobIds := make(map[int]map[string]int)
+	for _, job := range jobs {
+		if jobIds[job.PipelineId] == nil {
+			jobIds[job.PipelineId] = make(map[string]int)
+		}
+		jobIds[job.PipelineId][job.Name] = job.GitlabId
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("ts.*, p.finished_at"),
+		dal.From("_tool_gitlab_test_suites ts"),
+		dal.Join(`JOIN _tool_gitlab_pipelines p ON p.connection_id = ts.connection_id AND p.gitlab_id = ts.pipeline_id`),
+		dal.Where("ts.project_id = ? AND ts.connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	jobIdGen := didgen.NewDomainIdGenerator(&models.GitlabJob{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GitlabProject{})
+	pipelineIdGen := didgen.NewDomainIdGenerator(&models.GitlabPipeline{})
+	suiteIdGen := didgen.NewDomainIdGenerator(&models.GitlabTestSuite{})
+	caseIdGen := didgen.NewDomainIdGenerator(&models.GitlabTestCase{})
+	cicdScopeId := projectIdGen.Generate(data.Options.ConnectionId, data.Options.ProjectId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(gitlabTestSuiteWithPipeline{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			gitlabSuite := inputRow.(*gitlabTestSuiteWithPipeline)
+			pipelineId := pipelineIdGen.Generate(gitlabSuite.ConnectionId, gitlabSuite.PipelineId)
+			taskId := ""
+			if jobId, ok := jobIds[gitlabSuite.PipelineId][gitlabSuite.Name]; ok {
+				taskId = jobIdGen.Generate(gitlabSuite.ConnectionId, jobId)
+			}
+			suite := &devops.TestSuite{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: suiteIdGen.Generate(gitlabSuite.ConnectionId, gitlabSuite.PipelineId, gitlabSuite.SuiteIndex),
+				},
+				Name:           gitlabSuite.Name,
+				CicdScopeId:    cicdScopeId,
+				CicdPipelineId: pipelineId,
+				CicdTaskId:     taskId,
+				DurationSec:    gitlabSuite.TotalTime,
+				TotalCount:     gitlabSuite.TotalCount,
+				PassedCount:    gitlabSuite.SuccessCount,
+				FailedCount:    gitlabSuite.FailedCount,
+				ErrorCount:     gitlabSuite.ErrorCount,
+				SkippedCount:   gitlabSuite.SkippedCount,
+				FinishedDate:   gitlabSuite.FinishedAt,
+			}
+			results := []interface{}{suite}
+
+			var gitlabCases []models.GitlabTestCase
+			err := db.All(&gitlabCases, dal.Where("connection_id = ? AND pipeline_id = ? AND suite_index = ?",
+				gitlabSuite.ConnectionId, gitlabSuite.PipelineId, gitlabSuite.SuiteIndex))
+			if err != nil {
+				return nil, err
+			}
+			for _, gitlabCase := range gitlabCases {
+				testCase := &devops.TestCase{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: api.GenerateTestCaseId(cicdScopeId, gitlabCase.Classname, gitlabCase.Name),
+					},
+					CicdScopeId: cicdScopeId,
+					ClassName:   gitlabCase.Classname,
+					Name:        gitlabCase.Name,
+				}
+				run := &devops.TestCaseRun{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: caseIdGen.Generate(gitlabCase.ConnectionId, gitlabCase.PipelineId, gitlabCase.SuiteIndex, gitlabCase.CaseIndex),
+					},
+					TestCaseId:     testCase.Id,
+					TestSuiteId:    suite.Id,
+					CicdScopeId:    cicdScopeId,
+					CicdPipelineId: pipelineId,
+					CicdTaskId:     taskId,
+					Result:         getTestResult(gitlabCase.Status),
+					DurationSec:    gitlabCase.ExecutionTime,
+					Message:        gitlabCase.StackTrace,
+					FinishedDate:   gitlabSuite.FinishedAt,
+				}
+				results = append(results, testCase, run)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getTestResult maps the status of a gitlab test case to a devops.TEST_* result
+func getTestResult(status string) string {
+	switch status {
+	case "success":
+		return devops.TEST_PASSED
+	case "failed":
+		return devops.TEST_FAILED
+	case "error":
+		return devops.TEST_ERROR
+	case "skipped":
+		return devops.TEST_SKIPPED
+	}
+	return ""
+}
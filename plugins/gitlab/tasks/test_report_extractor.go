@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiTestReportsMeta)
+}
+
+type ApiTestReport struct {
+	TotalCount int                  `json:"total_count"`
+	TestSuites []ApiTestReportSuite `json:"test_suites"`
+}
+
+type ApiTestReportSuite struct {
+	Name         string              `json:"name"`
+	TotalTime    float64             `json:"total_time"`
+	TotalCount   int                 `json:"total_count"`
+	SuccessCount int                 `json:"success_count"`
+	FailedCount  int                 `json:"failed_count"`
+	SkippedCount int                 `json:"skipped_count"`
+	ErrorCount   int                 `json:"error_count"`
+	TestCases    []ApiTestReportCase `json:"test_cases"`
+}
+
+type ApiTestReportCase struct {
+	Status        string  `json:"status"`
+	Name          string  `json:"name"`
+	Classname     string  `json:"classname"`
+	ExecutionTime float64 `json:"execution_time"`
+	StackTrace    string  `json:"stack_trace"`
+}
+
+var ExtractApiTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiTestReports",
+	EntryPoint:       ExtractApiTestReports,
+	EnabledByDefault: true,
+	Description:      "Extract raw test reports data into tool layer table gitlab_test_suites and gitlab_test_cases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectApiTestReportsMeta},
+}
+
+func ExtractApiTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_TEST_REPORT_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			report := &ApiTestReport{}
+			err := errors.Convert(json.Unmarshal(row.Data, report))
+			if err != nil {
+				return nil, err
+			}
+			// pipelines without junit artifacts answer an empty report
+			if report.TotalCount == 0 {
+				return nil, nil
+			}
+			input := &GitlabInput{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]interface{}, 0)
+			for suiteIndex, suite := range report.TestSuites {
+				results = append(results, &models.GitlabTestSuite{
+					ConnectionId: data.Options.ConnectionId,
+					PipelineId:   input.GitlabId,
+					SuiteIndex:   suiteIndex,
+					ProjectId:    data.Options.ProjectId,
+					Name:         suite.Name,
+					TotalTime:    suite.TotalTime,
+					TotalCount:   suite.TotalCount,
+					SuccessCount: suite.SuccessCount,
+					FailedCount:  suite.FailedCount,
+					SkippedCount: suite.SkippedCount,
+					ErrorCount:   suite.ErrorCount,
+				})
+				for caseIndex, c := range suite.TestCases {
+					results = append(results, &models.GitlabTestCase{
+						ConnectionId:  data.Options.ConnectionId,
+						PipelineId:    input.GitlabId,
+						SuiteIndex:    suiteIndex,
+						CaseIndex:     caseIndex,
+						ProjectId:     data.Options.ProjectId,
+						Classname:     c.Classname,
+						Name:          c.Name,
+						Status:        c.Status,
+						ExecutionTime: c.ExecutionTime,
+						StackTrace:    c.StackTrace,
+					})
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -114,7 +114,7 @@ func GetJob(
 		job.Path = path
 		job.FullName = fullName
 
-		if job.Jobs != nil {
+		if job.Jobs != nil && !models.IsMultiBranchClass(job.Class) {
 			err = callback(job, true)
 			if err != nil {
 				return err
@@ -140,7 +140,8 @@ func GetAllJobs(
 		job.Path = path
 		job.FullName = beforename + job.Name
 
-		if job.Jobs != nil {
+		// branch jobs of a multibranch project are collected along with the project itself
+		if job.Jobs != nil && !models.IsMultiBranchClass(job.Class) {
 			err = callback(job, true)
 			if err != nil {
 				return err
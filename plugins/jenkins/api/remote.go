@@ -50,8 +50,8 @@ func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 
 			var resBody []models.Job
 			_, err = GetJobsPage(apiClient, gid, queryData.Page-1, queryData.PerPage, func(job *models.Job) errors.Error {
-				// this is a group
-				if job.Jobs != nil {
+				// this is a group, multibranch projects are listed as jobs instead
+				if job.Jobs != nil && !models.IsMultiBranchClass(job.Class) {
 					job.Path = gid
 					resBody = append(resBody, *job)
 				}
@@ -71,8 +71,8 @@ func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, er
 
 			var resBody []models.Job
 			_, err = GetJobsPage(apiClient, gid, queryData.Page-1, queryData.PerPage, func(job *models.Job) errors.Error {
-				// this is only a job
-				if job.Jobs == nil {
+				// this is only a job, or a multibranch project whose branch jobs are collected as a whole
+				if job.Jobs == nil || models.IsMultiBranchClass(job.Class) {
 					job.Path = gid
 					resBody = append(resBody, *job)
 				}
@@ -112,7 +112,7 @@ func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutp
 			count := 0
 			pageoOffset := (queryData.Page - 1) * queryData.PerPage
 			err = GetAllJobs(apiClient, "", "", queryData.PerPage, func(job *models.Job, isPath bool) errors.Error {
-				if job.Jobs == nil {
+				if !isPath {
 					if strings.Contains(job.FullName, queryData.Search[0]) {
 						if count >= pageoOffset {
 							resBody = append(resBody, *job)
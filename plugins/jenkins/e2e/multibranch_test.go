@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/impl"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/apache/incubator-devlake/plugins/jenkins/tasks"
+)
+
+func TestJenkinsMultiBranchDataFlow(t *testing.T) {
+	var jenkins impl.Jenkins
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jenkins", jenkins)
+
+	taskData := &tasks.JenkinsTaskData{
+		Options: &tasks.JenkinsOptions{
+			ConnectionId: 1,
+			JobName:      `devlake`,
+			JobFullName:  `org/devlake`,
+			JobPath:      `job/org/`,
+			JobClass:     `org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject`,
+		},
+		RegexEnricher: api.NewRegexEnricher(),
+	}
+
+	// verify branch jobs extraction
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_branch_jobs.csv", "_raw_jenkins_api_branch_jobs")
+	dataflowTester.FlushTabler(&models.JenkinsBranchJob{})
+	dataflowTester.Subtask(tasks.ExtractApiBranchJobsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.JenkinsBranchJob{},
+		"./snapshot_tables/_tool_jenkins_branch_jobs.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"full_name",
+			"parent_full_name",
+			"name",
+			"branch",
+			"class",
+			"color",
+			"is_pull_request",
+			"last_build_timestamp",
+		),
+	)
+
+	// verify builds extraction, `feature%2Fold` is a deleted branch whose builds were collected before
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_multibranch_builds.csv", "_raw_jenkins_api_builds")
+	dataflowTester.FlushTabler(&models.JenkinsBuild{})
+	dataflowTester.FlushTabler(&models.JenkinsBuildCommit{})
+	dataflowTester.Subtask(tasks.ExtractApiBuildsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.JenkinsBuild{},
+		"./snapshot_tables/_tool_jenkins_multibranch_builds.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"full_name",
+			"job_name",
+			"job_path",
+			"duration",
+			"number",
+			"result",
+			"timestamp",
+			"start_time",
+			"building",
+			"branch",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CICDTask{})
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.Subtask(tasks.ConvertBuildsToCICDMeta, taskData)
+	dataflowTester.VerifyTable(
+		devops.CICDPipeline{},
+		"./snapshot_tables/cicd_pipelines_multibranch.csv",
+		e2ehelper.ColumnWithRawData(
+			"name",
+			"result",
+			"status",
+			"type",
+			"duration_sec",
+			"environment",
+			"branch",
+			"created_date",
+			"finished_date",
+			"cicd_scope_id",
+		),
+	)
+	dataflowTester.VerifyTable(
+		devops.CICDTask{},
+		"./snapshot_tables/cicd_tasks_multibranch.csv",
+		e2ehelper.ColumnWithRawData(
+			"name",
+			"pipeline_id",
+			"result",
+			"status",
+			"duration_sec",
+			"started_date",
+			"finished_date",
+			"cicd_scope_id",
+		),
+	)
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/jenkins/impl"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/apache/incubator-devlake/plugins/jenkins/tasks"
+)
+
+func TestJenkinsTestReportsDataFlow(t *testing.T) {
+	var jenkins impl.Jenkins
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jenkins", jenkins)
+
+	taskData := &tasks.JenkinsTaskData{
+		Options: &tasks.JenkinsOptions{
+			ConnectionId: 1,
+			JobName:      `devlake`,
+			JobFullName:  `Test-jenkins-dir/test-jenkins-sub-dir/test-sub-sub-dir/devlake`,
+			JobPath:      `job/Test-jenkins-dir/job/test-jenkins-sub-dir/job/test-sub-sub-dir/`,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jenkins_api_test_reports.csv", "_raw_jenkins_api_test_reports")
+	dataflowTester.FlushTabler(&models.JenkinsBuild{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_jenkins_builds_for_stages.csv", models.JenkinsBuild{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.JenkinsTestSuite{})
+	dataflowTester.FlushTabler(&models.JenkinsTestCase{})
+	dataflowTester.Subtask(tasks.ExtractApiTestReportsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.JenkinsTestSuite{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_jenkins_test_suites.csv",
+		TargetFields: []string{
+			"connection_id", "build_name", "suite_index", "name", "duration",
+			"total_count", "passed_count", "failed_count", "skipped_count",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(models.JenkinsTestCase{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/_tool_jenkins_test_cases.csv",
+		TargetFields: []string{
+			"connection_id", "build_name", "suite_index", "case_index", "class_name",
+			"name", "status", "duration", "error_details",
+		},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.TestSuite{})
+	dataflowTester.FlushTabler(&devops.TestCase{})
+	dataflowTester.FlushTabler(&devops.TestCaseRun{})
+	dataflowTester.Subtask(tasks.ConvertTestReportsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(devops.TestSuite{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/test_suites.csv",
+		TargetFields: []string{
+			"id", "name", "cicd_scope_id", "cicd_pipeline_id", "cicd_task_id", "duration_sec", "total_count",
+			"passed_count", "failed_count", "error_count", "skipped_count", "finished_date",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(devops.TestCase{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/test_cases.csv",
+		TargetFields: []string{"id", "cicd_scope_id", "class_name", "name"},
+	})
+	dataflowTester.VerifyTableWithOptions(devops.TestCaseRun{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/test_case_runs.csv",
+		TargetFields: []string{
+			"id", "test_case_id", "test_suite_id", "cicd_scope_id", "cicd_pipeline_id", "cicd_task_id",
+			"result", "duration_sec", "message", "finished_date",
+		},
+	})
+}
@@ -69,12 +69,15 @@ func (p Jenkins) ScopeConfig() dal.Tabler {
 
 func (p Jenkins) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
+		&models.JenkinsBranchJob{},
 		&models.JenkinsBuild{},
 		&models.JenkinsBuildCommit{},
 		&models.JenkinsConnection{},
 		&models.JenkinsJob{},
 		&models.JenkinsJobDag{},
 		&models.JenkinsStage{},
+		&models.JenkinsTestCase{},
+		&models.JenkinsTestSuite{},
 		&models.JenkinsScopeConfig{},
 	}
 }
@@ -90,13 +93,18 @@ func (p Jenkins) Name() string {
 func (p Jenkins) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.ConvertJobsMeta,
+		tasks.CollectApiBranchJobsMeta,
+		tasks.ExtractApiBranchJobsMeta,
 		tasks.CollectApiBuildsMeta,
 		tasks.ExtractApiBuildsMeta,
 		tasks.CollectApiStagesMeta,
 		tasks.ExtractApiStagesMeta,
+		tasks.CollectApiTestReportsMeta,
+		tasks.ExtractApiTestReportsMeta,
 		tasks.EnrichApiBuildWithStagesMeta,
 		tasks.ConvertBuildsToCICDMeta,
 		tasks.ConvertStagesMeta,
+		tasks.ConvertTestReportsMeta,
 		tasks.ConvertBuildReposMeta,
 	}
 }
@@ -253,6 +261,7 @@ func EnrichOptions(taskCtx plugin.TaskContext,
 	err = api.GetJob(apiClient, op.JobPath, op.JobName, op.JobFullName, 100, func(job *models.Job, isPath bool) errors.Error {
 		log.Debug(fmt.Sprintf("Current job: %s", job.FullName))
 		op.JobPath = job.Path
+		op.JobClass = job.Class
 		jenkinsJob := job.ConvertApiScope().(*models.JenkinsJob)
 
 		jenkinsJob.ConnectionId = op.ConnectionId
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// JenkinsBranchJob is a branch or pull request job discovered under a multibranch project,
+// Name keeps the url encoded form used by jenkins (e.g. "feature%2Ffoo") while Branch is decoded
+type JenkinsBranchJob struct {
+	common.NoPKModel
+	ConnectionId       uint64 `gorm:"primaryKey"`
+	FullName           string `gorm:"primaryKey;type:varchar(255)"` // "path1/multibranch/feature%2Ffoo"
+	ParentFullName     string `gorm:"index;type:varchar(255)"`      // full name of the multibranch project
+	Name               string `gorm:"type:varchar(255)"`
+	Branch             string `gorm:"type:varchar(255)"`
+	Class              string `gorm:"type:varchar(255)"`
+	Url                string
+	Color              string `gorm:"type:varchar(255)"`
+	IsPullRequest      bool
+	LastBuildTimestamp int64
+}
+
+func (JenkinsBranchJob) TableName() string {
+	return "_tool_jenkins_branch_jobs"
+}
+
+// IsMultiBranchClass tells whether the jenkins `_class` belongs to a multibranch project, which has
+// a child job for every branch or pull request instead of builds of its own
+func IsMultiBranchClass(class string) bool {
+	return strings.HasSuffix(class, "WorkflowMultiBranchProject")
+}
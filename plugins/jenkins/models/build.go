@@ -41,6 +41,7 @@ type JenkinsBuild struct {
 	TriggeredBy       string    `gorm:"type:varchar(255)"`
 	Building          bool
 	HasStages         bool
+	Branch            string `gorm:"type:varchar(255)"` // branch of the job under a multibranch project
 }
 
 func (JenkinsBuild) TableName() string {
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addBranchJobs struct{}
+
+type branchJob20230626 struct {
+	archived.NoPKModel
+	ConnectionId       uint64 `gorm:"primaryKey"`
+	FullName           string `gorm:"primaryKey;type:varchar(255)"`
+	ParentFullName     string `gorm:"index;type:varchar(255)"`
+	Name               string `gorm:"type:varchar(255)"`
+	Branch             string `gorm:"type:varchar(255)"`
+	Class              string `gorm:"type:varchar(255)"`
+	Url                string
+	Color              string `gorm:"type:varchar(255)"`
+	IsPullRequest      bool
+	LastBuildTimestamp int64
+}
+
+func (branchJob20230626) TableName() string {
+	return "_tool_jenkins_branch_jobs"
+}
+
+type build20230626 struct {
+	Branch string `gorm:"type:varchar(255)"`
+}
+
+func (build20230626) TableName() string {
+	return "_tool_jenkins_builds"
+}
+
+func (*addBranchJobs) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&branchJob20230626{},
+		&build20230626{},
+	)
+}
+
+func (*addBranchJobs) Version() uint64 {
+	return 20230626000001
+}
+
+func (*addBranchJobs) Name() string {
+	return "add branch jobs of multibranch projects and branch to builds"
+}
@@ -34,5 +34,7 @@ func All() []plugin.MigrationScript {
 		new(addFullNameForBuilds),
 		new(addConnectionIdToTransformationRule),
 		new(renameTr2ScopeConfig),
+		new(addTestReports),
+		new(addBranchJobs),
 	}
 }
@@ -161,3 +161,21 @@ type Cause struct {
 	UpstreamProject  string `json:"upstreamProject"`
 	UpstreamURL      string `json:"upstreamUrl"`
 }
+
+type TestReport struct {
+	Suites []TestReportSuite `json:"suites"`
+}
+
+type TestReportSuite struct {
+	Name     string           `json:"name"`
+	Duration float64          `json:"duration"`
+	Cases    []TestReportCase `json:"cases"`
+}
+
+type TestReportCase struct {
+	ClassName    string  `json:"className"`
+	Name         string  `json:"name"`
+	Status       string  `json:"status"`
+	Duration     float64 `json:"duration"`
+	ErrorDetails string  `json:"errorDetails"`
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// JenkinsTestSuite is a suite of the test report of a build, identified by its position in the report
+type JenkinsTestSuite struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	BuildName    string `gorm:"primaryKey;type:varchar(255)"`
+	SuiteIndex   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name         string `gorm:"type:varchar(255)"`
+	Duration     float64
+	TotalCount   int
+	PassedCount  int
+	FailedCount  int
+	SkippedCount int
+}
+
+func (JenkinsTestSuite) TableName() string {
+	return "_tool_jenkins_test_suites"
+}
+
+type JenkinsTestCase struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	BuildName    string `gorm:"primaryKey;type:varchar(255)"`
+	SuiteIndex   int    `gorm:"primaryKey;autoIncrement:false"`
+	CaseIndex    int    `gorm:"primaryKey;autoIncrement:false"`
+	ClassName    string `gorm:"type:varchar(255)"`
+	Name         string
+	Status       string `gorm:"type:varchar(100)"`
+	Duration     float64
+	ErrorDetails string
+}
+
+func (JenkinsTestCase) TableName() string {
+	return "_tool_jenkins_test_cases"
+}
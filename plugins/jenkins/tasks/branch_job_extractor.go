@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ExtractApiBranchJobsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBranchJobs",
+	EntryPoint:       ExtractApiBranchJobs,
+	EnabledByDefault: true,
+	Description:      "Extract raw branch jobs data into tool layer table jenkins_branch_jobs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// branch sources name the jobs of pull requests (github, bitbucket) or merge requests (gitlab) this way
+var pullRequestJobNamePattern = regexp.MustCompile(`^(PR|MR)-\d+$`)
+
+type ApiBranchJob struct {
+	Class     string                 `json:"_class"`
+	Name      string                 `json:"name"`
+	Url       string                 `json:"url"`
+	Color     string                 `json:"color"`
+	LastBuild *SimpleJenkinsApiBuild `json:"lastBuild"`
+}
+
+func ExtractApiBranchJobs(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_BRANCH_JOB_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			body := &ApiBranchJob{}
+			err := errors.Convert(json.Unmarshal(row.Data, body))
+			if err != nil {
+				return nil, err
+			}
+			// jenkins encodes the branch name to make a valid job name, e.g. "feature/foo" becomes "feature%2Ffoo"
+			branch, decodeErr := url.PathUnescape(body.Name)
+			if decodeErr != nil {
+				branch = body.Name
+			}
+			branchJob := &models.JenkinsBranchJob{
+				ConnectionId:   data.Options.ConnectionId,
+				FullName:       data.Options.JobFullName + "/" + body.Name,
+				ParentFullName: data.Options.JobFullName,
+				Name:           body.Name,
+				Branch:         branch,
+				Class:          body.Class,
+				Url:            body.Url,
+				Color:          body.Color,
+				IsPullRequest:  pullRequestJobNamePattern.MatchString(body.Name),
+			}
+			if body.LastBuild != nil {
+				branchJob.LastBuildTimestamp = body.LastBuild.Timestamp
+			}
+			return []interface{}{branchJob}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
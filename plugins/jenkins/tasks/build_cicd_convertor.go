@@ -19,6 +19,7 @@ package tasks
 
 import (
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
@@ -44,10 +45,8 @@ func ConvertBuildsToCICD(taskCtx plugin.SubTaskContext) (err errors.Error) {
 	data := taskCtx.GetData().(*JenkinsTaskData)
 	clauses := []dal.Clause{
 		dal.From("_tool_jenkins_builds"),
-		dal.Where(`_tool_jenkins_builds.connection_id = ?
-						and _tool_jenkins_builds.job_path = ? 
-						and _tool_jenkins_builds.job_name = ?`,
-			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName),
+		dal.Where(`_tool_jenkins_builds.connection_id = ?`, data.Options.ConnectionId),
+		jobBuildsFilter("_tool_jenkins_builds", data.Options),
 	}
 	cursor, err := db.Cursor(clauses...)
 	if err != nil {
@@ -104,16 +103,22 @@ func ConvertBuildsToCICD(taskCtx plugin.SubTaskContext) (err errors.Error) {
 				CicdScopeId:  jobIdGen.Generate(jenkinsBuild.ConnectionId, data.Options.JobFullName),
 				Type:         data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, jenkinsBuild.FullName),
 				Environment:  data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, jenkinsBuild.FullName),
+				Branch:       jenkinsBuild.Branch,
 			}
 			jenkinsPipeline.RawDataOrigin = jenkinsBuild.RawDataOrigin
 			results = append(results, jenkinsPipeline)
 
 			if !jenkinsBuild.HasStages {
+				// builds of a multibranch project belong to its branch jobs
+				jobFullName := data.Options.JobFullName
+				if jenkinsBuild.Branch != "" {
+					jobFullName = jenkinsBuild.FullName[:strings.LastIndex(jenkinsBuild.FullName, "#")]
+				}
 				jenkinsTask := &devops.CICDTask{
 					DomainEntity: domainlayer.DomainEntity{
 						Id: buildIdGen.Generate(jenkinsBuild.ConnectionId, jenkinsBuild.FullName),
 					},
-					Name:         data.Options.JobFullName,
+					Name:         jobFullName,
 					Result:       jenkinsPipelineResult,
 					Status:       jenkinsPipelineStatus,
 					DurationSec:  uint64(durationSec),
@@ -41,6 +41,7 @@ var CollectApiBuildsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect builds data from jenkins api, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Retry:            plugin.CollectorRetry,
 }
 
 type SimpleJob struct {
@@ -53,8 +54,26 @@ type SimpleJenkinsApiBuild struct {
 	Timestamp int64 `json:"timestamp"`
 }
 
+// SimpleBranchJob is a branch job of a multibranch project whose builds are collected
+type SimpleBranchJob struct {
+	FullName      string
+	Name          string
+	Branch        string
+	IsPullRequest bool
+}
+
+// EscapedName returns the name used in the job url, the name is url encoded by jenkins already
+func (j SimpleBranchJob) EscapedName() string {
+	return url.PathEscape(j.Name)
+}
+
+const buildsTreeFormat = "allBuilds[timestamp,number,duration,building,estimatedDuration,fullDisplayName,result,actions[lastBuiltRevision[SHA1,branch[name]],remoteUrls,mercurialRevisionNumber,causes[*]],changeSet[kind,revisions[revision]]]{%d,%d}"
+
 func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*JenkinsTaskData)
+	if models.IsMultiBranchClass(data.Options.JobClass) {
+		return collectMultiBranchBuilds(taskCtx, data)
+	}
 	db := taskCtx.GetDal()
 	collector, err := helper.NewStatefulApiCollectorForFinalizableEntity(helper.FinalizableApiCollectorArgs{
 		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
@@ -74,9 +93,7 @@ func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				UrlTemplate: fmt.Sprintf("%sjob/%s/api/json", data.Options.JobPath, data.Options.JobName),
 				Query: func(reqData *helper.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
 					query := url.Values{}
-					treeValue := fmt.Sprintf(
-						"allBuilds[timestamp,number,duration,building,estimatedDuration,fullDisplayName,result,actions[lastBuiltRevision[SHA1,branch[name]],remoteUrls,mercurialRevisionNumber,causes[*]],changeSet[kind,revisions[revision]]]{%d,%d}",
-						reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size)
+					treeValue := fmt.Sprintf(buildsTreeFormat, reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size)
 					query.Set("tree", treeValue)
 					return query, nil
 				},
@@ -138,3 +155,98 @@ func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return collector.Execute()
 }
+
+// collectMultiBranchBuilds collects builds of every branch job under a multibranch project. Builds of jobs
+// whose branch has been deleted are kept from previous collections since the raw data is collected incrementally
+func collectMultiBranchBuilds(taskCtx plugin.SubTaskContext, data *JenkinsTaskData) errors.Error {
+	db := taskCtx.GetDal()
+	collectorWithState, err := helper.NewStatefulApiCollector(helper.RawDataSubTaskArgs{
+		Params: JenkinsApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			FullName:     data.Options.JobFullName,
+		},
+		Ctx:   taskCtx,
+		Table: RAW_BUILD_TABLE,
+	}, data.TimeAfter)
+	if err != nil {
+		return err
+	}
+
+	clauses := []dal.Clause{
+		dal.Select("full_name, name, branch, is_pull_request"),
+		dal.From(&models.JenkinsBranchJob{}),
+		dal.Where("connection_id = ? AND parent_full_name = ?", data.Options.ConnectionId, data.Options.JobFullName),
+	}
+	// builds are listed from the newest one, those started before `since` are skipped
+	since := data.TimeAfter
+	incremental := collectorWithState.IsIncremental()
+	if incremental {
+		since = collectorWithState.LatestState.LatestSuccessStart
+		// builds still running at the last collection have to be collected again
+		unfinished := &models.JenkinsBuild{}
+		err = db.First(unfinished,
+			dal.Where("connection_id = ? AND job_path = ? AND building = ?",
+				data.Options.ConnectionId, branchJobPath(data.Options), true),
+			dal.Orderby("timestamp"),
+		)
+		if err == nil && unfinished.StartTime.Before(*since) {
+			since = &unfinished.StartTime
+		} else if err != nil && !db.IsErrorNotFound(err) {
+			return err
+		}
+		clauses = append(clauses, dal.Where("last_build_timestamp >= ?", since.UnixMilli()))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimpleBranchJob{}))
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		Input:       iterator,
+		Incremental: incremental,
+		PageSize:    100,
+		UrlTemplate: branchJobPath(data.Options) + "job/{{ .Input.EscapedName }}/api/json",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("tree", fmt.Sprintf(buildsTreeFormat, reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size))
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var body struct {
+				Builds []json.RawMessage `json:"allBuilds"`
+			}
+			err := helper.UnmarshalResponse(res, &body)
+			if err != nil {
+				return nil, err
+			}
+			if since == nil {
+				return body.Builds, nil
+			}
+			// a short page stops the paging, older builds have been collected already
+			builds := make([]json.RawMessage, 0, len(body.Builds))
+			for _, item := range body.Builds {
+				b := &SimpleJenkinsApiBuild{}
+				if err := json.Unmarshal(item, b); err != nil {
+					return nil, errors.BadInput.Wrap(err, "failed to unmarshal jenkins build")
+				}
+				if b.Timestamp >= since.UnixMilli() {
+					builds = append(builds, item)
+				}
+			}
+			return builds, nil
+		},
+		// the branch may be deleted after its job was listed
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collectorWithState.Execute()
+}
@@ -56,15 +56,28 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				return nil, err
 			}
 
+			// builds of a multibranch project are collected from its branch jobs
+			branchJob := &SimpleBranchJob{}
+			if len(row.Input) > 0 {
+				err = errors.Convert(json.Unmarshal(row.Input, branchJob))
+				if err != nil {
+					return nil, err
+				}
+			}
+			jobName, jobPath, jobFullName := data.Options.JobName, data.Options.JobPath, data.Options.JobFullName
+			if branchJob.FullName != "" {
+				jobName, jobPath, jobFullName = branchJob.Name, branchJobPath(data.Options), branchJob.FullName
+			}
+
 			results := make([]interface{}, 0)
 			strList := strings.Split(body.Class, ".")
 			class := strList[len(strList)-1]
 			build := &models.JenkinsBuild{
 				ConnectionId:      data.Options.ConnectionId,
-				JobName:           data.Options.JobName,
-				JobPath:           data.Options.JobPath,
+				JobName:           jobName,
+				JobPath:           jobPath,
 				Duration:          body.Duration,
-				FullName:          fmt.Sprintf(`%s#%d`, data.Options.JobFullName, body.Number),
+				FullName:          fmt.Sprintf(`%s#%d`, jobFullName, body.Number),
 				EstimatedDuration: body.EstimatedDuration,
 				Number:            body.Number,
 				Result:            body.Result,
@@ -72,6 +85,7 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 				Class:             class,
 				Building:          body.Building,
 				StartTime:         time.Unix(body.Timestamp/1000, 0),
+				Branch:            buildBranch(branchJob, body),
 			}
 			// we also need to collect the commit info from the build which does not have changeSet
 			// changeSet describes the changes that were made in the build
@@ -124,3 +138,25 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return extractor.Execute()
 }
+
+// buildBranch returns the branch a build of a multibranch project ran on. Jobs of pull requests are named
+// after them (e.g. `PR-12`), so their branch is taken from the revision the build checked out when possible
+func buildBranch(branchJob *SimpleBranchJob, body *models.ApiBuildResponse) string {
+	if !branchJob.IsPullRequest {
+		return branchJob.Branch
+	}
+	for _, a := range body.Actions {
+		if a.LastBuiltRevision == nil {
+			continue
+		}
+		for _, b := range a.LastBuiltRevision.Branches {
+			name := strings.TrimPrefix(b.Name, "refs/heads/")
+			name = strings.TrimPrefix(name, "refs/remotes/")
+			name = strings.TrimPrefix(name, "origin/")
+			if name != "" && !pullRequestJobNamePattern.MatchString(name) {
+				return name
+			}
+		}
+	}
+	return branchJob.Branch
+}
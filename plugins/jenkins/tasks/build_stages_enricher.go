@@ -41,9 +41,8 @@ func EnrichApiBuildWithStages(taskCtx plugin.SubTaskContext) errors.Error {
 		dal.Join(`inner join _tool_jenkins_stages tjs 
 						on tjs.build_name = tjb.full_name 
 						and tjs.connection_id = tjb.connection_id`),
-		dal.Where(`tjb.connection_id = ? 
-							and tjb.job_path = ? and tjb.job_name = ?`,
-			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName),
+		dal.Where(`tjb.connection_id = ?`, data.Options.ConnectionId),
+		jobBuildsFilter("tjb", data.Options),
 	}
 	cursor, err := db.Cursor(clauses...)
 	if err != nil {
@@ -18,9 +18,12 @@ limitations under the License.
 package tasks
 
 import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
-	"net/http"
 )
 
 func ignoreHTTPStatus404(res *http.Response) errors.Error {
@@ -32,3 +35,17 @@ func ignoreHTTPStatus404(res *http.Response) errors.Error {
 	}
 	return nil
 }
+
+// branchJobPath returns the path of the branch jobs when the job in options is a multibranch project
+func branchJobPath(op *JenkinsOptions) string {
+	return fmt.Sprintf("%sjob/%s/", op.JobPath, op.JobName)
+}
+
+// jobBuildsFilter selects builds of the job in options, for a multibranch project these are the builds of
+// all its branch jobs, including the ones whose branch has been deleted since
+func jobBuildsFilter(table string, op *JenkinsOptions) dal.Clause {
+	return dal.Where(
+		fmt.Sprintf("((%[1]s.job_path = ? AND %[1]s.job_name = ?) OR %[1]s.job_path = ?)", table),
+		op.JobPath, op.JobName, branchJobPath(op),
+	)
+}
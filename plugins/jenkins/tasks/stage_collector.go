@@ -38,21 +38,30 @@ var CollectApiStagesMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "Collect stages data from jenkins api, supports timeFilter but not diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Retry:            plugin.CollectorRetry,
 }
 
 type SimpleBuild struct {
 	Number   string
 	FullName string
+	JobPath  string
+	JobName  string
+}
+
+// JobUrl returns the url path of the job the build belongs to, the name of a branch job
+// is url encoded by jenkins (e.g. "feature%2Ffoo") and has to be escaped once more
+func (b SimpleBuild) JobUrl() string {
+	return fmt.Sprintf("%sjob/%s/", b.JobPath, url.PathEscape(b.JobName))
 }
 
 func CollectApiStages(taskCtx plugin.SubTaskContext) errors.Error {
 	db := taskCtx.GetDal()
 	data := taskCtx.GetData().(*JenkinsTaskData)
 	clauses := []dal.Clause{
-		dal.Select("tjb.number,tjb.full_name"),
+		dal.Select("tjb.number,tjb.full_name,tjb.job_path,tjb.job_name"),
 		dal.From("_tool_jenkins_builds as tjb"),
-		dal.Where(`tjb.connection_id = ? and tjb.job_path = ? and tjb.job_name = ? and tjb.class = ?`,
-			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName, "WorkflowRun"),
+		dal.Where(`tjb.connection_id = ? and tjb.class = ?`, data.Options.ConnectionId, "WorkflowRun"),
+		jobBuildsFilter("tjb", data.Options),
 	}
 	timeAfter := data.TimeAfter
 	if timeAfter != nil {
@@ -81,7 +90,7 @@ func CollectApiStages(taskCtx plugin.SubTaskContext) errors.Error {
 		},
 		ApiClient:   data.ApiClient,
 		Input:       iterator,
-		UrlTemplate: fmt.Sprintf("%sjob/%s/{{ .Input.Number }}/wfapi/describe", data.Options.JobPath, data.Options.JobName),
+		UrlTemplate: "{{ .Input.JobUrl }}{{ .Input.Number }}/wfapi/describe",
 		/*
 			(Optional) Return query string for request, or you can plug them into UrlTemplate directly
 		*/
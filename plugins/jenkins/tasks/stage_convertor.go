@@ -70,8 +70,8 @@ func ConvertStages(taskCtx plugin.SubTaskContext) (err errors.Error) {
 			tjb.triggered_by, tjb.building`),
 		dal.From("_tool_jenkins_stages tjs"),
 		dal.Join("left join _tool_jenkins_builds tjb on tjs.build_name = tjb.full_name"),
-		dal.Where("tjb.connection_id = ? and tjb.job_path = ? and tjb.job_name = ? ",
-			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName),
+		dal.Where("tjb.connection_id = ?", data.Options.ConnectionId),
+		jobBuildsFilter("tjb", data.Options),
 	}
 
 	cursor, err := db.Cursor(clauses...)
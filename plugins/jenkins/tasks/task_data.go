@@ -34,6 +34,7 @@ type JenkinsOptions struct {
 	JobFullName   string `json:"jobFullName"` // "path1/path2/job name"
 	JobName       string `json:"jobName"`     // "job name"
 	JobPath       string `json:"jobPath"`     // "job/path1/job/path2"
+	JobClass      string `json:"jobClass"`    // "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
 	TimeAfter     string
 	Tasks         []string                   `json:"tasks,omitempty"`
 	ScopeConfig   *models.JenkinsScopeConfig `mapstructure:"scopeConfig" json:"scopeConfig"`
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_TEST_REPORT_TABLE = "jenkins_api_test_reports"
+
+var CollectApiTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiTestReports",
+	EntryPoint:       CollectApiTestReports,
+	EnabledByDefault: true,
+	Description:      "Collect test reports of finished builds from jenkins api, supports timeFilter but not diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	Retry:            plugin.CollectorRetry,
+}
+
+func CollectApiTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	clauses := []dal.Clause{
+		dal.Select("tjb.number,tjb.full_name,tjb.job_path,tjb.job_name"),
+		dal.From("_tool_jenkins_builds as tjb"),
+		dal.Where(`tjb.connection_id = ? and tjb.building = ?`, data.Options.ConnectionId, false),
+		jobBuildsFilter("tjb", data.Options),
+	}
+	timeAfter := data.TimeAfter
+	if timeAfter != nil {
+		clauses = append(clauses, dal.Where(`tjb.start_time >= ?`, timeAfter))
+	}
+
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimpleBuild{}))
+	if err != nil {
+		return err
+	}
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		ApiClient:   data.ApiClient,
+		Input:       iterator,
+		UrlTemplate: "{{ .Input.JobUrl }}{{ .Input.Number }}/testReport/api/json",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("tree", "suites[name,duration,cases[className,name,status,duration,errorDetails]]")
+			return query, nil
+		},
+		// the whole report of a build is saved as one row, suites are told apart by their position
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var report json.RawMessage
+			err := api.UnmarshalResponse(res, &report)
+			if err != nil {
+				return nil, err
+			}
+			return []json.RawMessage{report}, nil
+		},
+		// builds without test results answer 404
+		AfterResponse: ignoreHTTPStatus404,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
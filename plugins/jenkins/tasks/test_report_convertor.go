@@ -0,0 +1,146 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ConvertTestReportsMeta = plugin.SubTaskMeta{
+	Name:             "convertTestReports",
+	EntryPoint:       ConvertTestReports,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table jenkins_test_suites and jenkins_test_cases into domain layer table test_suites, test_cases and test_case_runs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type jenkinsTestSuiteWithBuild struct {
+	models.JenkinsTestSuite
+	HasStages     bool
+	StartTime     time.Time
+	BuildDuration float64
+}
+
+func ConvertTestReports(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	clauses := []dal.Clause{
+		dal.Select("tjts.*, tjb.has_stages, tjb.start_time, tjb.duration AS build_duration"),
+		dal.From("_tool_jenkins_test_suites AS tjts"),
+		dal.Join(`JOIN _tool_jenkins_builds tjb ON tjb.connection_id = tjts.connection_id AND tjb.full_name = tjts.build_name`),
+		dal.Where(`tjb.connection_id = ?`, data.Options.ConnectionId),
+		jobBuildsFilter("tjb", data.Options),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	buildIdGen := didgen.NewDomainIdGenerator(&models.JenkinsBuild{})
+	jobIdGen := didgen.NewDomainIdGenerator(&models.JenkinsJob{})
+	suiteIdGen := didgen.NewDomainIdGenerator(&models.JenkinsTestSuite{})
+	caseIdGen := didgen.NewDomainIdGenerator(&models.JenkinsTestCase{})
+	cicdScopeId := jobIdGen.Generate(data.Options.ConnectionId, data.Options.JobFullName)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(jenkinsTestSuiteWithBuild{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_TEST_REPORT_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jenkinsSuite := inputRow.(*jenkinsTestSuiteWithBuild)
+			pipelineId := buildIdGen.Generate(jenkinsSuite.ConnectionId, jenkinsSuite.BuildName)
+			// builds without stages are converted into a task of their own
+			taskId := ""
+			if !jenkinsSuite.HasStages {
+				taskId = pipelineId
+			}
+			finishedDate := jenkinsSuite.StartTime.Add(time.Duration(jenkinsSuite.BuildDuration) * time.Millisecond)
+			suite := &devops.TestSuite{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: suiteIdGen.Generate(jenkinsSuite.ConnectionId, jenkinsSuite.BuildName, jenkinsSuite.SuiteIndex),
+				},
+				Name:           jenkinsSuite.Name,
+				CicdScopeId:    cicdScopeId,
+				CicdPipelineId: pipelineId,
+				CicdTaskId:     taskId,
+				DurationSec:    jenkinsSuite.Duration,
+				TotalCount:     jenkinsSuite.TotalCount,
+				PassedCount:    jenkinsSuite.PassedCount,
+				FailedCount:    jenkinsSuite.FailedCount,
+				SkippedCount:   jenkinsSuite.SkippedCount,
+				FinishedDate:   &finishedDate,
+			}
+			results := []interface{}{suite}
+
+			var jenkinsCases []models.JenkinsTestCase
+			err := db.All(&jenkinsCases, dal.Where("connection_id = ? AND build_name = ? AND suite_index = ?",
+				jenkinsSuite.ConnectionId, jenkinsSuite.BuildName, jenkinsSuite.SuiteIndex))
+			if err != nil {
+				return nil, err
+			}
+			for _, jenkinsCase := range jenkinsCases {
+				testCase := &devops.TestCase{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: api.GenerateTestCaseId(cicdScopeId, jenkinsCase.ClassName, jenkinsCase.Name),
+					},
+					CicdScopeId: cicdScopeId,
+					ClassName:   jenkinsCase.ClassName,
+					Name:        jenkinsCase.Name,
+				}
+				run := &devops.TestCaseRun{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: caseIdGen.Generate(jenkinsCase.ConnectionId, jenkinsCase.BuildName, jenkinsCase.SuiteIndex, jenkinsCase.CaseIndex),
+					},
+					TestCaseId:     testCase.Id,
+					TestSuiteId:    suite.Id,
+					CicdScopeId:    cicdScopeId,
+					CicdPipelineId: pipelineId,
+					CicdTaskId:     taskId,
+					Result:         getTestResult(jenkinsCase.Status),
+					DurationSec:    jenkinsCase.Duration,
+					Message:        jenkinsCase.ErrorDetails,
+					FinishedDate:   &finishedDate,
+				}
+				results = append(results, testCase, run)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
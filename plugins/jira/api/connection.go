@@ -55,7 +55,7 @@ func TestConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 	if e != nil {
 		return nil, errors.Convert(e)
 	}
-	e = vld.StructExcept(connection, "BasicAuth", "AccessToken")
+	e = vld.StructExcept(connection, "BasicAuth", "AccessToken", "OAuth2")
 	if e != nil {
 		return nil, errors.Convert(e)
 	}
@@ -84,6 +84,9 @@ func TestConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 		return nil, errors.NotFound.New(fmt.Sprintf("Seems like an invalid Endpoint URL, please try %s", restUrl.String()))
 	}
 	if res.StatusCode == http.StatusUnauthorized {
+		if connection.AuthMethod == plugin.AUTH_METHOD_OAUTH2 {
+			return nil, errors.HttpStatus(http.StatusBadRequest).New("The OAuth2 access token was rejected, please check the scopes granted to the app")
+		}
 		return nil, errors.HttpStatus(http.StatusBadRequest).New("Error username/password")
 	}
 
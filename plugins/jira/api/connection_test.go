@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestConnectionWithOAuth2(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":3600,"refresh_token":"refresh-1"}`))
+	})
+	authorized := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer access" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(body))
+		}
+	}
+	mux.HandleFunc("/rest/api/2/serverInfo", authorized(`{"deploymentType":"Cloud","versionNumbers":[1001,0,0]}`))
+	mux.HandleFunc("/rest/agile/1.0/board", authorized(`{"values":[]}`))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	vld = validator.New()
+	basicRes = unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {})
+	output, err := TestConnection(&plugin.ApiResourceInput{Body: map[string]interface{}{
+		"endpoint":     server.URL + "/rest/",
+		"authMethod":   plugin.AUTH_METHOD_OAUTH2,
+		"clientId":     "id",
+		"clientSecret": "secret",
+		"tokenUrl":     server.URL + "/oauth/token",
+		"refreshToken": "refresh-0",
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, output.Status)
+	assert.True(t, output.Body.(JiraTestConnResponse).Success)
+}
@@ -139,6 +139,7 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertIssueCommentsMeta,
 		tasks.ConvertWorklogsMeta,
 		tasks.ConvertIssueChangelogsMeta,
+		tasks.GenerateIssueSnapshotsMeta,
 
 		tasks.ConvertSprintsMeta,
 		tasks.ConvertSprintIssuesMeta,
@@ -36,12 +36,14 @@ type BoardResponse struct {
 	Value string
 }
 
-// JiraConn holds the essential information to connect to the Jira API
+// JiraConn holds the essential information to connect to the Jira API,
+// OAuth2 covers the OAuth 2.0 (3LO) apps of Jira Cloud, whose refresh tokens are rotated on every exchange
 type JiraConn struct {
 	helper.RestConnection `mapstructure:",squash"`
 	helper.MultiAuth      `mapstructure:",squash"`
 	helper.BasicAuth      `mapstructure:",squash"`
 	helper.AccessToken    `mapstructure:",squash"`
+	helper.OAuth2         `mapstructure:",squash"`
 }
 
 // SetupAuthentication implements the `IAuthentication` interface by delegating
@@ -41,5 +41,6 @@ func All() []plugin.MigrationScript {
 		new(addRepoUrl),
 		new(addApplicationType),
 		new(clearRepoPattern),
+		new(addOAuth2ToConnection),
 	}
 }
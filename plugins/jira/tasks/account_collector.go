@@ -38,6 +38,7 @@ var CollectAccountsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "collect Jira accounts, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectAccounts(taskCtx plugin.SubTaskContext) errors.Error {
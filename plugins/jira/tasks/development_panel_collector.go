@@ -42,6 +42,7 @@ var CollectDevelopmentPanelMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "collect Jira development panel",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectDevelopmentPanel(taskCtx plugin.SubTaskContext) errors.Error {
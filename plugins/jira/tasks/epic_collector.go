@@ -44,6 +44,7 @@ var CollectEpicsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "collect Jira epics from all boards, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectEpics(taskCtx plugin.SubTaskContext) errors.Error {
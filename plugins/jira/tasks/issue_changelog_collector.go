@@ -43,6 +43,7 @@ var CollectIssueChangelogsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "collect Jira Issue change logs, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectIssueChangelogs(taskCtx plugin.SubTaskContext) errors.Error {
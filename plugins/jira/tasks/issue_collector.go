@@ -42,6 +42,7 @@ var CollectIssuesMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "collect Jira issues, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectIssues(taskCtx plugin.SubTaskContext) errors.Error {
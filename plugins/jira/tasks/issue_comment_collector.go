@@ -43,6 +43,7 @@ var CollectIssueCommentsMeta = plugin.SubTaskMeta{
 	EnabledByDefault: false,
 	Description:      "collect Jira issue comments, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectIssueComments(taskCtx plugin.SubTaskContext) errors.Error {
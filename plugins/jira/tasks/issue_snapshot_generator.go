@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var GenerateIssueSnapshotsMeta = plugin.SubTaskMeta{
+	Name:             "generateIssueSnapshots",
+	EntryPoint:       GenerateIssueSnapshots,
+	EnabledByDefault: true,
+	Description:      "generate daily snapshots of Jira issues from their changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func GenerateIssueSnapshots(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	fields := api.IssueSnapshotFields{}
+	if data.Options.ScopeConfig != nil && data.Options.ScopeConfig.StoryPointField != "" {
+		fields.StoryPoint = []string{data.Options.ScopeConfig.StoryPointField}
+	}
+	generator, err := api.NewIssueSnapshotGenerator(api.IssueSnapshotGeneratorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_ISSUE_TABLE,
+		},
+		BoardId: didgen.NewDomainIdGenerator(&models.JiraBoard{}).Generate(data.Options.ConnectionId, data.Options.BoardId),
+		Fields:  fields,
+		Since:   data.TimeAfter,
+	})
+	if err != nil {
+		return err
+	}
+	return generator.Execute()
+}
@@ -37,6 +37,7 @@ var CollectIssueTypesMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "collect Jira issue_types, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectIssueTypes(taskCtx plugin.SubTaskContext) errors.Error {
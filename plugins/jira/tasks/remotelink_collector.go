@@ -39,6 +39,7 @@ var CollectRemotelinksMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "collect Jira remote links, supports both timeFilter and diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectRemotelinks(taskCtx plugin.SubTaskContext) errors.Error {
@@ -34,6 +34,7 @@ var CollectStatusMeta = plugin.SubTaskMeta{
 	EnabledByDefault: true,
 	Description:      "collect Jira status, does not support either timeFilter or diffSync.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Retry:            plugin.CollectorRetry,
 }
 
 func CollectStatus(taskCtx plugin.SubTaskContext) errors.Error {
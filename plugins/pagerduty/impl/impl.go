@@ -115,9 +115,8 @@ func (p PagerDuty) PrepareTaskData(taskCtx plugin.TaskContext, options map[strin
 		}
 		timeAfter = &convertedTime
 	}
-	client, err := helper.NewApiClient(taskCtx.GetContext(), connection.Endpoint, map[string]string{
-		"Authorization": fmt.Sprintf("Token %s", connection.Token),
-	}, 0, connection.Proxy, taskCtx)
+	// authenticated by the connection, the same way as the connection gets tested, with its secrets resolved
+	client, err := helper.NewApiClientFromConnection(taskCtx.GetContext(), taskCtx, connection)
 	if err != nil {
 		return nil, err
 	}
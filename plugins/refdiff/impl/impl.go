@@ -32,6 +32,7 @@ var _ interface {
 	plugin.PluginTask
 	plugin.PluginApi
 	plugin.PluginModel
+	plugin.PluginDataLineage
 	plugin.PluginMetric
 } = (*RefDiff)(nil)
 
@@ -55,6 +56,19 @@ func (p RefDiff) GetTablesInfo() []dal.Tabler {
 	}
 }
 
+// DataLineage declares the diffs calculated from the refs and deployments of scopes, the finished pairs are
+// deleted as well so the diffs get calculated again once the scope is collected again
+func (p RefDiff) DataLineage() []plugin.TableLineage {
+	return []plugin.TableLineage{
+		{Table: "ref_commits", Column: "new_ref_id", ParentTable: "refs", ParentColumn: "id"},
+		{Table: "refs_issues_diffs", Column: "new_ref_id", ParentTable: "refs", ParentColumn: "id"},
+		{Table: "commits_diffs", Column: "new_commit_sha", ParentTable: "ref_commits", ParentColumn: "new_commit_sha"},
+		{Table: "commits_diffs", Column: "new_commit_sha", ParentTable: "cicd_deployment_commits", ParentColumn: "commit_sha"},
+		{Table: "_tool_refdiff_finished_commits_diffs", Column: "new_commit_sha", ParentTable: "ref_commits", ParentColumn: "new_commit_sha"},
+		{Table: "_tool_refdiff_finished_commits_diffs", Column: "new_commit_sha", ParentTable: "cicd_deployment_commits", ParentColumn: "commit_sha"},
+	}
+}
+
 func (p RefDiff) IsProjectMetric() bool {
 	return false
 }
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/slack/impl"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+	"github.com/apache/incubator-devlake/plugins/slack/tasks"
+)
+
+func TestSlackChannelMessageDataFlow(t *testing.T) {
+	var slack impl.Slack
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "slack", slack)
+
+	taskData := &tasks.SlackTaskData{
+		Options: &tasks.SlackOptions{
+			ConnectionId: 1,
+		},
+	}
+
+	// import raw data table, the replies of a thread come along with its root message
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_slack_channel_message.csv", "_raw_slack_channel_message")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_slack_thread.csv", "_raw_slack_thread")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.SlackChannelMessage{})
+	dataflowTester.FlushTabler(&models.SlackMessageReaction{})
+	dataflowTester.Subtask(tasks.ExtractChannelMessageMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractThreadMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.SlackChannelMessage{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_slack_channel_messages.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(models.SlackMessageReaction{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_slack_message_reactions.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&chat.ChatMessage{})
+	dataflowTester.FlushTabler(&chat.ChatReaction{})
+	dataflowTester.Subtask(tasks.ConvertChannelMessageMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertMessageReactionMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(chat.ChatMessage{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/chat_messages.csv",
+		TargetFields: []string{
+			"id", "channel_id", "thread_id", "author_id", "type", "content",
+			"reply_count", "is_deleted", "created_date", "updated_date",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(chat.ChatReaction{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/chat_reactions.csv",
+		TargetFields: []string{"message_id", "name", "account_id"},
+	})
+
+	// verify threads and participants, bot replies don't count as a response
+	dataflowTester.FlushTabler(&chat.ChatThread{})
+	dataflowTester.FlushTabler(&chat.ChatParticipant{})
+	dataflowTester.Subtask(tasks.GenerateChatActivityMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(chat.ChatThread{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/chat_threads.csv",
+		TargetFields: []string{
+			"id", "channel_id", "author_id", "reply_count", "participant_count",
+			"created_date", "first_response_date", "last_reply_date",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(chat.ChatParticipant{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/chat_participants.csv",
+		TargetFields: []string{"channel_id", "account_id", "message_count", "first_message_date", "last_message_date"},
+	})
+}
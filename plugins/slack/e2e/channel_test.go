@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/slack/impl"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+	"github.com/apache/incubator-devlake/plugins/slack/tasks"
+)
+
+func TestSlackChannelDataFlow(t *testing.T) {
+	var slack impl.Slack
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "slack", slack)
+
+	taskData := &tasks.SlackTaskData{
+		Options: &tasks.SlackOptions{
+			ConnectionId: 1,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_slack_channel.csv", "_raw_slack_channel")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.SlackChannel{})
+	dataflowTester.Subtask(tasks.ExtractChannelMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.SlackChannel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_slack_channels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion, direct messages and multi-person direct messages are private
+	dataflowTester.FlushTabler(&chat.ChatChannel{})
+	dataflowTester.Subtask(tasks.ConvertChannelMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(chat.ChatChannel{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/chat_channels.csv",
+		TargetFields: []string{"id", "name", "description", "type", "is_private", "is_archived", "creator_id", "created_date"},
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/impl"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+	"github.com/apache/incubator-devlake/plugins/slack/tasks"
+)
+
+func TestSlackUserDataFlow(t *testing.T) {
+	var slack impl.Slack
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "slack", slack)
+
+	taskData := &tasks.SlackTaskData{
+		Options: &tasks.SlackOptions{
+			ConnectionId: 1,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_slack_user.csv", "_raw_slack_user")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.SlackUser{})
+	dataflowTester.Subtask(tasks.ExtractUserMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(models.SlackUser{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_slack_users.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&crossdomain.Account{})
+	dataflowTester.Subtask(tasks.ConvertUserMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(crossdomain.Account{}, e2ehelper.TableOptions{
+		CSVRelPath:   "./snapshot_tables/accounts.csv",
+		TargetFields: []string{"id", "email", "full_name", "user_name", "avatar_url"},
+	})
+}
+
+func TestSlackUserCollector(t *testing.T) {
+	var slack impl.Slack
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "slack", slack)
+
+	// created through the model so the token gets encrypted
+	dataflowTester.FlushTabler(&models.SlackConnection{})
+	connection := &models.SlackConnection{
+		BaseConnection: helper.BaseConnection{Name: "slack", Model: common.Model{ID: 1}},
+		SlackConn: models.SlackConn{
+			RestConnection: helper.RestConnection{Endpoint: "https://slack.com/api/"},
+			AccessToken:    helper.AccessToken{Token: "xoxb-test"},
+		},
+	}
+	if err := dataflowTester.Dal.Create(connection); err != nil {
+		panic(err)
+	}
+
+	// the first page is full so the collection follows the cursor to the second one
+	dataflowTester.FlushRawTable("_raw_slack_user")
+	dataflowTester.SubtaskWithCassette(tasks.CollectUserMeta, "./cassettes/users.json", map[string]interface{}{
+		"connectionId": 1,
+	})
+
+	dataflowTester.FlushTabler(&models.SlackUser{})
+	dataflowTester.Subtask(tasks.ExtractUserMeta, &tasks.SlackTaskData{
+		Options: &tasks.SlackOptions{
+			ConnectionId: 1,
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(models.SlackUser{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_slack_users_collected.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
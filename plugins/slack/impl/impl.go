@@ -55,6 +55,8 @@ func (p Slack) GetTablesInfo() []dal.Tabler {
 		&models.SlackConnection{},
 		&models.SlackChannelMessage{},
 		&models.SlackChannel{},
+		&models.SlackUser{},
+		&models.SlackMessageReaction{},
 	}
 }
 
@@ -80,6 +82,9 @@ func (p Slack) ScopeConfig() dal.Tabler {
 
 func (p Slack) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
+		tasks.CollectUserMeta,
+		tasks.ExtractUserMeta,
+
 		tasks.CollectChannelMeta,
 		tasks.ExtractChannelMeta,
 
@@ -88,6 +93,12 @@ func (p Slack) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.CollectThreadMeta,
 		tasks.ExtractThreadMeta,
+
+		tasks.ConvertUserMeta,
+		tasks.ConvertChannelMeta,
+		tasks.ConvertChannelMessageMeta,
+		tasks.ConvertMessageReactionMeta,
+		tasks.GenerateChatActivityMeta,
 	}
 }
 
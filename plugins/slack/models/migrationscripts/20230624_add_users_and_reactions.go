@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addUsersAndReactions struct{}
+
+type user20230624 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	Id           string `gorm:"primaryKey;type:varchar(255)"`
+	TeamId       string `gorm:"type:varchar(255)"`
+	Name         string `gorm:"type:varchar(255)"`
+	RealName     string `gorm:"type:varchar(255)"`
+	DisplayName  string `gorm:"type:varchar(255)"`
+	Email        string `gorm:"type:varchar(255)"`
+	Image        string `gorm:"type:varchar(255)"`
+	IsBot        bool
+	Deleted      bool
+}
+
+func (user20230624) TableName() string {
+	return "_tool_slack_users"
+}
+
+type messageReaction20230624 struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ChannelId    string `gorm:"primaryKey;type:varchar(255)"`
+	MessageTs    string `gorm:"primaryKey;type:varchar(255)"`
+	Name         string `gorm:"primaryKey;type:varchar(255)"`
+	UserId       string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (messageReaction20230624) TableName() string {
+	return "_tool_slack_message_reactions"
+}
+
+func (*addUsersAndReactions) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&user20230624{},
+		&messageReaction20230624{},
+	)
+}
+
+func (*addUsersAndReactions) Version() uint64 {
+	return 20230624000001
+}
+
+func (*addUsersAndReactions) Name() string {
+	return "add users and message reactions for the domain layer conversion of slack"
+}
@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+	"reflect"
+	"time"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertChannel
+
+func ConvertChannel(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*SlackTaskData)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.SlackChannel{}),
+		dal.Where("connection_id = ?", data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	channelIdGen := didgen.NewDomainIdGenerator(&models.SlackChannel{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.SlackUser{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: SlackApiParams{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_CHANNEL_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.SlackChannel{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			slackChannel := inputRow.(*models.SlackChannel)
+			channel := &chat.ChatChannel{
+				DomainEntity: domainlayer.DomainEntity{Id: channelIdGen.Generate(data.Options.ConnectionId, slackChannel.Id)},
+				Name:         slackChannel.Name,
+				Type:         chat.CHANNEL,
+				IsPrivate:    slackChannel.IsPrivate,
+				IsArchived:   slackChannel.IsArchived,
+			}
+			if slackChannel.IsIm {
+				channel.Type = chat.DIRECT
+				channel.IsPrivate = true
+			} else if slackChannel.IsMpim {
+				channel.Type = chat.GROUP
+				channel.IsPrivate = true
+			}
+			if slackChannel.Creator != "" {
+				channel.CreatorId = userIdGen.Generate(data.Options.ConnectionId, slackChannel.Creator)
+			}
+			if slackChannel.Created > 0 {
+				createdDate := time.Unix(int64(slackChannel.Created), 0)
+				channel.CreatedDate = &createdDate
+			}
+			return []interface{}{channel}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+var ConvertChannelMeta = plugin.SubTaskMeta{
+	Name:             "convertChannel",
+	EntryPoint:       ConvertChannel,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_slack_channels into domain layer table chat_channels",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CHAT},
+}
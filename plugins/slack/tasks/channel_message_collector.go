@@ -115,4 +115,5 @@ var CollectChannelMessageMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectChannelMessage,
 	EnabledByDefault: true,
 	Description:      "Collect channel message from Slack api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CHAT},
 }
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/plugins/slack/apimodels"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// extractMessageReactions returns one reaction for each user who reacted to the message
+func extractMessageReactions(connectionId uint64, channelId string, body *apimodels.SlackChannelMessageResultItem) []interface{} {
+	var results []interface{}
+	for _, reaction := range body.Reactions {
+		for _, userId := range reaction.Users {
+			results = append(results, &models.SlackMessageReaction{
+				ConnectionId: connectionId,
+				ChannelId:    channelId,
+				MessageTs:    body.Ts,
+				Name:         reaction.Name,
+				UserId:       userId,
+			})
+		}
+	}
+	return results
+}
+
+// parseTs converts a message timestamp like `1687593600.123456` into time
+func parseTs(ts string) (time.Time, errors.Error) {
+	seconds, micros, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.BadInput.Wrap(err, "invalid message ts "+ts)
+	}
+	var usec int64
+	if micros != "" {
+		usec, err = strconv.ParseInt(micros, 10, 64)
+		if err != nil {
+			return time.Time{}, errors.BadInput.Wrap(err, "invalid message ts "+ts)
+		}
+	}
+	return time.Unix(sec, usec*int64(time.Microsecond)), nil
+}
@@ -118,4 +118,5 @@ var CollectThreadMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectThread,
 	EnabledByDefault: true,
 	Description:      "Collect thread from Slack api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CHAT},
 }
@@ -39,6 +39,11 @@ func Proxy(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Er
 	if err != nil {
 		return nil, err
 	}
+	// secrets kept by external providers are resolved into a copy, the same as api clients created from connections
+	connection, err = plugin.CopyWithSecretsResolved(context.TODO(), connection)
+	if err != nil {
+		return nil, err
+	}
 	apiClient, err := helper.NewApiClient(
 		context.TODO(),
 		connection.Endpoint,
@@ -60,7 +60,7 @@ func makeScopeV200(connectionId uint64, scopes []*plugin.BlueprintScopeV200) ([]
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_TICKET) {
 			domainBoard := &ticket.Board{
 				DomainEntity: domainlayer.DomainEntity{
-					Id: didgen.NewDomainIdGenerator(&models.TrelloConnection{}).Generate(trelloBoard.ConnectionId, trelloBoard.BoardId),
+					Id: didgen.NewDomainIdGenerator(&models.TrelloBoard{}).Generate(trelloBoard.ConnectionId, trelloBoard.BoardId),
 				},
 				Name: trelloBoard.Name,
 			}
@@ -18,22 +18,37 @@ limitations under the License.
 package e2e
 
 import (
+	"encoding/json"
+	"testing"
+
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/trello/impl"
 	"github.com/apache/incubator-devlake/plugins/trello/models"
 	"github.com/apache/incubator-devlake/plugins/trello/tasks"
-	"testing"
 )
 
 func TestTrelloCardDataFlow(t *testing.T) {
 	var trello impl.Trello
 	dataflowTester := e2ehelper.NewDataFlowTester(t, "trello", trello)
 
+	scopeConfig := &models.TrelloScopeConfig{
+		StatusMappings: json.RawMessage(`{
+			"🗒 Backlog": "TODO",
+			"🗓 Sprint Backlog - [Timeline]": "TODO",
+			"📅 Working On": "IN_PROGRESS",
+			"🧑🏾‍💻 Testing [Staging Server]": "IN_PROGRESS",
+			"📆 Sprint - Done [Version: 1.2.0]": "DONE",
+			"🗄 Sprint - Done [Version: 1.1.0]": "DONE"
+		}`),
+		TypeMappings: json.RawMessage(`{"Flagged 🔴": "BUG"}`),
+	}
 	taskData := &tasks.TrelloTaskData{
 		Options: &tasks.TrelloOptions{
 			ConnectionId: 1,
 			BoardId:      "6402f643d23aa9af56b28f4b",
+			ScopeConfig:  scopeConfig,
 		},
 	}
 
@@ -47,4 +62,44 @@ func TestTrelloCardDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/_tool_trello_cards.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_lists.csv", &models.TrelloList{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_labels.csv", &models.TrelloLabel{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_members.csv", &models.TrelloMember{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_card_actions.csv", &models.TrelloCardAction{})
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.FlushTabler(&ticket.IssueLabel{})
+	dataflowTester.Subtask(tasks.ConvertCardMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertCardLabelMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.Issue{}, e2ehelper.TableOptions{
+		CSVRelPath: "./snapshot_tables/issues.csv",
+		TargetFields: []string{
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"description",
+			"type",
+			"original_type",
+			"status",
+			"original_status",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"lead_time_minutes",
+			"assignee_id",
+			"assignee_name",
+		},
+	})
+	dataflowTester.VerifyTableWithOptions(ticket.BoardIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(ticket.IssueLabel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_labels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
@@ -60,6 +60,7 @@ func (p Trello) GetTablesInfo() []dal.Tabler {
 		&models.TrelloLabel{},
 		&models.TrelloMember{},
 		&models.TrelloCheckItem{},
+		&models.TrelloCardAction{},
 		&models.TrelloScopeConfig{},
 	}
 }
@@ -88,6 +89,15 @@ func (p Trello) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.CollectMemberMeta,
 		tasks.ExtractMemberMeta,
+
+		tasks.CollectCardActionMeta,
+		tasks.ExtractCardActionMeta,
+
+		tasks.ConvertBoardMeta,
+		tasks.ConvertAccountMeta,
+		tasks.ConvertCardMeta,
+		tasks.ConvertCardLabelMeta,
+		tasks.ConvertCardActionMeta,
 	}
 }
 
@@ -118,6 +128,24 @@ func (p Trello) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]i
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "error getting connection for Trello plugin")
 	}
+	db := taskCtx.GetDal()
+	if op.ScopeConfig == nil && op.ScopeConfigId == 0 {
+		var board models.TrelloBoard
+		err = db.First(&board, dal.Where("connection_id = ? AND board_id = ?", op.ConnectionId, op.BoardId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("fail to find board %s", op.BoardId))
+		}
+		op.ScopeConfigId = board.ScopeConfigId
+	}
+	if op.ScopeConfig == nil && op.ScopeConfigId != 0 {
+		var scopeConfig models.TrelloScopeConfig
+		err = db.First(&scopeConfig, dal.Where("id = ?", op.ScopeConfigId))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "fail to get scopeConfig")
+		}
+		op.ScopeConfig = &scopeConfig
+	}
+
 	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
 	if err != nil {
 		return nil, err
@@ -27,7 +27,7 @@ var _ plugin.ToolLayerScope = (*TrelloBoard)(nil)
 type TrelloBoard struct {
 	common.NoPKModel `json:"-" mapstructure:"-"`
 	ConnectionId     uint64 `json:"connectionId" mapstructure:"connectionId" gorm:"primaryKey"`
-	BoardId          string `json:"boardId" mapstructure:"boardId" gorm:"type:varchar(255)"`
+	BoardId          string `json:"boardId" mapstructure:"boardId" gorm:"primaryKey;type:varchar(255)"`
 	ScopeConfigId    uint64 `json:"scopeConfigId,omitempty" mapstructure:"scopeConfigId"`
 	Name             string `json:"name" mapstructure:"name" gorm:"type:varchar(255)"`
 }
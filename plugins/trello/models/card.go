@@ -36,6 +36,9 @@ type TrelloCard struct {
 	ShortUrl         string `gorm:"type:varchar(255)"`
 	Subscribed       bool
 	Url              string `gorm:"type:varchar(255)"`
+	Desc             string
+	IDMembers        []string `gorm:"serializer:json;type:text"`
+	IDLabels         []string `gorm:"serializer:json;type:text"`
 	common.NoPKModel
 }
 
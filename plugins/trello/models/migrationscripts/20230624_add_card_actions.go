@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type addCardActions struct{}
+
+type board20230624 struct {
+	archived.NoPKModel
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	BoardId       string `gorm:"primaryKey;type:varchar(255)"` // a connection may hold more than one board
+	ScopeConfigId uint64
+	Name          string `gorm:"type:varchar(255)"`
+}
+
+func (board20230624) TableName() string {
+	return "_tool_trello_boards"
+}
+
+type card20230624 struct {
+	Desc      string
+	IDMembers string `gorm:"type:text"`
+	IDLabels  string `gorm:"type:text"`
+}
+
+func (card20230624) TableName() string {
+	return "_tool_trello_cards"
+}
+
+type scopeConfig20230624 struct {
+	StatusMappings json.RawMessage
+	TypeMappings   json.RawMessage
+}
+
+func (scopeConfig20230624) TableName() string {
+	return "_tool_trello_scope_configs"
+}
+
+type cardAction20230624 struct {
+	ID                string `gorm:"primaryKey;type:varchar(255)"`
+	IDBoard           string `gorm:"type:varchar(255)"`
+	IDCard            string `gorm:"index;type:varchar(255)"`
+	Type              string `gorm:"type:varchar(100)"`
+	IDMemberCreator   string `gorm:"type:varchar(255)"`
+	MemberCreatorName string `gorm:"type:varchar(255)"`
+	ListBeforeId      string `gorm:"type:varchar(255)"`
+	ListBeforeName    string `gorm:"type:varchar(255)"`
+	ListAfterId       string `gorm:"type:varchar(255)"`
+	ListAfterName     string `gorm:"type:varchar(255)"`
+	Date              time.Time
+	archived.NoPKModel
+}
+
+func (cardAction20230624) TableName() string {
+	return "_tool_trello_card_actions"
+}
+
+func (script *addCardActions) Up(basicRes context.BasicRes) errors.Error {
+	// the primary key of boards used to be the auto-incremented connection_id alone, which is why
+	// migrationhelper.TransformTable can not be used here
+	db := basicRes.GetDal()
+	tmpTableName := "_tool_trello_boards_20230624"
+	err := db.RenameTable("_tool_trello_boards", tmpTableName)
+	if err != nil {
+		return err
+	}
+	err = db.AutoMigrate(&board20230624{})
+	if err != nil {
+		return err
+	}
+	err = migrationhelper.CopyTableColumns(basicRes, tmpTableName, "_tool_trello_boards", func(s *board20230624) (*board20230624, errors.Error) {
+		dst := *s
+		return &dst, nil
+	})
+	if err != nil {
+		return err
+	}
+	err = db.DropTables(tmpTableName)
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&card20230624{},
+		&scopeConfig20230624{},
+		&cardAction20230624{},
+	)
+}
+
+func (*addCardActions) Version() uint64 {
+	return 20230624101500
+}
+
+func (*addCardActions) Name() string {
+	return "add card actions and fields for the domain layer conversion of trello"
+}
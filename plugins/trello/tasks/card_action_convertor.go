@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertCardAction
+
+var ConvertCardActionMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCardAction",
+	EntryPoint:       ConvertCardAction,
+	EnabledByDefault: true,
+	Description:      "Convert list moves of tool layer table trello_card_actions into domain layer table issue_changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertCardAction(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TrelloTaskData)
+	db := taskCtx.GetDal()
+
+	statusMappings, err := getStatusMappings(data)
+	if err != nil {
+		return err
+	}
+	// only moves between lists change the status of a card
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloCardAction{}),
+		dal.Where("id_board = ? AND list_before_id != ''", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	actionIdGen := didgen.NewDomainIdGenerator(&models.TrelloCardAction{})
+	cardIdGen := didgen.NewDomainIdGenerator(&models.TrelloCard{})
+	memberIdGen := didgen.NewDomainIdGenerator(&models.TrelloMember{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_CARD_ACTION_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.TrelloCardAction{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			action := inputRow.(*models.TrelloCardAction)
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity:      domainlayer.DomainEntity{Id: actionIdGen.Generate(action.ID)},
+				IssueId:           cardIdGen.Generate(action.IDCard),
+				AuthorId:          memberIdGen.Generate(action.IDMemberCreator),
+				AuthorName:        action.MemberCreatorName,
+				FieldId:           "idList",
+				FieldName:         "status",
+				OriginalFromValue: action.ListBeforeName,
+				OriginalToValue:   action.ListAfterName,
+				FromValue:         getStdStatus(statusMappings, action.ListBeforeName),
+				ToValue:           getStdStatus(statusMappings, action.ListAfterName),
+				CreatedDate:       action.Date,
+			}
+			return []interface{}{changelog}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,196 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertCard
+
+var ConvertCardMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCard",
+	EntryPoint:       ConvertCard,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_cards into domain layer table issues and board_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type cardWithList struct {
+	models.TrelloCard
+	ListName string
+}
+
+func ConvertCard(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TrelloTaskData)
+	db := taskCtx.GetDal()
+
+	statusMappings, err := getStatusMappings(data)
+	if err != nil {
+		return err
+	}
+	typeMappings, err := getTypeMappings(data)
+	if err != nil {
+		return err
+	}
+	labelNames, err := getLabelNames(db, data.Options.BoardId)
+	if err != nil {
+		return err
+	}
+	var members []models.TrelloMember
+	err = db.All(&members)
+	if err != nil {
+		return err
+	}
+	memberNames := make(map[string]string, len(members))
+	for _, member := range members {
+		memberNames[member.ID] = member.FullName
+	}
+	// the last time each card entered each list, the one of its current list is when a done card was resolved
+	var listEntries []struct {
+		IDCard      string
+		ListAfterId string
+		Date        time.Time
+	}
+	err = db.All(
+		&listEntries,
+		dal.Select("id_card, list_after_id, MAX(date) AS date"),
+		dal.From(&models.TrelloCardAction{}),
+		dal.Where("id_board = ? AND list_after_id != ''", data.Options.BoardId),
+		dal.Groupby("id_card, list_after_id"),
+	)
+	if err != nil {
+		return err
+	}
+	enteredAt := make(map[string]time.Time, len(listEntries))
+	for _, entry := range listEntries {
+		enteredAt[entry.IDCard+":"+entry.ListAfterId] = entry.Date
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("_tool_trello_cards.*, _tool_trello_lists.name AS list_name"),
+		dal.From(&models.TrelloCard{}),
+		dal.Join("LEFT JOIN _tool_trello_lists ON _tool_trello_lists.id = _tool_trello_cards.id_list"),
+		dal.Where("_tool_trello_cards.id_board = ?", data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	boardId := didgen.NewDomainIdGenerator(&models.TrelloBoard{}).Generate(data.Options.ConnectionId, data.Options.BoardId)
+	cardIdGen := didgen.NewDomainIdGenerator(&models.TrelloCard{})
+	memberIdGen := didgen.NewDomainIdGenerator(&models.TrelloMember{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_CARD_TABLE,
+		},
+		InputRowType: reflect.TypeOf(cardWithList{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			card := inputRow.(*cardWithList)
+			issue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: cardIdGen.Generate(card.ID)},
+				Url:            card.Url,
+				IssueKey:       strconv.Itoa(card.IDShort),
+				Title:          card.Name,
+				Description:    card.Desc,
+				Status:         getStdStatus(statusMappings, card.ListName),
+				OriginalStatus: card.ListName,
+				CreatedDate:    getCreatedDate(card.ID),
+				UpdatedDate:    &card.DateLastActivity,
+			}
+			for _, labelId := range card.IDLabels {
+				if stdType, ok := typeMappings[labelNames[labelId]]; ok {
+					issue.Type = stdType
+					issue.OriginalType = labelNames[labelId]
+					break
+				}
+			}
+			if issue.Status == ticket.DONE {
+				resolutionDate := card.DateLastActivity
+				if entered, ok := enteredAt[card.ID+":"+card.IDList]; ok {
+					resolutionDate = entered
+				}
+				issue.ResolutionDate = &resolutionDate
+				// cards copied from templates keep their original activity dates, which may predate the copy
+				if issue.CreatedDate != nil && resolutionDate.After(*issue.CreatedDate) {
+					issue.LeadTimeMinutes = int64(resolutionDate.Sub(*issue.CreatedDate).Minutes())
+				}
+			}
+			results := []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: boardId,
+					IssueId: issue.Id,
+				},
+			}
+			for i, memberId := range card.IDMembers {
+				assignee := &ticket.IssueAssignee{
+					IssueId:      issue.Id,
+					AssigneeId:   memberIdGen.Generate(memberId),
+					AssigneeName: memberNames[memberId],
+				}
+				if i == 0 {
+					issue.AssigneeId = assignee.AssigneeId
+					issue.AssigneeName = assignee.AssigneeName
+				}
+				results = append(results, assignee)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getLabelNames returns the name of each label of the board, falling back to its color for unnamed labels
+func getLabelNames(db dal.Dal, boardId string) (map[string]string, errors.Error) {
+	var labels []models.TrelloLabel
+	err := db.All(&labels, dal.Where("id_board = ?", boardId))
+	if err != nil {
+		return nil, err
+	}
+	labelNames := make(map[string]string, len(labels))
+	for _, label := range labels {
+		labelNames[label.ID] = label.Name
+		if label.Name == "" {
+			labelNames[label.ID] = label.Color
+		}
+	}
+	return labelNames, nil
+}
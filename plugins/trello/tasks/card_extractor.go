@@ -33,6 +33,7 @@ var ExtractCardMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractCard,
 	EnabledByDefault: true,
 	Description:      "Extract raw data into tool layer table trello_cards",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
 type TrelloApiCard struct {
@@ -102,6 +103,9 @@ func ExtractCard(taskCtx plugin.SubTaskContext) errors.Error {
 					ShortUrl:         apiCard.ShortUrl,
 					Subscribed:       apiCard.Subscribed,
 					Url:              apiCard.Url,
+					Desc:             apiCard.Desc,
+					IDMembers:        apiCard.IDMembers,
+					IDLabels:         apiCard.IDLabels,
 				},
 			}, nil
 		},
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+const RAW_BOARD_TABLE = "trello_boards"
+
+// getStatusMappings returns the standard issue status of each list name configured in the scope config
+func getStatusMappings(data *TrelloTaskData) (map[string]string, errors.Error) {
+	if data.Options.ScopeConfig == nil {
+		return map[string]string{}, nil
+	}
+	return parseMappings(data.Options.ScopeConfig.StatusMappings)
+}
+
+// getTypeMappings returns the standard issue type of each label name configured in the scope config
+func getTypeMappings(data *TrelloTaskData) (map[string]string, errors.Error) {
+	if data.Options.ScopeConfig == nil {
+		return map[string]string{}, nil
+	}
+	return parseMappings(data.Options.ScopeConfig.TypeMappings)
+}
+
+func parseMappings(raw json.RawMessage) (map[string]string, errors.Error) {
+	mappings := make(map[string]string)
+	if len(raw) == 0 {
+		return mappings, nil
+	}
+	err := errors.Convert(json.Unmarshal(raw, &mappings))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid mappings in trello scope config")
+	}
+	for name, std := range mappings {
+		mappings[name] = strings.ToUpper(std)
+	}
+	return mappings, nil
+}
+
+// getStdStatus maps a list name to a standard issue status, lists left out of the mappings are OTHER
+func getStdStatus(statusMappings map[string]string, listName string) string {
+	if stdStatus, ok := statusMappings[listName]; ok {
+		return stdStatus
+	}
+	return ticket.OTHER
+}
+
+// getCreatedDate returns the creation time encoded in the leading 4 bytes of a Trello object id
+func getCreatedDate(id string) *time.Time {
+	if len(id) < 8 {
+		return nil
+	}
+	seconds, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return nil
+	}
+	createdDate := time.Unix(seconds, 0).UTC()
+	return &createdDate
+}
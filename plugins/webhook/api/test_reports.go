@@ -0,0 +1,215 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+)
+
+const maxTestReportMemory = 32 << 20 // 32 MB
+const maxTestReportSize = 64 << 20   // 64 MB, larger uploads are rejected
+const testReportBatchSize = 500
+
+// PostTestReport
+// @Summary create test report by webhook
+// @Description Create test suites and test case runs from a JUnit XML report uploaded as the multipart field `file`.<br/>
+// @Description pipeline_id is the id of the cicd_pipeline the tests ran in, task_id the id of its cicd_task if any,
+// @Description end_time defaults to now. Uploading the same file for the same pipeline and task again replaces the previous results.
+// @Tags plugins/webhook
+// @Accept multipart/form-data
+// @Param file formData file true "JUnit XML report"
+// @Param pipeline_id formData string true "cicd_pipeline id"
+// @Param task_id formData string false "cicd_task id"
+// @Param end_time formData string false "finished time in RFC3339"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/webhook/:connectionId/test_reports [POST]
+func PostTestReport(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := &models.WebhookConnection{}
+	err := connectionHelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	if input.Request == nil {
+		return nil, errors.BadInput.New("the report should be uploaded as multipart/form-data")
+	}
+	if input.Request.MultipartForm == nil {
+		input.Request.Body = http.MaxBytesReader(nil, input.Request.Body, maxTestReportSize)
+		if err := input.Request.ParseMultipartForm(maxTestReportMemory); err != nil {
+			return nil, errors.BadInput.Wrap(err, "failed to parse multipart form")
+		}
+	}
+	pipelineId := strings.TrimSpace(input.Request.FormValue("pipeline_id"))
+	if pipelineId == "" {
+		return nil, errors.BadInput.New("pipeline_id is required")
+	}
+	taskId := strings.TrimSpace(input.Request.FormValue("task_id"))
+	finishedDate := time.Now()
+	if endTime := input.Request.FormValue("end_time"); endTime != "" {
+		finishedDate, err = errors.Convert01(time.Parse(time.RFC3339, endTime))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "end_time should be in RFC3339")
+		}
+	}
+	file, fileHeader, err1 := input.Request.FormFile("file")
+	if err1 != nil {
+		return nil, errors.BadInput.Wrap(err1, "the report should be uploaded as the field `file`")
+	}
+	// nolint
+	defer file.Close()
+	content, err1 := io.ReadAll(io.LimitReader(file, maxTestReportSize+1))
+	if err1 != nil {
+		return nil, errors.Convert(err1)
+	}
+	if len(content) > maxTestReportSize {
+		return nil, errors.BadInput.New(fmt.Sprintf("the report should not be larger than %d bytes", maxTestReportSize))
+	}
+	suites, err := api.ParseJunitReport(content)
+	if err != nil {
+		return nil, err
+	}
+
+	scopeId := fmt.Sprintf("%s:%d", "webhook", connection.ID)
+	reportHash16 := fmt.Sprintf("%x", md5.Sum([]byte(pipelineId+"\n"+taskId+"\n"+fileHeader.Filename)))[:16]
+	reportId := fmt.Sprintf("%s:%d:%s", "webhook", connection.ID, reportHash16)
+
+	testSuites := make([]*devops.TestSuite, 0, len(suites))
+	testCases := make([]*devops.TestCase, 0)
+	seenTestCases := make(map[string]bool)
+	testCaseRuns := make([]*devops.TestCaseRun, 0)
+	for suiteIndex, suite := range suites {
+		testSuite := &devops.TestSuite{
+			DomainEntity: domainlayer.DomainEntity{
+				Id: fmt.Sprintf("%s:%d", reportId, suiteIndex),
+			},
+			Name:           suite.Name,
+			CicdScopeId:    scopeId,
+			CicdPipelineId: pipelineId,
+			CicdTaskId:     taskId,
+			DurationSec:    suite.Duration,
+			TotalCount:     len(suite.Cases),
+			PassedCount:    suite.CountResult(devops.TEST_PASSED),
+			FailedCount:    suite.CountResult(devops.TEST_FAILED),
+			ErrorCount:     suite.CountResult(devops.TEST_ERROR),
+			SkippedCount:   suite.CountResult(devops.TEST_SKIPPED),
+			FinishedDate:   &finishedDate,
+		}
+		testSuites = append(testSuites, testSuite)
+		for caseIndex, c := range suite.Cases {
+			testCaseId := api.GenerateTestCaseId(scopeId, c.ClassName, c.Name)
+			if !seenTestCases[testCaseId] {
+				seenTestCases[testCaseId] = true
+				testCases = append(testCases, &devops.TestCase{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: testCaseId,
+					},
+					CicdScopeId: scopeId,
+					ClassName:   c.ClassName,
+					Name:        c.Name,
+				})
+			}
+			testCaseRuns = append(testCaseRuns, &devops.TestCaseRun{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: fmt.Sprintf("%s:%d", testSuite.Id, caseIndex),
+				},
+				TestCaseId:     testCaseId,
+				TestSuiteId:    testSuite.Id,
+				CicdScopeId:    scopeId,
+				CicdPipelineId: pipelineId,
+				CicdTaskId:     taskId,
+				Result:         c.Result,
+				DurationSec:    c.Duration,
+				Message:        c.Message,
+				FinishedDate:   &finishedDate,
+			})
+		}
+	}
+
+	err = saveTestReport(basicRes.GetDal(), reportId, testSuites, testCases, testCaseRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// saveTestReport replaces the results of the previous upload of the report within a transaction,
+// so a failure halfway leaves the previous results untouched
+func saveTestReport(
+	db dal.Dal,
+	reportId string,
+	testSuites []*devops.TestSuite,
+	testCases []*devops.TestCase,
+	testCaseRuns []*devops.TestCaseRun,
+) (err errors.Error) {
+	tx := db.Begin()
+	defer func() {
+		if r := recover(); r != nil || err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				basicRes.GetLogger().Error(rollbackErr, "failed to rollback the test report %s", reportId)
+			}
+		}
+	}()
+	// drop the results of the previous upload of the report
+	err = tx.Delete(&devops.TestCaseRun{}, dal.Where("test_suite_id LIKE ?", reportId+":%"))
+	if err != nil {
+		return err
+	}
+	err = tx.Delete(&devops.TestSuite{}, dal.Where("id LIKE ?", reportId+":%"))
+	if err != nil {
+		return err
+	}
+	if err = createOrUpdateInBatches(tx, testSuites); err != nil {
+		return err
+	}
+	if err = createOrUpdateInBatches(tx, testCases); err != nil {
+		return err
+	}
+	if err = createOrUpdateInBatches(tx, testCaseRuns); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func createOrUpdateInBatches[T any](db dal.Dal, entities []T) errors.Error {
+	for start := 0; start < len(entities); start += testReportBatchSize {
+		end := start + testReportBatchSize
+		if end > len(entities) {
+			end = len(entities)
+		}
+		if err := db.CreateOrUpdate(entities[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
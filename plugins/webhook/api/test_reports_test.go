@@ -0,0 +1,131 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/apache/incubator-devlake/plugins/webhook/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testJunitReport = `<testsuites>
+  <testsuite name="suite-a" time="1.5">
+    <testcase classname="a.A" name="passes" time="0.5"/>
+    <testcase classname="a.A" name="fails" time="1"><failure message="expected 1"/></testcase>
+  </testsuite>
+  <testsuite name="suite-b">
+    <testcase classname="a.A" name="passes" time="0.1"/>
+  </testsuite>
+</testsuites>`
+
+func mockTestReportRes(t *testing.T) (*mockdal.Dal, *mockdal.Transaction) {
+	mockDal := new(mockdal.Dal)
+	mockTx := new(mockdal.Transaction)
+	mockRes := new(mockcontext.BasicRes)
+	mockRes.On("GetDal").Return(mockDal)
+	mockRes.On("GetLogger").Return(unithelper.DummyLogger())
+	mockRes.On("GetConfig", mock.Anything).Return("")
+	mockDal.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.WebhookConnection).ID = 1
+	}).Return(nil)
+	mockDal.On("Begin").Return(mockTx)
+	basicRes = mockRes
+	connectionHelper = api.NewConnectionHelper(mockRes, nil, "webhook")
+	t.Cleanup(func() {
+		mockTx.AssertExpectations(t)
+	})
+	return mockDal, mockTx
+}
+
+func newTestReportInput(t *testing.T, report string) *plugin.ApiResourceInput {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	assert.Nil(t, writer.WriteField("pipeline_id", "webhook:1:100"))
+	part, err := writer.CreateFormFile("file", "junit.xml")
+	assert.Nil(t, err)
+	_, err = part.Write([]byte(report))
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	req, err := http.NewRequest(http.MethodPost, "/plugins/webhook/connections/1/test_reports", body)
+	assert.Nil(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return &plugin.ApiResourceInput{Params: map[string]string{"connectionId": "1"}, Request: req}
+}
+
+func TestPostTestReport(t *testing.T) {
+	_, mockTx := mockTestReportRes(t)
+	var suites []*devops.TestSuite
+	var cases []*devops.TestCase
+	var runs []*devops.TestCaseRun
+	mockTx.On("Delete", &devops.TestCaseRun{}, mock.Anything).Return(nil).Once()
+	mockTx.On("Delete", &devops.TestSuite{}, mock.Anything).Return(nil).Once()
+	mockTx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		switch entities := args.Get(0).(type) {
+		case []*devops.TestSuite:
+			suites = append(suites, entities...)
+		case []*devops.TestCase:
+			cases = append(cases, entities...)
+		case []*devops.TestCaseRun:
+			runs = append(runs, entities...)
+		}
+	}).Return(nil).Times(3)
+	mockTx.On("Commit").Return(nil).Once()
+
+	output, err := PostTestReport(newTestReportInput(t, testJunitReport))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, output.Status)
+	assert.Len(t, suites, 2)
+	assert.Equal(t, "webhook:1", suites[0].CicdScopeId)
+	assert.Equal(t, "webhook:1:100", suites[0].CicdPipelineId)
+	assert.Equal(t, 1, suites[0].FailedCount)
+	// the same test case in different suites is saved once
+	assert.Len(t, cases, 2)
+	assert.Len(t, runs, 3)
+	assert.Equal(t, suites[1].Id, runs[2].TestSuiteId)
+}
+
+func TestPostTestReportRollback(t *testing.T) {
+	_, mockTx := mockTestReportRes(t)
+	mockTx.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
+	mockTx.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockTx.On("CreateOrUpdate", mock.Anything, mock.Anything).Return(errors.Default.New("db is gone")).Once()
+	mockTx.On("Rollback").Return(nil).Once()
+
+	_, err := PostTestReport(newTestReportInput(t, testJunitReport))
+	assert.NotNil(t, err)
+	mockTx.AssertNotCalled(t, "Commit")
+}
+
+func TestPostTestReportInvalid(t *testing.T) {
+	mockDal, _ := mockTestReportRes(t)
+	_, err := PostTestReport(newTestReportInput(t, "not a report"))
+	assert.NotNil(t, err)
+	mockDal.AssertNotCalled(t, "Begin")
+}
@@ -85,6 +85,9 @@ func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 		":connectionId/deployments": {
 			"POST": api.PostDeploymentCicdTask,
 		},
+		":connectionId/test_reports": {
+			"POST": api.PostTestReport,
+		},
 		":connectionId/issues": {
 			"POST": api.PostIssue,
 		},
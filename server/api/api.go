@@ -34,6 +34,8 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/audit"
+	"github.com/apache/incubator-devlake/server/api/auth"
 	_ "github.com/apache/incubator-devlake/server/api/docs"
 	"github.com/apache/incubator-devlake/server/api/ping"
 	"github.com/apache/incubator-devlake/server/api/shared"
@@ -76,7 +78,7 @@ func CreateApiService() {
 	})
 
 	// Endpoint to proceed database migration
-	router.GET("/proceed-db-migration", func(ctx *gin.Context) {
+	router.GET("/proceed-db-migration", auth.Authenticate, func(ctx *gin.Context) {
 		// Check if migration requires confirmation
 		if !services.MigrationRequireConfirmation() {
 			// Return success response
@@ -123,7 +125,7 @@ func CreateApiService() {
 		// Allow common methods
 		AllowMethods: []string{"PUT", "PATCH", "POST", "GET", "OPTIONS"},
 		// Allow common headers
-		AllowHeaders: []string{"Origin", "Content-Type"},
+		AllowHeaders: []string{"Origin", "Content-Type", "Authorization"},
 		// Expose these headers
 		ExposeHeaders: []string{"Content-Length"},
 		// Allow credentials
@@ -132,6 +134,11 @@ func CreateApiService() {
 		MaxAge: 120 * time.Hour,
 	}))
 
+	// Verify the api key or JWT and the role of the caller if AUTH_ENABLED
+	router.Use(auth.Authenticate)
+	// Record all mutating calls
+	router.Use(audit.Record)
+
 	// Register API endpoints
 	RegisterRouter(router)
 	// Get port from config
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikeys
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/auth"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedApiKeys struct {
+	ApiKeys []*models.ApiKey `json:"apiKeys"`
+	Count   int64            `json:"count"`
+}
+
+// @Summary Create an api key
+// @Description Create an api key, the plain key is returned only once in the response
+// @Tags framework/api-keys
+// @Accept application/json
+// @Param apikey body models.ApiInputApiKey true "json"
+// @Success 201  {object} models.ApiOutputApiKey
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /api-keys [post]
+func Post(c *gin.Context) {
+	input := &models.ApiInputApiKey{}
+	err := c.ShouldBind(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	creator := ""
+	if identity := auth.GetIdentity(c); identity != nil {
+		creator = identity.Name
+	}
+	output, err := services.CreateApiKey(input, creator)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating api key"))
+		return
+	}
+	shared.ApiOutputSuccess(c, output, http.StatusCreated)
+}
+
+// @Summary Get list of api keys
+// @Description GET /api-keys?page=1&pageSize=10
+// @Tags framework/api-keys
+// @Param page query int false "page"
+// @Param pageSize query int false "pageSize"
+// @Success 200  {object} PaginatedApiKeys
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /api-keys [get]
+func Index(c *gin.Context) {
+	var query services.ApiKeyQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	apiKeys, count, err := services.GetApiKeys(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting api keys"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedApiKeys{ApiKeys: apiKeys, Count: count}, http.StatusOK)
+}
+
+// @Summary Revoke an api key
+// @Description Revoke an api key
+// @Tags framework/api-keys
+// @Param apiKeyId path int true "api key id"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /api-keys/{apiKeyId} [delete]
+func Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("apiKeyId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad apiKeyId format supplied"))
+		return
+	}
+	err = services.DeleteApiKey(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting api key"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
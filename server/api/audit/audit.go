@@ -0,0 +1,183 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/auth"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+var logger = logruslog.Global.Nested("audit")
+
+// replaced in tests
+var createAuditLog = services.CreateAuditLog
+
+// routes that are not configuration changes
+var skippedRoutes = []string{
+	"/push/",
+	"/proceed-db-migration",
+}
+
+// known entities, the last one appears in the route is the target of the call
+var entityTypes = map[string]bool{
+	"api-keys":      true,
+	"blueprints":    true,
+	"connections":   true,
+	"pipelines":     true,
+	"projects":      true,
+	"scope-configs": true,
+	"scopes":        true,
+	"tasks":         true,
+}
+
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *bodyRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// Record is the middleware recording every mutating call along with the changes it made into _devlake_audit_logs
+func Record(c *gin.Context) {
+	method := c.Request.Method
+	route := c.FullPath()
+	if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions || route == "" {
+		return
+	}
+	for _, skipped := range skippedRoutes {
+		if strings.HasPrefix(route, skipped) {
+			return
+		}
+	}
+	params := make(map[string]string, len(c.Params))
+	for _, param := range c.Params {
+		params[param.Key] = param.Value
+	}
+	var before interface{}
+	if method != http.MethodPost {
+		before = services.GetAuditSnapshot(route, params)
+	}
+
+	recorder := &bodyRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	c.Next()
+
+	status := recorder.Status()
+	var after interface{}
+	if status < http.StatusMultipleChoices && method != http.MethodDelete {
+		after = recorder.body.Bytes()
+	} else if status >= http.StatusMultipleChoices {
+		// nothing changed
+		before = nil
+	}
+	plugin, entityType, entityId := parseRoute(route, c.Params)
+	auditLog := &models.AuditLog{
+		Actor:      getActor(c),
+		Method:     method,
+		Route:      route,
+		Path:       c.Request.URL.Path,
+		Plugin:     plugin,
+		EntityType: entityType,
+		EntityId:   entityId,
+		StatusCode: status,
+	}
+	err := createAuditLog(auditLog, before, after)
+	if err != nil {
+		logger.Error(err, "failed to record audit log for %s %s", method, c.Request.URL.Path)
+	}
+}
+
+func getActor(c *gin.Context) string {
+	if identity := auth.GetIdentity(c); identity != nil {
+		return identity.Name
+	}
+	// set by the reverse proxy if any, anybody can send the header though, so it only names the actor
+	// when the request comes from one of AUTH_TRUSTED_PROXIES
+	user := c.GetHeader("X-Forwarded-User")
+	if user == "" || isTrustedProxy(c.Request.RemoteAddr) {
+		return user
+	}
+	return fmt.Sprintf("anonymous (X-Forwarded-User: %s)", user)
+}
+
+// isTrustedProxy tells whether the remote address is one of the ips or cidrs listed in AUTH_TRUSTED_PROXIES
+func isTrustedProxy(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range strings.Split(config.GetConfig().GetString("AUTH_TRUSTED_PROXIES"), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if _, cidr, err := net.ParseCIDR(proxy); err == nil {
+			if cidr.Contains(ip) {
+				return true
+			}
+		} else if trusted := net.ParseIP(proxy); trusted != nil && trusted.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRoute extracts the plugin, the type and the id of the target entity from the route, e.g.
+// /plugins/github/connections/:connectionId/scopes/:scopeId => github, scopes, 1/123
+func parseRoute(route string, params gin.Params) (string, string, string) {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	plugin := ""
+	if len(segments) > 1 && segments[0] == "plugins" {
+		plugin = segments[1]
+		segments = segments[2:]
+	}
+	entityType := ""
+	ids := make([]string, 0)
+	for _, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			ids = append(ids, strings.TrimPrefix(params.ByName(segment[1:]), "/"))
+			continue
+		}
+		if entityTypes[segment] {
+			entityType = segment
+		}
+	}
+	if entityType == "" && len(segments) > 0 {
+		entityType = segments[len(segments)-1]
+	}
+	return plugin, entityType, strings.Join(ids, "/")
+}
@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRoute(t *testing.T) {
+	plugin, entityType, entityId := parseRoute(
+		"/plugins/github/connections/:connectionId/scopes/:scopeId",
+		gin.Params{{Key: "connectionId", Value: "1"}, {Key: "scopeId", Value: "123"}},
+	)
+	assert.Equal(t, "github", plugin)
+	assert.Equal(t, "scopes", entityType)
+	assert.Equal(t, "1/123", entityId)
+
+	plugin, entityType, entityId = parseRoute(
+		"/blueprints/:blueprintId/trigger",
+		gin.Params{{Key: "blueprintId", Value: "7"}},
+	)
+	assert.Equal(t, "", plugin)
+	assert.Equal(t, "blueprints", entityType)
+	assert.Equal(t, "7", entityId)
+
+	_, entityType, entityId = parseRoute(
+		"/projects/*projectName",
+		gin.Params{{Key: "projectName", Value: "/team/a"}},
+	)
+	assert.Equal(t, "projects", entityType)
+	assert.Equal(t, "team/a", entityId)
+
+	plugin, entityType, entityId = parseRoute("/plugins/jira/test", nil)
+	assert.Equal(t, "jira", plugin)
+	assert.Equal(t, "test", entityType)
+	assert.Equal(t, "", entityId)
+}
+
+func TestGetActor(t *testing.T) {
+	cfg := config.GetConfig()
+	trustedProxies := cfg.GetString("AUTH_TRUSTED_PROXIES")
+	cfg.Set("AUTH_TRUSTED_PROXIES", "10.0.0.0/8, 192.168.1.1")
+	defer cfg.Set("AUTH_TRUSTED_PROXIES", trustedProxies)
+
+	gin.SetMode(gin.TestMode)
+	for _, tc := range []struct {
+		remoteAddr string
+		user       string
+		actor      string
+	}{
+		{"10.1.2.3:4567", "alice", "alice"},
+		{"192.168.1.1:4567", "alice", "alice"},
+		{"192.168.1.2:4567", "alice", "anonymous (X-Forwarded-User: alice)"},
+		{"[::1]:4567", "alice", "anonymous (X-Forwarded-User: alice)"},
+		{"192.168.1.2:4567", "", ""},
+	} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/blueprints", nil)
+		c.Request.RemoteAddr = tc.remoteAddr
+		if tc.user != "" {
+			c.Request.Header.Set("X-Forwarded-User", tc.user)
+		}
+		assert.Equal(t, tc.actor, getActor(c), tc.remoteAddr)
+	}
+}
+
+func TestRecordRedactsCreatedApiKey(t *testing.T) {
+	var diffs []string
+	createAuditLog = func(auditLog *models.AuditLog, before, after interface{}) errors.Error {
+		diff, err := services.MakeAuditDiff(before, after)
+		diffs = append(diffs, diff)
+		return err
+	}
+	defer func() { createAuditLog = services.CreateAuditLog }()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Record)
+	router.POST("/api-keys", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, &models.ApiOutputApiKey{
+			ApiKey: models.ApiKey{Name: "ci", Role: models.ROLE_ADMIN, Prefix: "dlk_abcd"},
+			Key:    "dlk_abcdefghijklmnopqrstuvwxyz",
+		})
+	})
+	router.POST("/api-keys/raw", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+		_, _ = c.Writer.WriteString(`{"name": "raw", "apiKey": "dlk_zyxwvutsrqponmlkjihgfedcba"}`)
+	})
+
+	for _, path := range []string{"/api-keys", "/api-keys/raw"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`)))
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), "dlk_")
+	}
+	assert.Len(t, diffs, 2)
+	// bodies written by both Write and WriteString are recorded, without the plain key
+	assert.Contains(t, diffs[0], `"ci"`)
+	assert.Contains(t, diffs[0], `"dlk_abcd"`)
+	assert.Contains(t, diffs[1], `"raw"`)
+	for _, diff := range diffs {
+		assert.NotContains(t, diff, "dlk_abcdefghijklmnopqrstuvwxyz")
+		assert.NotContains(t, diff, "dlk_zyxwvutsrqponmlkjihgfedcba")
+	}
+}
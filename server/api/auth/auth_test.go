@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredRole(t *testing.T) {
+	cases := []struct {
+		method string
+		route  string
+		role   string
+	}{
+		{http.MethodGet, "/pipelines", models.ROLE_VIEWER},
+		{http.MethodPost, "/pipelines", models.ROLE_OPERATOR},
+		{http.MethodGet, "/pipelines/:pipelineId/logging.tar.gz", models.ROLE_OPERATOR},
+		{http.MethodPatch, "/blueprints/:blueprintId", models.ROLE_OPERATOR},
+		{http.MethodPatch, "/projects/*projectName", models.ROLE_OPERATOR},
+		{http.MethodDelete, "/projects/*projectName", models.ROLE_ADMIN},
+		{http.MethodPost, "/push/:tableName", models.ROLE_ADMIN},
+		{http.MethodGet, "/api-keys", models.ROLE_ADMIN},
+		{http.MethodGet, "/audit-logs", models.ROLE_ADMIN},
+		{http.MethodGet, "/retention", models.ROLE_VIEWER},
+		{http.MethodPost, "/retention/run", models.ROLE_ADMIN},
+		{http.MethodGet, "/notification-channels", models.ROLE_ADMIN},
+		{http.MethodPost, "/notification-channels/1/test", models.ROLE_ADMIN},
+		{http.MethodPost, "/plugins/github/test", models.ROLE_ADMIN},
+		{http.MethodGet, "/plugins/github/connections", models.ROLE_VIEWER},
+		{http.MethodPost, "/plugins/github/connections", models.ROLE_ADMIN},
+		{http.MethodPatch, "/plugins/github/connections/:connectionId", models.ROLE_ADMIN},
+		{http.MethodGet, "/plugins/github/connections/:connectionId/proxy/rest/*path", models.ROLE_ADMIN},
+		{http.MethodPut, "/plugins/github/connections/:connectionId/scopes", models.ROLE_OPERATOR},
+		{http.MethodGet, "/plugins/github/connections/:connectionId/scope-configs", models.ROLE_VIEWER},
+		{http.MethodGet, "/plugins", models.ROLE_VIEWER},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.role, RequiredRole(c.method, c.route), "%s %s", c.method, c.route)
+	}
+}
@@ -48,6 +48,11 @@ func Post(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad JSON request body format"))
 		return
 	}
+	// pipelines created by users jump ahead of the cron ones unless told otherwise
+	if newPipeline.Priority == nil {
+		priority := models.PIPELINE_PRIORITY_MANUAL
+		newPipeline.Priority = &priority
+	}
 
 	pipeline, err := services.CreatePipeline(newPipeline)
 	// Return all created tasks to the User
@@ -86,6 +91,23 @@ func Index(c *gin.Context) {
 	shared.ApiOutputSuccess(c, shared.ResponsePipelines{Pipelines: pipelines, Count: count}, http.StatusOK)
 }
 
+// @Summary Get the queue of pending pipelines
+// @Description GET /pipelines/queue
+// @Description Pending pipelines are listed in the order they are going to be started,
+// @Description blockedBy tells why a pipeline can not be started right now
+// @Tags framework/pipelines
+// @Success 200  {object} []services.QueuedPipeline
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /pipelines/queue [get]
+func GetQueue(c *gin.Context) {
+	queue, err := services.GetPipelineQueue()
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipeline queue"))
+		return
+	}
+	shared.ApiOutputSuccess(c, queue, http.StatusOK)
+}
+
 // @Summary Get detail of a pipeline
 // @Description GET /pipelines/:pipelineId
 // @Description RETURN SAMPLE
@@ -170,9 +192,11 @@ func DownloadLogs(c *gin.Context) {
 
 // RerunPipeline rerun all failed tasks of the specified pipeline
 // @Summary rerun tasks
+// @Description mode=resume starts each rerun task from its failed subtask instead of the first one
 // @Tags framework/pipelines
 // @Accept application/json
 // @Param pipelineId path int true "pipelineId"
+// @Param mode query string false "full or resume, defaults to full"
 // @Success 200  {object} []models.Task
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
@@ -184,7 +208,7 @@ func PostRerun(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
 		return
 	}
-	rerunTasks, err := services.RerunPipeline(id, nil)
+	rerunTasks, err := services.RerunPipeline(id, nil, c.Query("mode"))
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "failed to rerun pipeline"))
 		return
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// getProjectMetrics maps the metric in the path to the service computing it, all metrics are returned by ""
+var getProjectMetrics = map[string]func(string, *services.ProjectMetricQuery) (interface{}, errors.Error){
+	"": func(name string, query *services.ProjectMetricQuery) (interface{}, errors.Error) {
+		return services.GetProjectMetrics(name, query)
+	},
+	"dora": func(name string, query *services.ProjectMetricQuery) (interface{}, errors.Error) {
+		return services.GetProjectDoraMetrics(name, query)
+	},
+	"pull-requests": func(name string, query *services.ProjectMetricQuery) (interface{}, errors.Error) {
+		return services.GetProjectPrMetrics(name, query)
+	},
+	"issues": func(name string, query *services.ProjectMetricQuery) (interface{}, errors.Error) {
+		return services.GetProjectIssueMetrics(name, query)
+	},
+}
+
+// parseMetricsPath splits `<projectName>/metrics[/<metric>]` into the project name and the metric
+func parseMetricsPath(path string) (string, string, bool) {
+	for metric := range getProjectMetrics {
+		if metric == "" {
+			continue
+		}
+		if suffix := "/metrics/" + metric; strings.HasSuffix(path, suffix) {
+			return strings.TrimSuffix(path, suffix), metric, true
+		}
+	}
+	if strings.HasSuffix(path, "/metrics") {
+		return strings.TrimSuffix(path, "/metrics"), "", true
+	}
+	return "", "", false
+}
+
+// @Summary Get metrics of a project
+// @Description GET /projects/:projectName/metrics[/:metric]?startDate=2023-01-01&endDate=2023-06-30&groupBy=MONTH
+// @Description metric could be `dora`, `pull-requests` or `issues`, groupBy could be WEEK or MONTH
+// @Description without a metric, dora, pr cycle time and issue lead time metrics of the project are returned at once,
+// @Description `dora` returns the weekly or monthly (default) dora metrics calculated by the dora plugin
+// @Tags framework/projects
+// @Param projectName path string true "project name"
+// @Param startDate query string false "start date, format: 2006-01-02"
+// @Param endDate query string false "end date, format: 2006-01-02"
+// @Param groupBy query string false "WEEK or MONTH"
+// @Success 200  {object} services.ProjectMetrics
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /projects/:projectName/metrics [get]
+// @Router /projects/:projectName/metrics/dora [get]
+// @Router /projects/:projectName/metrics/pull-requests [get]
+// @Router /projects/:projectName/metrics/issues [get]
+func GetProjectMetrics(c *gin.Context) {
+	projectName, metric, ok := parseMetricsPath(c.Param("projectName")[1:])
+	if !ok {
+		shared.ApiOutputError(c, errors.NotFound.New("unknown project metrics"))
+		return
+	}
+	var query services.ProjectMetricQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	query.GroupBy = strings.ToUpper(query.GroupBy)
+	output, e := getProjectMetrics[metric](projectName, &query)
+	if e != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(e, "error getting project metrics"))
+		return
+	}
+	shared.ApiOutputSuccess(c, output, http.StatusOK)
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProjectMetricsRoutes(t *testing.T) {
+	var gotProject, gotMetric string
+	var gotQuery *services.ProjectMetricQuery
+	originalMetrics, originalProject := getProjectMetrics, getProject
+	defer func() { getProjectMetrics, getProject = originalMetrics, originalProject }()
+	getProjectMetrics = map[string]func(string, *services.ProjectMetricQuery) (interface{}, errors.Error){}
+	for metric := range originalMetrics {
+		metric := metric
+		getProjectMetrics[metric] = func(name string, query *services.ProjectMetricQuery) (interface{}, errors.Error) {
+			gotProject, gotMetric, gotQuery = name, metric, query
+			return []string{}, nil
+		}
+	}
+	getProject = func(name string) (*models.ApiOutputProject, errors.Error) {
+		gotProject, gotMetric = name, ""
+		return &models.ApiOutputProject{}, nil
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/projects/*projectName", GetProject)
+	get := func(url string) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get("/projects/team/devlake"))
+	assert.Equal(t, "team/devlake", gotProject)
+	assert.Equal(t, "", gotMetric)
+
+	// project names could contain slashes
+	for _, metric := range []string{"dora", "pull-requests", "issues"} {
+		gotMetric = ""
+		assert.Equal(t, http.StatusOK, get("/projects/team/devlake/metrics/"+metric+"?groupBy=week"))
+		assert.Equal(t, "team/devlake", gotProject)
+		assert.Equal(t, metric, gotMetric)
+		assert.Equal(t, "WEEK", gotQuery.GroupBy)
+	}
+	gotMetric = "unset"
+	assert.Equal(t, http.StatusOK, get("/projects/team/devlake/metrics"))
+	assert.Equal(t, "team/devlake", gotProject)
+	assert.Equal(t, "", gotMetric)
+
+	gotMetric = "unset"
+	assert.Equal(t, http.StatusBadRequest, get("/projects/p/metrics/dora?startDate=yesterday"))
+	assert.Equal(t, "unset", gotMetric)
+}
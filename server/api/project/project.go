@@ -27,6 +27,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+var getProject = services.GetProject // replaced in tests
+
 type PaginatedProjects struct {
 	Projects []*models.Project `json:"projects"`
 	Count    int64             `json:"count"`
@@ -43,8 +45,14 @@ type PaginatedProjects struct {
 // @Router /projects/:projectName [get]
 func GetProject(c *gin.Context) {
 	projectName := c.Param("projectName")[1:]
+	// gin doesn't allow any route to coexist with the catch-all parameter, so
+	// the metrics endpoints have to be dispatched from here
+	if _, _, ok := parseMetricsPath(projectName); ok {
+		GetProjectMetrics(c)
+		return
+	}
 
-	projectOutput, err := services.GetProject(projectName)
+	projectOutput, err := getProject(projectName)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting project"))
 		return
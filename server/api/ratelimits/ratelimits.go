@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimits
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get rate limits of connections
+// @Description GET /rate-limits?plugin=github&connectionId=1
+// @Description Connections are listed once a task of this process has sent requests through them,
+// @Description remaining is reported by the server when it does, otherwise estimated from the configured budget
+// @Tags framework/rate-limits
+// @Param plugin query string false "plugin"
+// @Param connectionId query int false "connectionId"
+// @Success 200  {object} []api.ConnectionRateLimit
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Router /rate-limits [get]
+func Index(c *gin.Context) {
+	var query services.RateLimitQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	shared.ApiOutputSuccess(c, services.GetConnectionRateLimits(&query), http.StatusOK)
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetentionStatus is the configured retention policy along with the report of the last janitor run
+type RetentionStatus struct {
+	Policy     *services.RetentionPolicy `json:"policy"`
+	LastReport *services.RetentionReport `json:"lastReport"`
+}
+
+// RunQuery is the query of POST /retention/run
+type RunQuery struct {
+	DryRun bool `form:"dryRun"`
+}
+
+// @Summary Get the data retention policy
+// @Description GET /retention
+// @Description Returns the policy configured by the RETENTION_* variables and the report of the last janitor run
+// @Tags framework/retention
+// @Success 200  {object} RetentionStatus
+// @Router /retention [get]
+func Get(c *gin.Context) {
+	shared.ApiOutputSuccess(c, &RetentionStatus{
+		Policy:     services.GetRetentionPolicy(),
+		LastReport: services.GetLastRetentionReport(),
+	}, http.StatusOK)
+}
+
+// @Summary Run the retention janitor
+// @Description POST /retention/run?dryRun=true
+// @Description Enforces the retention policy now, with dryRun the reclaimable rows and bytes are reported without deleting anything
+// @Tags framework/retention
+// @Param dryRun query bool false "dryRun"
+// @Success 200  {object} services.RetentionReport
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 409  {object} shared.ApiBody "Conflict"
+// @Router /retention/run [post]
+func Run(c *gin.Context) {
+	var query RunQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	report, err := services.RunRetentionJanitor(query.DryRun)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, report, http.StatusOK)
+}
@@ -25,12 +25,18 @@ import (
 	"strings"
 
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/server/api/apikeys"
+	"github.com/apache/incubator-devlake/server/api/auditlogs"
 	"github.com/apache/incubator-devlake/server/api/blueprints"
 	"github.com/apache/incubator-devlake/server/api/domainlayer"
+	"github.com/apache/incubator-devlake/server/api/notification"
 	"github.com/apache/incubator-devlake/server/api/pipelines"
 	"github.com/apache/incubator-devlake/server/api/plugininfo"
 	"github.com/apache/incubator-devlake/server/api/project"
 	"github.com/apache/incubator-devlake/server/api/push"
+	"github.com/apache/incubator-devlake/server/api/ratelimits"
+	"github.com/apache/incubator-devlake/server/api/retention"
+	"github.com/apache/incubator-devlake/server/api/scopedeletions"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/api/task"
 	"github.com/apache/incubator-devlake/server/services"
@@ -41,6 +47,7 @@ import (
 func RegisterRouter(r *gin.Engine) {
 	r.GET("/pipelines", pipelines.Index)
 	r.POST("/pipelines", pipelines.Post)
+	r.GET("/pipelines/queue", pipelines.GetQueue)
 	r.GET("/pipelines/:pipelineId", pipelines.Get)
 	r.PATCH("/blueprints/:blueprintId", blueprints.Patch)
 	r.POST("/blueprints/:blueprintId/trigger", blueprints.Trigger)
@@ -62,6 +69,32 @@ func RegisterRouter(r *gin.Engine) {
 	r.POST("/push/:tableName", push.Post)
 	r.GET("/domainlayer/repos", domainlayer.ReposIndex)
 
+	// api key api
+	r.GET("/api-keys", apikeys.Index)
+	r.POST("/api-keys", apikeys.Post)
+	r.DELETE("/api-keys/:apiKeyId", apikeys.Delete)
+
+	// audit log api
+	r.GET("/audit-logs", auditlogs.Index)
+
+	// notification api
+	r.GET("/notification-channels", notification.Index)
+	r.POST("/notification-channels", notification.Post)
+	r.GET("/notification-channels/:channelId", notification.Get)
+	r.PATCH("/notification-channels/:channelId", notification.Patch)
+	r.DELETE("/notification-channels/:channelId", notification.Delete)
+	r.POST("/notification-channels/:channelId/test", notification.Test)
+	r.GET("/notifications", notification.IndexNotifications)
+
+	// rate limit api
+	r.GET("/rate-limits", ratelimits.Index)
+	// data retention api
+	r.GET("/retention", retention.Get)
+	r.POST("/retention/run", retention.Run)
+	// scope deletion api, deletions are started by DELETE /plugins/:plugin/connections/:connectionId/scopes/:scopeId
+	r.GET("/scope-deletions", scopedeletions.Index)
+	r.GET("/scope-deletions/:deletionId", scopedeletions.Get)
+
 	// plugin api
 	r.GET("/plugininfo", plugininfo.Get)
 	r.GET("/plugins", plugininfo.GetPluginMetas)
@@ -0,0 +1,87 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scopedeletions
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedScopeDeletions struct {
+	ScopeDeletions []*models.ScopeDeletion `json:"scopeDeletions"`
+	Count          int64                   `json:"count"`
+}
+
+// @Summary Get list of scope deletions
+// @Description GET /scope-deletions?plugin=github&connectionId=1&scopeId=123&status=TASK_RUNNING&page=1&pageSize=10
+// @Description Scope deletions are started by DELETE /plugins/:plugin/connections/:connectionId/scopes/:scopeId[?dry_run=true]
+// @Tags framework/scope-deletions
+// @Param plugin query string false "plugin"
+// @Param connectionId query int false "connectionId"
+// @Param scopeId query string false "scopeId"
+// @Param status query string false "status"
+// @Param page query int false "page"
+// @Param pageSize query int false "pageSize"
+// @Success 200  {object} PaginatedScopeDeletions
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /scope-deletions [get]
+func Index(c *gin.Context) {
+	var query services.ScopeDeletionQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	deletions, count, err := services.GetScopeDeletions(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting scope deletions"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedScopeDeletions{ScopeDeletions: deletions, Count: count}, http.StatusOK)
+}
+
+// @Summary Get a scope deletion
+// @Description GET /scope-deletions/:deletionId
+// @Description The row counts per table, and the progress while deleting
+// @Tags framework/scope-deletions
+// @Param deletionId path int true "deletionId"
+// @Success 200  {object} models.ScopeDeletion
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 404  {object} shared.ApiBody "Not Found"
+// @Router /scope-deletions/{deletionId} [get]
+func Get(c *gin.Context) {
+	deletionId, err := strconv.ParseUint(c.Param("deletionId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad deletionId format supplied"))
+		return
+	}
+	deletion, err := services.GetScopeDeletion(deletionId)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	shared.ApiOutputSuccess(c, deletion, http.StatusOK)
+}
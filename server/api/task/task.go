@@ -73,8 +73,11 @@ func GetTaskByPipeline(c *gin.Context) {
 
 // RerunTask rerun the specified task.
 // @Summary rerun task
+// @Description mode=resume starts the rerun from the failed subtask instead of the first one
 // @Tags framework/tasks
 // @Accept application/json
+// @Param taskId path int true "taskId"
+// @Param mode query string false "full or resume, defaults to full"
 // @Success 200  {object} models.Task
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
@@ -86,7 +89,7 @@ func PostRerun(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad taskId format supplied"))
 		return
 	}
-	task, err := services.RerunTask(id)
+	task, err := services.RerunTask(id, c.Query("mode"))
 	if err != nil {
 		shared.ApiOutputError(c, err)
 		return
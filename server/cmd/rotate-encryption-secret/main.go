@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/spf13/cobra"
+)
+
+const newSecretEnvStr = "NEW_ENCRYPTION_SECRET"
+
+// rotate-encryption-secret re-encrypts all `encdec` columns from ENCRYPTION_SECRET to a new secret.
+// The new secret is read from NEW_ENCRYPTION_SECRET, or the first line of stdin if it is not set, so that
+// it never shows up in the shell history or the process list.
+// The server must be stopped while rotating, and restarted with ENCRYPTION_SECRET set to the new secret afterward.
+func main() {
+	cmd := &cobra.Command{
+		Use:   "rotate-encryption-secret",
+		Short: "Re-encrypt all encrypted columns from ENCRYPTION_SECRET to a new secret",
+	}
+	dryRun := cmd.Flags().Bool("dry-run", false, "verify all values could be decrypted and report the changes without writing")
+	checkpoint := cmd.Flags().StringP("checkpoint", "c", "encryption-rotation.checkpoint.json", "progress file used to resume an interrupted rotation")
+	batchSize := cmd.Flags().IntP("batch-size", "b", 500, "number of rows updated within a transaction")
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		cfg := config.GetConfig()
+		options := &runner.EncryptionRotationOptions{
+			OldSecret:      cfg.GetString(plugin.EncodeKeyEnvStr),
+			NewSecret:      cfg.GetString(newSecretEnvStr),
+			DryRun:         *dryRun,
+			CheckpointPath: *checkpoint,
+			BatchSize:      *batchSize,
+		}
+		if options.NewSecret == "" {
+			options.NewSecret = readNewSecret()
+		}
+		basicRes := runner.CreateAppBasicRes()
+		err := runner.LoadPlugins(basicRes)
+		if err != nil {
+			panic(err)
+		}
+		report, err := runner.RotateEncryptionSecret(basicRes, options)
+		if report != nil {
+			output, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(output))
+		}
+		if err != nil {
+			basicRes.GetLogger().Error(err, "encryption secret rotation failed, rerun the command to resume")
+			os.Exit(1)
+		}
+		if options.DryRun {
+			fmt.Println("dry run finished, nothing was written")
+			return
+		}
+		_ = os.Remove(options.CheckpointPath)
+		fmt.Printf("rotation finished, set %s to the new secret and restart the server\n", plugin.EncodeKeyEnvStr)
+	}
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// readNewSecret reads the new secret from the first line of stdin
+func readNewSecret() string {
+	fmt.Fprintf(os.Stderr, "%s is not set, enter the new encryption secret: ", newSecretEnvStr)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return ""
+	}
+	return strings.TrimRight(line, "\r\n")
+}
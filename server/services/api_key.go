@@ -0,0 +1,190 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/dgrijalva/jwt-go"
+)
+
+const apiKeyPrefix = "dlk_"
+
+// apiKeysTableExists caches whether the migration creating _devlake_api_keys has run
+var apiKeysTableExists atomic.Bool
+
+// ApiKeyQuery used to query api keys as the api input
+type ApiKeyQuery struct {
+	Pagination
+}
+
+// Identity is the authenticated caller of the REST api
+type Identity struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// HasRole tells whether the identity grants permissions of `role`
+func (i *Identity) HasRole(role string) bool {
+	return i != nil && models.RoleLevels[i.Role] >= models.RoleLevels[role]
+}
+
+// JwtClaims is the payload of JWT tokens accepted by the REST api, the tokens must be signed with
+// AUTH_JWT_SECRET using HS256 and carry the role of the subject
+type JwtClaims struct {
+	jwt.StandardClaims
+	Role string `json:"role"`
+}
+
+// CreateApiKey generates a new api key, only the hash of the key is stored
+func CreateApiKey(input *models.ApiInputApiKey, creator string) (*models.ApiOutputApiKey, errors.Error) {
+	if err := VerifyStruct(input); err != nil {
+		return nil, err
+	}
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.Internal.Wrap(err, "error generating api key")
+	}
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+	apiKey := &models.ApiKey{
+		Name:      input.Name,
+		Role:      input.Role,
+		Prefix:    key[:len(apiKeyPrefix)+6],
+		KeyHash:   hashApiKey(key),
+		Creator:   creator,
+		ExpiredAt: input.ExpiredAt,
+	}
+	err := db.Create(apiKey)
+	if err != nil {
+		if db.IsDuplicationError(err) {
+			return nil, errors.BadInput.New(fmt.Sprintf("An api key with name [%s] already exists", input.Name))
+		}
+		return nil, errors.Default.Wrap(err, "error creating DB api key")
+	}
+	return &models.ApiOutputApiKey{
+		ApiKey: *apiKey,
+		Key:    key,
+	}, nil
+}
+
+// GetApiKeys returns a paginated list of api keys
+func GetApiKeys(query *ApiKeyQuery) ([]*models.ApiKey, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.ApiKey{}),
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of api keys")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	apiKeys := make([]*models.ApiKey, 0)
+	err = db.All(&apiKeys, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB api keys")
+	}
+	return apiKeys, count, nil
+}
+
+// DeleteApiKey revokes the api key
+func DeleteApiKey(id uint64) errors.Error {
+	apiKey := &models.ApiKey{}
+	err := db.First(apiKey, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return errors.NotFound.New("api key not found")
+		}
+		return errors.Default.Wrap(err, "error getting DB api key")
+	}
+	return db.Delete(apiKey)
+}
+
+// Authenticate resolves the identity of a bearer token, which could be either an api key or a JWT
+func Authenticate(token string) (*Identity, errors.Error) {
+	if token == "" {
+		return nil, errors.Unauthorized.New("authorization token is missing")
+	}
+	if adminKey := cfg.GetString("AUTH_ADMIN_API_KEY"); adminKey != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminKey)) == 1 {
+		return &Identity{Name: "admin", Role: models.ROLE_ADMIN}, nil
+	}
+	if strings.HasPrefix(token, apiKeyPrefix) {
+		return authenticateApiKey(token)
+	}
+	return authenticateJwt(token)
+}
+
+func authenticateApiKey(key string) (*Identity, errors.Error) {
+	// the table doesn't exist until the pending migrations are proceeded, which /proceed-db-migration is called for
+	if !apiKeysTableExists.Load() {
+		if !db.HasTable(&models.ApiKey{}) {
+			return nil, errors.Unauthorized.New("api keys are not available before the database migration, use AUTH_ADMIN_API_KEY or a JWT instead")
+		}
+		apiKeysTableExists.Store(true)
+	}
+	apiKey := &models.ApiKey{}
+	err := db.First(apiKey, dal.Where("key_hash = ?", hashApiKey(key)))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.Unauthorized.New("invalid api key")
+		}
+		return nil, errors.Default.Wrap(err, "error getting DB api key")
+	}
+	if apiKey.ExpiredAt != nil && apiKey.ExpiredAt.Before(time.Now()) {
+		return nil, errors.Unauthorized.New("api key expired")
+	}
+	return &Identity{Name: apiKey.Name, Role: apiKey.Role}, nil
+}
+
+func authenticateJwt(token string) (*Identity, errors.Error) {
+	secret := cfg.GetString("AUTH_JWT_SECRET")
+	if secret == "" {
+		return nil, errors.Unauthorized.New("invalid api key")
+	}
+	claims := &JwtClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, errors.Unauthorized.Wrap(err, "invalid token")
+	}
+	if _, ok := models.RoleLevels[claims.Role]; !ok {
+		return nil, errors.Unauthorized.New(fmt.Sprintf("invalid role [%s] in token", claims.Role))
+	}
+	return &Identity{Name: claims.Subject, Role: claims.Role}, nil
+}
+
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
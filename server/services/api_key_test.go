@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testJwtSecret = "jwt-secret"
+
+func setupAuthTest(t *testing.T) *mockdal.Dal {
+	v := viper.New()
+	v.Set("AUTH_ADMIN_API_KEY", "admin-key")
+	v.Set("AUTH_JWT_SECRET", testJwtSecret)
+	cfg = v
+	mockDal := new(mockdal.Dal)
+	db = mockDal
+	apiKeysTableExists.Store(false)
+	t.Cleanup(func() {
+		cfg, db = nil, nil
+		apiKeysTableExists.Store(false)
+	})
+	return mockDal
+}
+
+func signJwt(t *testing.T, method jwt.SigningMethod, key interface{}, claims *JwtClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	assert.Nil(t, err)
+	return token
+}
+
+func TestAuthenticateAdminKey(t *testing.T) {
+	setupAuthTest(t)
+	identity, err := Authenticate("admin-key")
+	assert.Nil(t, err)
+	assert.Equal(t, &Identity{Name: "admin", Role: models.ROLE_ADMIN}, identity)
+
+	_, err = Authenticate("")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+	// neither a prefix nor an extension of the admin key is accepted
+	_, err = Authenticate("admin-ke")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+	_, err = Authenticate("admin-key0")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+}
+
+func TestAuthenticateJwt(t *testing.T) {
+	setupAuthTest(t)
+	claims := func(role string, expiresAt time.Time) *JwtClaims {
+		return &JwtClaims{
+			StandardClaims: jwt.StandardClaims{Subject: "alice", ExpiresAt: expiresAt.Unix()},
+			Role:           role,
+		}
+	}
+	later := time.Now().Add(time.Hour)
+
+	identity, err := Authenticate(signJwt(t, jwt.SigningMethodHS256, []byte(testJwtSecret), claims(models.ROLE_OPERATOR, later)))
+	assert.Nil(t, err)
+	assert.Equal(t, &Identity{Name: "alice", Role: models.ROLE_OPERATOR}, identity)
+
+	invalidTokens := map[string]string{
+		"expired":        signJwt(t, jwt.SigningMethodHS256, []byte(testJwtSecret), claims(models.ROLE_OPERATOR, time.Now().Add(-time.Hour))),
+		"wrong secret":   signJwt(t, jwt.SigningMethodHS256, []byte("other-secret"), claims(models.ROLE_ADMIN, later)),
+		"unsigned":       signJwt(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(models.ROLE_ADMIN, later)),
+		"unknown role":   signJwt(t, jwt.SigningMethodHS256, []byte(testJwtSecret), claims("ROOT", later)),
+		"not even a jwt": "not-a-token",
+	}
+	for name, token := range invalidTokens {
+		_, err = Authenticate(token)
+		if assert.NotNil(t, err, name) {
+			assert.Equal(t, errors.Unauthorized, err.GetType(), name)
+		}
+	}
+
+	// JWTs are rejected when no secret is configured
+	cfg.(*viper.Viper).Set("AUTH_JWT_SECRET", "")
+	_, err = Authenticate(signJwt(t, jwt.SigningMethodHS256, []byte(""), claims(models.ROLE_ADMIN, later)))
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+}
+
+func TestAuthenticateApiKey(t *testing.T) {
+	mockDal := setupAuthTest(t)
+	key := apiKeyPrefix + "0123456789abcdef"
+	expiredKey := apiKeyPrefix + "fedcba9876543210"
+	past := time.Now().Add(-time.Hour)
+	notFound := errors.NotFound.New("record not found")
+	mockDal.On("HasTable", mock.Anything).Return(true).Once()
+	// keys are looked up by their hash, the plain keys are never stored
+	byHash := []dal.Clause{dal.Where("key_hash = ?", hashApiKey(key))}
+	mockDal.On("First", mock.AnythingOfType("*models.ApiKey"), byHash).Return(nil).Run(func(args mock.Arguments) {
+		apiKey := args.Get(0).(*models.ApiKey)
+		apiKey.Name, apiKey.Role = "ci", models.ROLE_OPERATOR
+	}).Once()
+	mockDal.On("First", mock.AnythingOfType("*models.ApiKey"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		apiKey := args.Get(0).(*models.ApiKey)
+		apiKey.Name, apiKey.Role, apiKey.ExpiredAt = "old", models.ROLE_ADMIN, &past
+	}).Once()
+	mockDal.On("First", mock.AnythingOfType("*models.ApiKey"), mock.Anything).Return(notFound).Once()
+	mockDal.On("IsErrorNotFound", notFound).Return(true)
+
+	identity, err := Authenticate(key)
+	assert.Nil(t, err)
+	assert.Equal(t, &Identity{Name: "ci", Role: models.ROLE_OPERATOR}, identity)
+	_, err = Authenticate(expiredKey)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+	_, err = Authenticate(apiKeyPrefix + "unknown")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+	// the existence of the table is only checked until it is found
+	mockDal.AssertNumberOfCalls(t, "HasTable", 1)
+}
+
+func TestAuthenticateApiKeyBeforeMigration(t *testing.T) {
+	mockDal := setupAuthTest(t)
+	mockDal.On("HasTable", mock.Anything).Return(false)
+
+	_, err := Authenticate(apiKeyPrefix + "0123456789abcdef")
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+	mockDal.AssertNotCalled(t, "First", mock.Anything, mock.Anything)
+	// the admin key keeps working, e.g. to call /proceed-db-migration
+	identity, err := Authenticate("admin-key")
+	assert.Nil(t, err)
+	assert.Equal(t, models.ROLE_ADMIN, identity.Role)
+}
@@ -0,0 +1,271 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const redactedValue = "******"
+
+// `key` alone is the plain api key returned by POST /api-keys, other fields merely containing it (e.g. issueKey) are kept
+var sensitiveFieldPattern = regexp.MustCompile(`(?i)(token|password|secret|passphrase|private_?key|app_?key|api_?key|^key$|credential|authorization)`)
+
+// AuditLogQuery used to filter audit logs as the api input
+type AuditLogQuery struct {
+	Pagination
+	Actor      string     `form:"actor"`
+	Method     string     `form:"method"`
+	Plugin     string     `form:"plugin"`
+	EntityType string     `form:"entityType"`
+	EntityId   string     `form:"entityId"`
+	StartTime  *time.Time `form:"startTime" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime    *time.Time `form:"endTime" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// GetAuditLogs returns a paginated list of audit logs based on `query`, newest first
+func GetAuditLogs(query *AuditLogQuery) ([]*models.AuditLog, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.AuditLog{}),
+	}
+	if query.Actor != "" {
+		clauses = append(clauses, dal.Where("actor = ?", query.Actor))
+	}
+	if query.Method != "" {
+		clauses = append(clauses, dal.Where("method = ?", strings.ToUpper(query.Method)))
+	}
+	if query.Plugin != "" {
+		clauses = append(clauses, dal.Where("plugin = ?", query.Plugin))
+	}
+	if query.EntityType != "" {
+		clauses = append(clauses, dal.Where("entity_type = ?", query.EntityType))
+	}
+	if query.EntityId != "" {
+		clauses = append(clauses, dal.Where("entity_id = ?", query.EntityId))
+	}
+	if query.StartTime != nil {
+		clauses = append(clauses, dal.Where("created_at >= ?", *query.StartTime))
+	}
+	if query.EndTime != nil {
+		clauses = append(clauses, dal.Where("created_at < ?", *query.EndTime))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of audit logs")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	auditLogs := make([]*models.AuditLog, 0)
+	err = db.All(&auditLogs, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB audit logs")
+	}
+	return auditLogs, count, nil
+}
+
+// GetAuditSnapshot returns the current state of the entity the route points to, it is taken before
+// the entity gets modified so the change could be recorded. nil is returned if the route doesn't point
+// to an existing entity.
+func GetAuditSnapshot(route string, params map[string]string) interface{} {
+	var snapshot interface{}
+	var err errors.Error
+	switch route {
+	case "/blueprints/:blueprintId":
+		id, e := strconv.ParseUint(params["blueprintId"], 10, 64)
+		if e != nil {
+			return nil
+		}
+		snapshot, err = GetBlueprint(id)
+	case "/pipelines/:pipelineId":
+		id, e := strconv.ParseUint(params["pipelineId"], 10, 64)
+		if e != nil {
+			return nil
+		}
+		snapshot, err = GetPipeline(id)
+	case "/projects/*projectName":
+		snapshot, err = GetProject(strings.TrimPrefix(params["projectName"], "/"))
+	default:
+		snapshot, err = getPluginResourceSnapshot(route, params)
+	}
+	if err != nil {
+		return nil
+	}
+	return snapshot
+}
+
+// getPluginResourceSnapshot calls the GET handler registered on the same resource path of the plugin,
+// e.g. GET connections/:connectionId for PATCH connections/:connectionId
+func getPluginResourceSnapshot(route string, params map[string]string) (interface{}, errors.Error) {
+	parts := strings.SplitN(route, "/", 4)
+	if len(parts) < 4 || parts[1] != "plugins" {
+		return nil, nil
+	}
+	pluginName, resourcePath := parts[2], parts[3]
+	pluginMeta, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	pluginApi, ok := pluginMeta.(plugin.PluginApi)
+	if !ok {
+		return nil, nil
+	}
+	handler, ok := pluginApi.ApiResources()[resourcePath]["GET"]
+	if !ok {
+		return nil, nil
+	}
+	input := &plugin.ApiResourceInput{Params: map[string]string{"plugin": pluginName}}
+	for key, value := range params {
+		input.Params[key] = value
+	}
+	output, err := handler(input)
+	if err != nil || output == nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+// CreateAuditLog saves the audit log along with the redacted diff between `before` and `after`
+func CreateAuditLog(auditLog *models.AuditLog, before, after interface{}) errors.Error {
+	diff, err := MakeAuditDiff(before, after)
+	if err != nil {
+		return err
+	}
+	if auditLog.EntityId == "" {
+		auditLog.EntityId = guessEntityId(after)
+	}
+	auditLog.Diff = diff
+	return db.Create(auditLog)
+}
+
+// MakeAuditDiff returns the changed top-level fields between `before` and `after` as a json object,
+// the values of sensitive fields are redacted
+func MakeAuditDiff(before, after interface{}) (string, errors.Error) {
+	beforeMap, err := toJsonMap(before)
+	if err != nil {
+		return "", err
+	}
+	afterMap, err := toJsonMap(after)
+	if err != nil {
+		return "", err
+	}
+	diff := make(map[string]map[string]interface{})
+	for key, beforeValue := range beforeMap {
+		afterValue, ok := afterMap[key]
+		if !ok || !reflect.DeepEqual(beforeValue, afterValue) {
+			diff[key] = map[string]interface{}{
+				"before": redact(key, beforeValue),
+				"after":  redact(key, afterValue),
+			}
+		}
+	}
+	for key, afterValue := range afterMap {
+		if _, ok := beforeMap[key]; !ok {
+			diff[key] = map[string]interface{}{
+				"before": nil,
+				"after":  redact(key, afterValue),
+			}
+		}
+	}
+	if len(diff) == 0 {
+		return "", nil
+	}
+	blob, e := json.Marshal(diff)
+	if e != nil {
+		return "", errors.Convert(e)
+	}
+	return string(blob), nil
+}
+
+func toJsonMap(v interface{}) (map[string]interface{}, errors.Error) {
+	result := make(map[string]interface{})
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return result, nil
+	}
+	blob, ok := v.([]byte)
+	if !ok {
+		var err error
+		blob, err = json.Marshal(v)
+		if err != nil {
+			return nil, errors.Convert(err)
+		}
+	}
+	if len(blob) == 0 || json.Unmarshal(blob, &result) != nil {
+		// not a json object, e.g. an array of scopes
+		var value interface{}
+		if json.Unmarshal(blob, &value) == nil && value != nil {
+			result["value"] = value
+		}
+	}
+	return result, nil
+}
+
+func redact(key string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if sensitiveFieldPattern.MatchString(key) {
+		if s, ok := value.(string); ok && s == "" {
+			return s
+		}
+		return redactedValue
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			redacted[k] = redact(k, item)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redact("", item)
+		}
+		return redacted
+	}
+	return value
+}
+
+func guessEntityId(after interface{}) string {
+	afterMap, err := toJsonMap(after)
+	if err != nil {
+		return ""
+	}
+	for _, key := range []string{"id", "name"} {
+		switch v := afterMap[key].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return ""
+}
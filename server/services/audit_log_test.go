@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeAuditDiff(t *testing.T) {
+	before := map[string]interface{}{
+		"name":       "bp",
+		"cronConfig": "0 0 * * *",
+		"enable":     true,
+		"token":      "old-token",
+	}
+	after := []byte(`{"name": "bp", "cronConfig": "0 1 * * *", "enable": true, "token": "new-token", "labels": ["a"]}`)
+	diff, err := MakeAuditDiff(before, after)
+	assert.Nil(t, err)
+	var result map[string]map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(diff), &result))
+	assert.Len(t, result, 3)
+	assert.Equal(t, "0 0 * * *", result["cronConfig"]["before"])
+	assert.Equal(t, "0 1 * * *", result["cronConfig"]["after"])
+	assert.Equal(t, redactedValue, result["token"]["before"])
+	assert.Equal(t, redactedValue, result["token"]["after"])
+	assert.Nil(t, result["labels"]["before"])
+	assert.Equal(t, []interface{}{"a"}, result["labels"]["after"])
+
+	// nested secrets are redacted as well
+	diff, err = MakeAuditDiff(nil, map[string]interface{}{
+		"connection": map[string]interface{}{"endpoint": "https://api.github.com", "password": "p"},
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, diff, "https://api.github.com")
+	assert.NotContains(t, diff, `"p"`)
+
+	// the plain api key is redacted while fields merely containing `key` are kept
+	diff, err = MakeAuditDiff(nil, []byte(`{"name": "ci", "key": "dlk_secret", "issueKey": "DL-1"}`))
+	assert.Nil(t, err)
+	assert.NotContains(t, diff, "dlk_secret")
+	assert.Contains(t, diff, "DL-1")
+
+	diff, err = MakeAuditDiff(before, before)
+	assert.Nil(t, err)
+	assert.Equal(t, "", diff)
+}
+
+func TestGuessEntityId(t *testing.T) {
+	assert.Equal(t, "12", guessEntityId([]byte(`{"id": 12, "name": "x"}`)))
+	assert.Equal(t, "x", guessEntityId([]byte(`{"name": "x"}`)))
+	assert.Equal(t, "", guessEntityId(nil))
+}
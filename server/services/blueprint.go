@@ -52,7 +52,7 @@ type BlueprintJob struct {
 
 func (bj BlueprintJob) Run() {
 	blueprint := bj.Blueprint
-	pipeline, err := createPipelineByBlueprint(blueprint, false)
+	pipeline, err := createPipelineByBlueprint(blueprint, false, models.PIPELINE_PRIORITY_CRON)
 	if err == ErrEmptyPlan {
 		blueprintLog.Info("Empty plan, blueprint id:[%d] blueprint name:[%s]", blueprint.ID, blueprint.Name)
 		return
@@ -277,7 +277,7 @@ func ReloadBlueprints(c *cron.Cron) errors.Error {
 	return nil
 }
 
-func createPipelineByBlueprint(blueprint *models.Blueprint, skipCollectors bool) (*models.Pipeline, errors.Error) {
+func createPipelineByBlueprint(blueprint *models.Blueprint, skipCollectors bool, priority int) (*models.Pipeline, errors.Error) {
 	var plan plugin.PipelinePlan
 	var err errors.Error
 	if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
@@ -295,6 +295,7 @@ func createPipelineByBlueprint(blueprint *models.Blueprint, skipCollectors bool)
 	newPipeline.BlueprintId = blueprint.ID
 	newPipeline.Labels = blueprint.Labels
 	newPipeline.SkipOnFail = blueprint.SkipOnFail
+	newPipeline.Priority = &priority
 
 	// if the plan is empty, we should not create the pipeline
 	var shouldCreatePipeline bool
@@ -416,7 +417,7 @@ func TriggerBlueprint(id uint64, skipCollectors bool) (*models.Pipeline, errors.
 	if err != nil {
 		return nil, err
 	}
-	pipeline, err := createPipelineByBlueprint(blueprint, skipCollectors)
+	pipeline, err := createPipelineByBlueprint(blueprint, skipCollectors, models.PIPELINE_PRIORITY_MANUAL)
 	// done
 	return pipeline, err
 }
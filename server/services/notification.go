@@ -18,50 +18,271 @@ limitations under the License.
 package services
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/utils"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/services/notification"
 )
 
-// NotificationService FIXME ...
+const (
+	notificationRetryInterval = 30 * time.Second
+	notificationRetryBackoff  = 30 * time.Second
+	sanitizedSecret           = "********"
+)
+
+var notificationLog = logruslog.Global.Nested("notification")
+
+// NotificationService sends events to the NOTIFICATION_ENDPOINT and all subscribed notification channels,
+// failed deliveries would be retried with exponential backoff
 type NotificationService struct {
 	EndPoint string
 	Secret   string
+	MaxRetry int
 }
 
-// NewNotificationService FIXME ...
-func NewNotificationService(endpoint, secret string) *NotificationService {
+// NewNotificationService creates the NotificationService, endpoint could be empty if the legacy
+// NOTIFICATION_ENDPOINT is not set
+func NewNotificationService(endpoint, secret string, maxRetry int) *NotificationService {
 	return &NotificationService{
 		EndPoint: endpoint,
 		Secret:   secret,
+		MaxRetry: maxRetry,
 	}
 }
 
-// PipelineNotification FIXME ...
+// NotificationChannelQuery used to query notification channels as the api input
+type NotificationChannelQuery struct {
+	Pagination
+}
+
+// NotificationQuery used to query notifications as the api input
+type NotificationQuery struct {
+	Pagination
+	ChannelId uint64 `form:"channelId"`
+	Type      string `form:"type"`
+	Status    string `form:"status"`
+}
+
+// PipelineNotification is the payload of pipeline events
 type PipelineNotification struct {
-	PipelineID uint64
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	BeganAt    *time.Time
-	FinishedAt *time.Time
-	Status     string
+	PipelineID  uint64
+	BlueprintID uint64
+	Name        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	BeganAt     *time.Time
+	FinishedAt  *time.Time
+	Status      string
+	Message     string
+}
+
+// TaskNotification is the payload of task events
+type TaskNotification struct {
+	PipelineID    uint64
+	BlueprintID   uint64
+	TaskID        uint64
+	Plugin        string
+	Status        string
+	Message       string
+	FailedSubTask string
+}
+
+// SubtaskNotification is the payload of subtask events
+type SubtaskNotification struct {
+	PipelineID   uint64
+	BlueprintID  uint64
+	TaskID       uint64
+	Plugin       string
+	Subtask      string
+	SpentSeconds int64
+	Threshold    int64
+}
+
+type subtaskWithPlugin struct {
+	models.Subtask
+	Plugin string
 }
 
-// PipelineStatusChanged FIXME ...
+// PipelineStatusChanged sends the event to NOTIFICATION_ENDPOINT and the subscribed channels
 func (n *NotificationService) PipelineStatusChanged(params PipelineNotification) errors.Error {
-	return n.sendNotification(models.NotificationPipelineStatusChanged, params)
+	channels, err := n.getChannels()
+	if err != nil {
+		return err
+	}
+	return n.dispatch(channels, models.NotificationPipelineStatusChanged, params.BlueprintID, params)
+}
+
+// PipelineFinished sends all events of the finished pipeline, including its failed tasks and slow subtasks
+func (n *NotificationService) PipelineFinished(pipeline *models.Pipeline) errors.Error {
+	channels, err := n.getChannels()
+	if err != nil {
+		return err
+	}
+	pipelineParams := PipelineNotification{
+		PipelineID:  pipeline.ID,
+		BlueprintID: pipeline.BlueprintId,
+		Name:        pipeline.Name,
+		CreatedAt:   pipeline.CreatedAt,
+		UpdatedAt:   pipeline.UpdatedAt,
+		BeganAt:     pipeline.BeganAt,
+		FinishedAt:  pipeline.FinishedAt,
+		Status:      pipeline.Status,
+		Message:     pipeline.Message,
+	}
+	err = n.dispatch(channels, models.NotificationPipelineStatusChanged, pipeline.BlueprintId, pipelineParams)
+	if err != nil {
+		return err
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	switch pipeline.Status {
+	case models.TASK_FAILED, models.TASK_PARTIAL, models.TASK_TIMEOUT:
+		err = n.dispatch(channels, models.NotificationPipelineFailed, pipeline.BlueprintId, pipelineParams)
+	case models.TASK_COMPLETED:
+		var recovered bool
+		recovered, err = isBlueprintRecovered(pipeline)
+		if err == nil && recovered {
+			err = n.dispatch(channels, models.NotificationBlueprintRecovered, pipeline.BlueprintId, pipelineParams)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	tasks, err := GetLatestTasksOfPipeline(pipeline)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if task.Status != models.TASK_FAILED && task.Status != models.TASK_TIMEOUT {
+			continue
+		}
+		err = n.dispatch(channels, models.NotificationTaskFailed, pipeline.BlueprintId, TaskNotification{
+			PipelineID:    pipeline.ID,
+			BlueprintID:   pipeline.BlueprintId,
+			TaskID:        task.ID,
+			Plugin:        task.Plugin,
+			Status:        task.Status,
+			Message:       task.Message,
+			FailedSubTask: task.FailedSubTask,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return n.dispatchSlowSubtasks(channels, pipeline)
 }
 
-func (n *NotificationService) sendNotification(notificationType models.NotificationType, data interface{}) errors.Error {
+func (n *NotificationService) dispatchSlowSubtasks(channels []*models.NotificationChannel, pipeline *models.Pipeline) errors.Error {
+	var subtasks []*subtaskWithPlugin
+	for _, channel := range channels {
+		if !channel.Subscribes(models.NotificationSubtaskSlow, pipeline.BlueprintId) || channel.SubtaskDurationThreshold <= 0 {
+			continue
+		}
+		if subtasks == nil {
+			subtasks = make([]*subtaskWithPlugin, 0)
+			err := db.All(
+				&subtasks,
+				dal.Select("st.*, t.plugin"),
+				dal.From("_devlake_subtasks st"),
+				dal.Join("INNER JOIN _devlake_tasks t ON (t.id = st.task_id)"),
+				dal.Where("t.pipeline_id = ?", pipeline.ID),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		for _, subtask := range subtasks {
+			if subtask.SpentSeconds <= channel.SubtaskDurationThreshold {
+				continue
+			}
+			err := n.send(channel, models.NotificationSubtaskSlow, SubtaskNotification{
+				PipelineID:   pipeline.ID,
+				BlueprintID:  pipeline.BlueprintId,
+				TaskID:       subtask.TaskID,
+				Plugin:       subtask.Plugin,
+				Subtask:      subtask.Name,
+				SpentSeconds: subtask.SpentSeconds,
+				Threshold:    channel.SubtaskDurationThreshold,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isBlueprintRecovered tells whether the previous pipeline of the blueprint failed
+func isBlueprintRecovered(pipeline *models.Pipeline) (bool, errors.Error) {
+	if pipeline.BlueprintId == 0 {
+		return false, nil
+	}
+	previous := make([]*models.Pipeline, 0, 1)
+	err := db.All(
+		&previous,
+		dal.From(&models.Pipeline{}),
+		dal.Where("blueprint_id = ? AND id < ? AND finished_at IS NOT NULL", pipeline.BlueprintId, pipeline.ID),
+		dal.Orderby("id DESC"),
+		dal.Limit(1),
+	)
+	if err != nil || len(previous) == 0 {
+		return false, err
+	}
+	switch previous[0].Status {
+	case models.TASK_FAILED, models.TASK_PARTIAL, models.TASK_TIMEOUT:
+		return true, nil
+	}
+	return false, nil
+}
+
+func (n *NotificationService) getChannels() ([]*models.NotificationChannel, errors.Error) {
+	channels := make([]*models.NotificationChannel, 0)
+	err := db.All(&channels, dal.Where("enable = ?", true))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting DB notification channels")
+	}
+	return channels, nil
+}
+
+func (n *NotificationService) dispatch(
+	channels []*models.NotificationChannel,
+	notificationType models.NotificationType,
+	blueprintId uint64,
+	data interface{},
+) errors.Error {
+	// the legacy endpoint receives PipelineStatusChanged only
+	if n.EndPoint != "" && notificationType == models.NotificationPipelineStatusChanged {
+		err := n.send(nil, notificationType, data)
+		if err != nil {
+			return err
+		}
+	}
+	for _, channel := range channels {
+		if !channel.Subscribes(notificationType, blueprintId) {
+			continue
+		}
+		err := n.send(channel, notificationType, data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send records the notification and makes the first delivery attempt, nil channel stands for NOTIFICATION_ENDPOINT
+func (n *NotificationService) send(channel *models.NotificationChannel, notificationType models.NotificationType, data interface{}) errors.Error {
 	var dataJson, err = json.Marshal(data)
 	if err != nil {
 		return errors.Convert(err)
@@ -70,7 +291,13 @@ func (n *NotificationService) sendNotification(notificationType models.Notificat
 	var notification models.Notification
 	notification.Data = string(dataJson)
 	notification.Type = notificationType
-	notification.Endpoint = n.EndPoint
+	notification.Status = models.NOTIFICATION_PENDING
+	if channel != nil {
+		notification.ChannelId = channel.ID
+		notification.Endpoint = sanitizeEndpoint(channel.Endpoint)
+	} else {
+		notification.Endpoint = n.EndPoint
+	}
 	nonce, err1 := utils.RandLetterBytes(16)
 	if err1 != nil {
 		return err1
@@ -81,25 +308,325 @@ func (n *NotificationService) sendNotification(notificationType models.Notificat
 	if err != nil {
 		return errors.Convert(err)
 	}
+	return n.deliver(&notification, channel)
+}
+
+func (n *NotificationService) deliver(record *models.Notification, channel *models.NotificationChannel) errors.Error {
+	var sender notification.Channel
+	var err errors.Error
+	if channel != nil {
+		sender, err = notification.NewChannel(channel)
+	} else {
+		sender = notification.NewWebhookChannel(n.EndPoint, n.Secret)
+	}
+	if err == nil {
+		var result *notification.Result
+		title, text := renderNotification(record.Type, record.Data)
+		result, err = sender.Send(&notification.Message{
+			Id:    record.ID,
+			Nonce: record.Nonce,
+			Type:  record.Type,
+			Title: title,
+			Text:  text,
+			Data:  record.Data,
+		})
+		if result != nil {
+			record.ResponseCode = result.ResponseCode
+			record.Response = result.Response
+		}
+	}
 
-	sign := n.signature(notification.Data, fmt.Sprintf("%d-%s", notification.ID, nonce))
-	url := fmt.Sprintf("%s?nouce=%d-%s&sign=%s", n.EndPoint, notification.ID, nonce, sign)
+	record.Attempts++
+	if err == nil {
+		record.Status = models.NOTIFICATION_SENT
+		record.LastError = ""
+		record.NextRetryAt = nil
+	} else {
+		notificationLog.Warn(err, "failed to deliver notification #%d, attempt %d", record.ID, record.Attempts)
+		record.LastError = err.Error()
+		if record.Attempts > n.MaxRetry {
+			record.Status = models.NOTIFICATION_FAILED
+			record.NextRetryAt = nil
+		} else {
+			record.Status = models.NOTIFICATION_RETRYING
+			nextRetryAt := time.Now().Add(notificationRetryBackoff * time.Duration(1<<(record.Attempts-1)))
+			record.NextRetryAt = &nextRetryAt
+		}
+	}
+	return db.Update(record)
+}
 
-	resp, err := http.Post(url, "application/json", strings.NewReader(notification.Data))
+// RetryNotifications redelivers the failed notifications whose backoff elapsed
+func (n *NotificationService) RetryNotifications() errors.Error {
+	notifications := make([]*models.Notification, 0)
+	err := db.All(
+		&notifications,
+		dal.Where("status = ? AND next_retry_at <= ?", models.NOTIFICATION_RETRYING, time.Now()),
+		dal.Orderby("id"),
+	)
 	if err != nil {
-		return errors.Convert(err)
+		return err
+	}
+	for _, record := range notifications {
+		var channel *models.NotificationChannel
+		if record.ChannelId != 0 {
+			channel = &models.NotificationChannel{}
+			err = db.First(channel, dal.Where("id = ?", record.ChannelId))
+			if err != nil {
+				if !db.IsErrorNotFound(err) {
+					return err
+				}
+				// the channel was deleted
+				record.Status = models.NOTIFICATION_FAILED
+				record.NextRetryAt = nil
+				if err = db.Update(record); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		err = n.deliver(record, channel)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	notification.ResponseCode = resp.StatusCode
-	respBody, err := io.ReadAll(resp.Body)
+func runNotificationRetrier() {
+	ticker := time.NewTicker(notificationRetryInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := notificationService.RetryNotifications(); err != nil {
+			notificationLog.Error(err, "failed to retry notifications")
+		}
+	}
+}
+
+// renderNotification produces the human-readable title and text of the notification for chat and email channels
+func renderNotification(notificationType models.NotificationType, data string) (string, string) {
+	switch notificationType {
+	case models.NotificationPipelineStatusChanged, models.NotificationPipelineFailed, models.NotificationBlueprintRecovered:
+		params := &PipelineNotification{}
+		_ = json.Unmarshal([]byte(data), params)
+		title := fmt.Sprintf("[DevLake] Pipeline #%d %s", params.PipelineID, params.Status)
+		switch notificationType {
+		case models.NotificationPipelineFailed:
+			title = fmt.Sprintf("[DevLake] Pipeline #%d failed", params.PipelineID)
+			if params.Status == models.TASK_TIMEOUT {
+				title = fmt.Sprintf("[DevLake] Pipeline #%d timed out", params.PipelineID)
+			}
+		case models.NotificationBlueprintRecovered:
+			title = fmt.Sprintf("[DevLake] Blueprint #%d recovered", params.BlueprintID)
+		}
+		lines := []string{
+			fmt.Sprintf("Pipeline: #%d %s", params.PipelineID, params.Name),
+			fmt.Sprintf("Blueprint: #%d", params.BlueprintID),
+			fmt.Sprintf("Status: %s", params.Status),
+		}
+		if params.Message != "" {
+			lines = append(lines, fmt.Sprintf("Message: %s", params.Message))
+		}
+		return title, strings.Join(lines, "\n")
+	case models.NotificationTaskFailed:
+		params := &TaskNotification{}
+		_ = json.Unmarshal([]byte(data), params)
+		verb := "failed"
+		if params.Status == models.TASK_TIMEOUT {
+			verb = "timed out"
+		}
+		return fmt.Sprintf("[DevLake] Task #%d of pipeline #%d %s", params.TaskID, params.PipelineID, verb), strings.Join([]string{
+			fmt.Sprintf("Plugin: %s", params.Plugin),
+			fmt.Sprintf("Status: %s", params.Status),
+			fmt.Sprintf("Failed subtask: %s", params.FailedSubTask),
+			fmt.Sprintf("Message: %s", params.Message),
+		}, "\n")
+	case models.NotificationSubtaskSlow:
+		params := &SubtaskNotification{}
+		_ = json.Unmarshal([]byte(data), params)
+		return fmt.Sprintf("[DevLake] Subtask %s of pipeline #%d exceeded %ds", params.Subtask, params.PipelineID, params.Threshold),
+			fmt.Sprintf("Plugin: %s\nTask: #%d\nSpent: %ds", params.Plugin, params.TaskID, params.SpentSeconds)
+	}
+	return fmt.Sprintf("[DevLake] %s", notificationType), data
+}
+
+// CreateNotificationChannel accepts a NotificationChannel instance and insert it to database
+func CreateNotificationChannel(channel *models.NotificationChannel) (*models.NotificationChannel, errors.Error) {
+	if err := VerifyStruct(channel); err != nil {
+		return nil, err
+	}
+	if _, err := notification.NewChannel(channel); err != nil {
+		return nil, err
+	}
+	channel.ID = 0
+	err := db.Create(channel)
 	if err != nil {
-		return errors.Convert(err)
+		if db.IsDuplicationError(err) {
+			return nil, errors.BadInput.New(fmt.Sprintf("A notification channel with name [%s] already exists", channel.Name))
+		}
+		return nil, errors.Default.Wrap(err, "error creating DB notification channel")
+	}
+	return sanitizeNotificationChannel(channel), nil
+}
+
+// GetNotificationChannels returns a paginated list of notification channels
+func GetNotificationChannels(query *NotificationChannelQuery) ([]*models.NotificationChannel, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.NotificationChannel{}),
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of notification channels")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	channels := make([]*models.NotificationChannel, 0)
+	err = db.All(&channels, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB notification channels")
+	}
+	for _, channel := range channels {
+		sanitizeNotificationChannel(channel)
+	}
+	return channels, count, nil
+}
+
+// GetNotificationChannel returns the detail of a given channel ID
+func GetNotificationChannel(id uint64) (*models.NotificationChannel, errors.Error) {
+	channel, err := getDbNotificationChannel(id)
+	if err != nil {
+		return nil, err
+	}
+	return sanitizeNotificationChannel(channel), nil
+}
+
+// PatchNotificationChannel updates the channel, secrets and the endpoint would be kept if they were left sanitized or empty
+func PatchNotificationChannel(id uint64, body map[string]interface{}) (*models.NotificationChannel, errors.Error) {
+	channel, err := getDbNotificationChannel(id)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, secret, password := channel.Endpoint, channel.Secret, channel.SmtpPassword
+	err = helper.DecodeMapStruct(body, channel, true)
+	if err != nil {
+		return nil, err
+	}
+	channel.ID = id
+	if channel.Endpoint == "" || channel.Endpoint == sanitizeEndpoint(endpoint) {
+		channel.Endpoint = endpoint
+	}
+	if channel.Secret == "" || channel.Secret == sanitizedSecret {
+		channel.Secret = secret
+	}
+	if channel.SmtpPassword == "" || channel.SmtpPassword == sanitizedSecret {
+		channel.SmtpPassword = password
+	}
+	if err = VerifyStruct(channel); err != nil {
+		return nil, err
+	}
+	if _, err = notification.NewChannel(channel); err != nil {
+		return nil, err
+	}
+	err = db.Update(channel)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error updating DB notification channel")
 	}
-	notification.Response = string(respBody)
-	return db.Update(notification)
+	return sanitizeNotificationChannel(channel), nil
 }
 
-func (n *NotificationService) signature(input, nouce string) string {
-	sum := sha256.Sum256([]byte(input + n.Secret + nouce))
-	return hex.EncodeToString(sum[:])
+// DeleteNotificationChannel deletes the channel
+func DeleteNotificationChannel(id uint64) errors.Error {
+	channel, err := getDbNotificationChannel(id)
+	if err != nil {
+		return err
+	}
+	return db.Delete(channel)
+}
+
+// TestNotificationChannel sends a test message to the channel
+func TestNotificationChannel(id uint64) errors.Error {
+	channel, err := getDbNotificationChannel(id)
+	if err != nil {
+		return err
+	}
+	sender, err := notification.NewChannel(channel)
+	if err != nil {
+		return err
+	}
+	_, err = sender.Send(&notification.Message{
+		Type:  "Test",
+		Title: "[DevLake] Test notification",
+		Text:  fmt.Sprintf("Notification channel [%s] works", channel.Name),
+		Data:  `{"test": true}`,
+	})
+	return err
+}
+
+// GetNotifications returns a paginated list of notifications, newest first
+func GetNotifications(query *NotificationQuery) ([]*models.Notification, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.Notification{}),
+	}
+	if query.ChannelId != 0 {
+		clauses = append(clauses, dal.Where("channel_id = ?", query.ChannelId))
+	}
+	if query.Type != "" {
+		clauses = append(clauses, dal.Where("type = ?", query.Type))
+	}
+	if query.Status != "" {
+		clauses = append(clauses, dal.Where("status = ?", query.Status))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of notifications")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	notifications := make([]*models.Notification, 0)
+	err = db.All(&notifications, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB notifications")
+	}
+	return notifications, count, nil
+}
+
+func getDbNotificationChannel(id uint64) (*models.NotificationChannel, errors.Error) {
+	channel := &models.NotificationChannel{}
+	err := db.First(channel, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("notification channel not found")
+		}
+		return nil, errors.Default.Wrap(err, "error getting DB notification channel")
+	}
+	return channel, nil
+}
+
+func sanitizeNotificationChannel(channel *models.NotificationChannel) *models.NotificationChannel {
+	channel.Endpoint = sanitizeEndpoint(channel.Endpoint)
+	if channel.Secret != "" {
+		channel.Secret = sanitizedSecret
+	}
+	if channel.SmtpPassword != "" {
+		channel.SmtpPassword = sanitizedSecret
+	}
+	return channel
+}
+
+// sanitizeEndpoint keeps the scheme and the host only, the rest of webhook urls, e.g. the ones of slack, carries the credentials
+func sanitizeEndpoint(endpoint string) string {
+	if endpoint == "" {
+		return ""
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return sanitizedSecret
+	}
+	return fmt.Sprintf("%s://%s/%s", u.Scheme, u.Host, sanitizedSecret)
 }
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// Message is the content of a notification
+type Message struct {
+	// Id and Nonce identify the notification, they are used to sign the payload of webhooks
+	Id    uint64
+	Nonce string
+	Type  models.NotificationType
+	Title string
+	// Text is the human-readable summary for chat and email channels
+	Text string
+	// Data is the json payload for webhooks
+	Data string
+}
+
+// Result is the response of the receiver
+type Result struct {
+	ResponseCode int
+	Response     string
+}
+
+// Channel delivers messages to a receiver
+type Channel interface {
+	Send(message *Message) (*Result, errors.Error)
+}
+
+// NewChannel creates the Channel according to the type of the channel settings
+func NewChannel(channel *models.NotificationChannel) (Channel, errors.Error) {
+	switch channel.Type {
+	case models.NOTIFICATION_CHANNEL_WEBHOOK:
+		return NewWebhookChannel(channel.Endpoint, channel.Secret), nil
+	case models.NOTIFICATION_CHANNEL_SLACK:
+		return &SlackChannel{Endpoint: channel.Endpoint}, nil
+	case models.NOTIFICATION_CHANNEL_FEISHU:
+		return &FeishuChannel{Endpoint: channel.Endpoint, Secret: channel.Secret}, nil
+	case models.NOTIFICATION_CHANNEL_SMTP:
+		return &SmtpChannel{
+			Host:     channel.SmtpHost,
+			Port:     channel.SmtpPort,
+			Username: channel.SmtpUsername,
+			Password: channel.SmtpPassword,
+			From:     channel.SmtpFrom,
+			To:       channel.SmtpTo,
+		}, nil
+	}
+	return nil, errors.BadInput.New(fmt.Sprintf("unsupported notification channel type %s", channel.Type))
+}
+
+func postJson(url string, body string) (*Result, errors.Error) {
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	result := &Result{ResponseCode: resp.StatusCode, Response: string(respBody)}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return result, errors.HttpStatus(resp.StatusCode).New(fmt.Sprintf("notification receiver responded %d", resp.StatusCode))
+	}
+	return result, nil
+}
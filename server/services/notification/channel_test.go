@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func startReceiver(t *testing.T, status int, response string, requests chan *http.Request, bodies chan string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.Nil(t, err)
+		requests <- r
+		bodies <- string(body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+}
+
+func TestWebhookChannel(t *testing.T) {
+	requests, bodies := make(chan *http.Request, 1), make(chan string, 1)
+	server := startReceiver(t, http.StatusOK, "ok", requests, bodies)
+	defer server.Close()
+
+	channel, err := NewChannel(&models.NotificationChannel{
+		Type:     models.NOTIFICATION_CHANNEL_WEBHOOK,
+		Endpoint: server.URL,
+		Secret:   "secret",
+	})
+	assert.Nil(t, err)
+	result, err := channel.Send(&Message{Id: 1, Nonce: "abc", Data: `{"pipelineId":1}`})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, result.ResponseCode)
+	req := <-requests
+	assert.Equal(t, "1-abc", req.URL.Query().Get("nouce"))
+	assert.Equal(t, channel.(*WebhookChannel).signature(`{"pipelineId":1}`, "1-abc"), req.URL.Query().Get("sign"))
+	assert.Equal(t, `{"pipelineId":1}`, <-bodies)
+}
+
+func TestSlackChannelFailure(t *testing.T) {
+	requests, bodies := make(chan *http.Request, 1), make(chan string, 1)
+	server := startReceiver(t, http.StatusInternalServerError, "oops", requests, bodies)
+	defer server.Close()
+
+	channel := &SlackChannel{Endpoint: server.URL}
+	result, err := channel.Send(&Message{Title: "Pipeline #1 failed", Text: "boom"})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, result.ResponseCode)
+	<-requests
+	payload := map[string]string{}
+	assert.Nil(t, json.Unmarshal([]byte(<-bodies), &payload))
+	assert.Equal(t, "*Pipeline #1 failed*\nboom", payload["text"])
+}
+
+func TestFeishuChannel(t *testing.T) {
+	requests, bodies := make(chan *http.Request, 1), make(chan string, 1)
+	server := startReceiver(t, http.StatusOK, `{"code":19021,"msg":"sign match fail"}`, requests, bodies)
+	defer server.Close()
+
+	channel := &FeishuChannel{Endpoint: server.URL, Secret: "secret"}
+	_, err := channel.Send(&Message{Title: "Pipeline #1 failed", Text: "boom"})
+	assert.NotNil(t, err)
+	<-requests
+	body := <-bodies
+	assert.True(t, strings.Contains(body, `"sign"`))
+	assert.True(t, strings.Contains(body, `"msg_type":"text"`))
+}
+
+func TestSmtpChannelBuildMail(t *testing.T) {
+	channel := &SmtpChannel{From: "lake@example.com", To: []string{"a@example.com", "b@example.com"}}
+	mail := string(channel.buildMail(&Message{Title: "Pipeline #1 failed", Text: "line1\nline2"}))
+	assert.Contains(t, mail, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(t, mail, "Subject: Pipeline #1 failed\r\n")
+	assert.Contains(t, mail, "line1\r\nline2")
+
+	// line breaks in pipeline names never make it into headers
+	mail = string(channel.buildMail(&Message{Title: "Pipeline x\r\nBcc: evil@example.com\nfailed"}))
+	assert.Contains(t, mail, "Subject: Pipeline x Bcc: evil@example.com failed\r\n")
+	assert.NotContains(t, mail, "\r\nBcc:")
+	mail = string(channel.buildMail(&Message{Title: "流水线 #1 失败"}))
+	assert.Contains(t, mail, "Subject: =?utf-8?q?")
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// FeishuChannel posts the message to a feishu custom bot, the request would be signed if
+// the bot has signature verification enabled
+type FeishuChannel struct {
+	Endpoint string
+	Secret   string
+}
+
+type feishuResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func (f *FeishuChannel) Send(message *Message) (*Result, errors.Error) {
+	payload := map[string]interface{}{
+		"msg_type": "text",
+		"content": map[string]string{
+			"text": message.Title + "\n" + message.Text,
+		},
+	}
+	if f.Secret != "" {
+		timestamp := time.Now().Unix()
+		payload["timestamp"] = fmt.Sprintf("%d", timestamp)
+		payload["sign"] = f.signature(timestamp)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	result, e := postJson(f.Endpoint, string(body))
+	if e != nil {
+		return result, e
+	}
+	// feishu responds 200 with a non-zero code on failures
+	resp := &feishuResponse{}
+	if json.Unmarshal([]byte(result.Response), resp) == nil && resp.Code != 0 {
+		return result, errors.Default.New(fmt.Sprintf("feishu bot responded code %d: %s", resp.Code, resp.Msg))
+	}
+	return result, nil
+}
+
+// signature follows https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot
+func (f *FeishuChannel) signature(timestamp int64) string {
+	key := fmt.Sprintf("%d\n%s", timestamp, f.Secret)
+	h := hmac.New(sha256.New, []byte(key))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// SmtpChannel sends the message as a plain text email
+type SmtpChannel struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (s *SmtpChannel) Send(message *Message) (*Result, errors.Error) {
+	if len(s.To) == 0 {
+		return nil, errors.BadInput.New("no recipient for the smtp channel")
+	}
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	err := smtp.SendMail(fmt.Sprintf("%s:%d", s.Host, s.Port), auth, s.From, s.To, s.buildMail(message))
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	return &Result{Response: "sent"}, nil
+}
+
+func (s *SmtpChannel) buildMail(message *Message) []byte {
+	var sb strings.Builder
+	sb.WriteString("From: " + headerValue(s.From) + "\r\n")
+	sb.WriteString("To: " + headerValue(strings.Join(s.To, ", ")) + "\r\n")
+	sb.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", headerValue(message.Title)) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(message.Text, "\n", "\r\n"))
+	sb.WriteString("\r\n")
+	return []byte(sb.String())
+}
+
+// headerValue drops line breaks, the title is made of pipeline names which could otherwise inject headers
+func headerValue(value string) string {
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// WebhookChannel posts the json data to the endpoint, the receiver could verify the payload by
+// comparing the `sign` query parameter with sha256(data + secret + nouce)
+type WebhookChannel struct {
+	Endpoint string
+	Secret   string
+}
+
+func NewWebhookChannel(endpoint, secret string) *WebhookChannel {
+	return &WebhookChannel{
+		Endpoint: endpoint,
+		Secret:   secret,
+	}
+}
+
+func (w *WebhookChannel) Send(message *Message) (*Result, errors.Error) {
+	nonce := fmt.Sprintf("%d-%s", message.Id, message.Nonce)
+	url := fmt.Sprintf("%s?nouce=%s&sign=%s", w.Endpoint, nonce, w.signature(message.Data, nonce))
+	return postJson(url, message.Data)
+}
+
+func (w *WebhookChannel) signature(input, nouce string) string {
+	sum := sha256.Sum256([]byte(input + w.Secret + nouce))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderNotification(t *testing.T) {
+	data, _ := json.Marshal(PipelineNotification{PipelineID: 3, BlueprintID: 2, Name: "bp", Status: models.TASK_FAILED, Message: "boom"})
+	title, text := renderNotification(models.NotificationPipelineFailed, string(data))
+	assert.Equal(t, "[DevLake] Pipeline #3 failed", title)
+	assert.Contains(t, text, "Message: boom")
+
+	title, _ = renderNotification(models.NotificationBlueprintRecovered, string(data))
+	assert.Equal(t, "[DevLake] Blueprint #2 recovered", title)
+
+	data, _ = json.Marshal(SubtaskNotification{PipelineID: 3, TaskID: 5, Plugin: "github", Subtask: "collectIssues", SpentSeconds: 700, Threshold: 600})
+	title, text = renderNotification(models.NotificationSubtaskSlow, string(data))
+	assert.Equal(t, "[DevLake] Subtask collectIssues of pipeline #3 exceeded 600s", title)
+	assert.Contains(t, text, "Spent: 700s")
+}
+
+func TestSanitizeNotificationChannel(t *testing.T) {
+	channel := sanitizeNotificationChannel(&models.NotificationChannel{
+		Secret:       "s",
+		SmtpPassword: "p",
+		Endpoint:     "https://hooks.slack.com/services/T000/B000/XXXX",
+	})
+	assert.Equal(t, sanitizedSecret, channel.Secret)
+	assert.Equal(t, sanitizedSecret, channel.SmtpPassword)
+	assert.Equal(t, "https://hooks.slack.com/"+sanitizedSecret, channel.Endpoint)
+
+	channel = sanitizeNotificationChannel(&models.NotificationChannel{SmtpHost: "smtp.example.com"})
+	assert.Equal(t, "", channel.Endpoint)
+	assert.Equal(t, sanitizedSecret, sanitizeEndpoint("not a url"))
+}
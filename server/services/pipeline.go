@@ -23,7 +23,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
@@ -41,8 +40,16 @@ import (
 
 var notificationService *NotificationService
 var temporalClient client.Client
+var pipelineQueue *pipelineScheduler
 var globalPipelineLog = logruslog.Global.Nested("pipeline service")
 
+const (
+	// RERUN_MODE_FULL reruns all subtasks of the failed tasks
+	RERUN_MODE_FULL = "full"
+	// RERUN_MODE_RESUME reruns the failed tasks starting from their failed subtasks
+	RERUN_MODE_RESUME = "resume"
+)
+
 // PipelineQuery is a query for GetPipelines
 type PipelineQuery struct {
 	Pagination
@@ -56,8 +63,15 @@ func pipelineServiceInit() {
 	// notification
 	var notificationEndpoint = cfg.GetString("NOTIFICATION_ENDPOINT")
 	var notificationSecret = cfg.GetString("NOTIFICATION_SECRET")
-	if strings.TrimSpace(notificationEndpoint) != "" {
-		notificationService = NewNotificationService(notificationEndpoint, notificationSecret)
+	var notificationRetry = 3
+	if cfg.IsSet("NOTIFICATION_RETRY") {
+		notificationRetry = cfg.GetInt("NOTIFICATION_RETRY")
+	}
+	notificationService = NewNotificationService(strings.TrimSpace(notificationEndpoint), notificationSecret, notificationRetry)
+	go runNotificationRetrier()
+	startRetentionJanitor()
+	if err := failInterruptedScopeDeletions(); err != nil {
+		panic(err)
 	}
 
 	// temporal client
@@ -112,6 +126,12 @@ func pipelineServiceInit() {
 		globalPipelineLog.Warn(nil, `pipelineMaxParallel=0 means pipeline will be run No Limit`)
 		pipelineMaxParallel = 10000
 	}
+	// 0 (the default) means pipelines sharing a connection are not limited
+	var pipelineMaxParallelPerConnection = cfg.GetInt("PIPELINE_MAX_PARALLEL_PER_CONNECTION")
+	if pipelineMaxParallelPerConnection < 0 {
+		panic(errors.BadInput.New(`PIPELINE_MAX_PARALLEL_PER_CONNECTION should be a positive integer`))
+	}
+	pipelineQueue = newPipelineScheduler(int(pipelineMaxParallel), pipelineMaxParallelPerConnection, getPipelineMaxParallelOfPlugin)
 	// run pipeline with independent goroutine
 	go RunPipelineInQueue(pipelineMaxParallel)
 }
@@ -169,8 +189,6 @@ func GetPipelineLogsArchivePath(pipeline *models.Pipeline) (string, errors.Error
 // RunPipelineInQueue query pipeline from db and run it in a queue
 func RunPipelineInQueue(pipelineMaxParallel int64) {
 	sema := semaphore.NewWeighted(pipelineMaxParallel)
-	runningParallelLabels := []string{}
-	var runningParallelLabelLock sync.Mutex
 	for {
 		globalPipelineLog.Info("acquire lock")
 		// start goroutine when sema lock ready and pipeline exist.
@@ -180,76 +198,46 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 			panic(err)
 		}
 		globalPipelineLog.Info("get lock and wait next pipeline")
-		dbPipeline := &models.Pipeline{}
+		var next *pendingPipeline
 		for {
 			cronLocker.Lock()
-			// prepare query to find an appropriate pipeline to execute
-			err := db.First(dbPipeline,
-				dal.Where("status IN ?", []string{models.TASK_CREATED, models.TASK_RERUN}),
-				dal.Join(
-					`left join _devlake_pipeline_labels ON
-						_devlake_pipeline_labels.pipeline_id = _devlake_pipelines.id AND
-						_devlake_pipeline_labels.name LIKE 'parallel/%' AND
-						_devlake_pipeline_labels.name in ?`,
-					runningParallelLabels,
-				),
-				dal.Groupby("id"),
-				dal.Having("count(_devlake_pipeline_labels.name)=0"),
-				dal.Select("id"),
-				dal.Orderby("id ASC"),
-				dal.Limit(1),
-			)
+			// find the pending pipeline with the highest priority which wouldn't exceed any limit
+			next, err = pipelineQueue.next()
+			if err == nil && next != nil {
+				// mark the pipeline running before releasing the lock, so it won't be rerun or dequeued again
+				err = db.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
+					{ColumnName: "status", Value: models.TASK_RUNNING},
+					{ColumnName: "message", Value: ""},
+					{ColumnName: "began_at", Value: time.Now()},
+				}, dal.Where("id = ?", next.pipeline.ID))
+				if err != nil {
+					panic(err)
+				}
+				pipelineQueue.start(next.pipeline.ID, next.resources)
+			}
 			cronLocker.Unlock()
-			if err == nil {
-				// next pipeline found
+			if next != nil {
 				break
 			}
-			if !db.IsErrorNotFound(err) {
+			if err != nil {
 				// log unexpected err
 				globalPipelineLog.Error(err, "dequeue failed")
 			}
 			time.Sleep(time.Second)
 		}
 
-		// mark the pipeline running
-		err = db.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
-			{ColumnName: "status", Value: models.TASK_RUNNING},
-			{ColumnName: "message", Value: ""},
-			{ColumnName: "began_at", Value: time.Now()},
-		}, dal.Where("id = ?", dbPipeline.ID))
-		if err != nil {
-			panic(err)
-		}
-
-		// add pipelineParallelLabels to runningParallelLabels
-		var pipelineParallelLabels []string
-		err = fillPipelineDetail(dbPipeline)
-		if err != nil {
-			panic(err)
-		}
-		for _, dbLabel := range dbPipeline.Labels {
-			if strings.HasPrefix(dbLabel, `parallel/`) {
-				pipelineParallelLabels = append(pipelineParallelLabels, dbLabel)
-			}
-		}
-		runningParallelLabelLock.Lock()
-		runningParallelLabels = append(runningParallelLabels, pipelineParallelLabels...)
-		runningParallelLabelLock.Unlock()
-
-		go func(pipelineId uint64, parallelLabels []string) {
+		go func(pipelineId uint64, resources *pipelineResources) {
 			defer sema.Release(1)
 			defer func() {
-				runningParallelLabelLock.Lock()
-				runningParallelLabels = utils.SliceRemove(runningParallelLabels, parallelLabels...)
-				runningParallelLabelLock.Unlock()
-				globalPipelineLog.Info("finish pipeline #%d, now runningParallelLabels is %s", pipelineId, runningParallelLabels)
+				pipelineQueue.finish(pipelineId)
+				globalPipelineLog.Info("finish pipeline #%d", pipelineId)
 			}()
-			globalPipelineLog.Info("run pipeline, %d, now running runningParallelLabels are %s", pipelineId, runningParallelLabels)
-			err = runPipeline(pipelineId)
+			globalPipelineLog.Info("run pipeline #%d, plugins %s, connections %s, parallel labels %s", pipelineId, resources.plugins, resources.connections, resources.parallelLabels)
+			err := runPipeline(pipelineId)
 			if err != nil {
 				globalPipelineLog.Error(err, "failed to run pipeline %d", pipelineId)
 			}
-		}(dbPipeline.ID, pipelineParallelLabels)
+		}(next.pipeline.ID, next.resources)
 	}
 }
 
@@ -353,19 +341,12 @@ func NotifyExternal(pipelineId uint64) errors.Error {
 	if notificationService == nil {
 		return nil
 	}
-	// send notification to an external web endpoint
+	// send notifications to the external web endpoint and the subscribed channels
 	pipeline, err := GetPipeline(pipelineId)
 	if err != nil {
 		return err
 	}
-	err = notificationService.PipelineStatusChanged(PipelineNotification{
-		PipelineID: pipeline.ID,
-		CreatedAt:  pipeline.CreatedAt,
-		UpdatedAt:  pipeline.UpdatedAt,
-		BeganAt:    pipeline.BeganAt,
-		FinishedAt: pipeline.FinishedAt,
-		Status:     pipeline.Status,
-	})
+	err = notificationService.PipelineFinished(pipeline)
 	if err != nil {
 		globalPipelineLog.Error(err, "failed to send notification: %v", err)
 		return err
@@ -418,6 +399,18 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 	return errors.Convert(err)
 }
 
+// getResumeFrom returns the subtask the rerun task should start from, empty means running all subtasks
+func getResumeFrom(task *models.Task, mode string) string {
+	if mode != RERUN_MODE_RESUME {
+		return ""
+	}
+	// the task failed before running any subtask, i.e. in PrepareTaskData
+	if task.FailedSubTask == "" || task.FailedSubTask == "unknown" {
+		return task.ResumeFrom
+	}
+	return task.FailedSubTask
+}
+
 // getPipelineLogsPath gets the logs directory of this pipeline
 func getPipelineLogsPath(pipeline *models.Pipeline) (string, errors.Error) {
 	pipelineLog := GetPipelineLogger(pipeline)
@@ -433,7 +426,10 @@ func getPipelineLogsPath(pipeline *models.Pipeline) (string, errors.Error) {
 }
 
 // RerunPipeline would rerun all failed tasks or specified task
-func RerunPipeline(pipelineId uint64, task *models.Task) ([]*models.Task, errors.Error) {
+func RerunPipeline(pipelineId uint64, task *models.Task, mode string) ([]*models.Task, errors.Error) {
+	if mode != "" && mode != RERUN_MODE_FULL && mode != RERUN_MODE_RESUME {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid rerun mode %s, it should be %s or %s", mode, RERUN_MODE_FULL, RERUN_MODE_RESUME))
+	}
 	// prevent pipeline executor from doing anything that might jeopardize the integrity
 	cronLocker.Lock()
 	defer cronLocker.Unlock()
@@ -480,11 +476,13 @@ func RerunPipeline(pipelineId uint64, task *models.Task) ([]*models.Task, errors
 	// TODO: this is better to be wrapped inside a transaction
 	rerunTasks := []*models.Task{}
 	for _, t := range failedTasks {
-		// mark previous task failed
-		t.Status = models.TASK_FAILED
-		err := db.UpdateColumn(t, "status", models.TASK_FAILED)
-		if err != nil {
-			return nil, err
+		// mark previous task failed, timed out ones are kept as they were
+		if t.Status != models.TASK_TIMEOUT {
+			t.Status = models.TASK_FAILED
+			err := db.UpdateColumn(t, "status", models.TASK_FAILED)
+			if err != nil {
+				return nil, err
+			}
 		}
 		// create new task
 		subtasks, err := t.GetSubTasks()
@@ -505,6 +503,7 @@ func RerunPipeline(pipelineId uint64, task *models.Task) ([]*models.Task, errors
 			PipelineRow: t.PipelineRow,
 			PipelineCol: t.PipelineCol,
 			IsRerun:     true,
+			ResumeFrom:  getResumeFrom(t, mode),
 		})
 		if err != nil {
 			return nil, err
@@ -513,11 +512,12 @@ func RerunPipeline(pipelineId uint64, task *models.Task) ([]*models.Task, errors
 		rerunTasks = append(rerunTasks, rerunTask)
 	}
 
-	// mark pipline rerun
-	err = db.UpdateColumn(&models.Pipeline{},
-		"status", models.TASK_RERUN,
-		dal.Where("id = ?", pipelineId),
-	)
+	// mark pipline rerun, reruns are triggered by users so they jump ahead of the cron pipelines
+	sets := []dal.DalSet{{ColumnName: "status", Value: models.TASK_RERUN}}
+	if pipeline.Priority < models.PIPELINE_PRIORITY_MANUAL {
+		sets = append(sets, dal.DalSet{ColumnName: "priority", Value: models.PIPELINE_PRIORITY_MANUAL})
+	}
+	err = db.UpdateColumns(&models.Pipeline{}, sets, dal.Where("id = ?", pipelineId))
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +75,9 @@ func CreateDbPipeline(newPipeline *models.NewPipeline) (*models.Pipeline, errors
 		Plan:          planByte,
 		SkipOnFail:    newPipeline.SkipOnFail,
 	}
+	if newPipeline.Priority != nil {
+		dbPipeline.Priority = *newPipeline.Priority
+	}
 	if newPipeline.BlueprintId != 0 {
 		dbPipeline.BlueprintId = newPipeline.BlueprintId
 	}
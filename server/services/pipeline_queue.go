@@ -0,0 +1,266 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+)
+
+// QueuedPipeline is a pending pipeline along with its position in the queue
+type QueuedPipeline struct {
+	ID          uint64    `json:"id"`
+	Name        string    `json:"name"`
+	BlueprintId uint64    `json:"blueprintId"`
+	Status      string    `json:"status"`
+	Priority    int       `json:"priority"`
+	Labels      []string  `json:"labels"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Position    int       `json:"position"`
+	// BlockedBy tells why the pipeline can not be started right now, empty if it is going to be started next
+	BlockedBy string `json:"blockedBy"`
+}
+
+// pipelineResources are what a pipeline occupies while running
+type pipelineResources struct {
+	parallelLabels []string
+	plugins        []string
+	// connections are in form of <plugin>:<connectionId>
+	connections []string
+}
+
+type pendingPipeline struct {
+	pipeline  *models.Pipeline
+	resources *pipelineResources
+}
+
+// pipelineScheduler picks pending pipelines by priority while keeping the running ones within the limits
+type pipelineScheduler struct {
+	maxParallel int
+	// maxParallelPerConnection limits running pipelines touching the same connection, 0 means no limit
+	maxParallelPerConnection int
+	// maxParallelOfPlugin returns the limit of running pipelines using the plugin, 0 means no limit
+	maxParallelOfPlugin func(pluginName string) int
+	lock                sync.Mutex
+	running             map[uint64]*pipelineResources
+}
+
+func newPipelineScheduler(maxParallel, maxParallelPerConnection int, maxParallelOfPlugin func(string) int) *pipelineScheduler {
+	return &pipelineScheduler{
+		maxParallel:              maxParallel,
+		maxParallelPerConnection: maxParallelPerConnection,
+		maxParallelOfPlugin:      maxParallelOfPlugin,
+		running:                  make(map[uint64]*pipelineResources),
+	}
+}
+
+func (s *pipelineScheduler) start(pipelineId uint64, resources *pipelineResources) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.running[pipelineId] = resources
+}
+
+func (s *pipelineScheduler) finish(pipelineId uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.running, pipelineId)
+}
+
+func (s *pipelineScheduler) snapshot() map[uint64]*pipelineResources {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	running := make(map[uint64]*pipelineResources, len(s.running))
+	for id, resources := range s.running {
+		running[id] = resources
+	}
+	return running
+}
+
+// blockedBy returns the reason why a pipeline with the resources can not run alongside the running ones
+func (s *pipelineScheduler) blockedBy(running map[uint64]*pipelineResources, resources *pipelineResources) string {
+	if s.maxParallel > 0 && len(running) >= s.maxParallel {
+		return fmt.Sprintf("the limit of %d running pipelines is reached", s.maxParallel)
+	}
+	labels := make(map[string]bool)
+	plugins := make(map[string]int)
+	connections := make(map[string]int)
+	for _, r := range running {
+		for _, label := range r.parallelLabels {
+			labels[label] = true
+		}
+		for _, pluginName := range r.plugins {
+			plugins[pluginName]++
+		}
+		for _, connection := range r.connections {
+			connections[connection]++
+		}
+	}
+	for _, label := range resources.parallelLabels {
+		if labels[label] {
+			return fmt.Sprintf("another pipeline labeled %s is running", label)
+		}
+	}
+	for _, pluginName := range resources.plugins {
+		if limit := s.maxParallelOfPlugin(pluginName); limit > 0 && plugins[pluginName] >= limit {
+			return fmt.Sprintf("the limit of %d running pipelines using plugin %s is reached", limit, pluginName)
+		}
+	}
+	if s.maxParallelPerConnection > 0 {
+		for _, connection := range resources.connections {
+			if connections[connection] >= s.maxParallelPerConnection {
+				return fmt.Sprintf("the limit of %d running pipelines using connection %s is reached", s.maxParallelPerConnection, connection)
+			}
+		}
+	}
+	return ""
+}
+
+// schedule returns why each of the pending pipelines can not be started, in the given order,
+// pipelines with an empty reason are assumed to be started before the ones behind them are checked
+func (s *pipelineScheduler) schedule(pending []*pendingPipeline) []string {
+	running := s.snapshot()
+	reasons := make([]string, len(pending))
+	for i, p := range pending {
+		reasons[i] = s.blockedBy(running, p.resources)
+		if reasons[i] == "" {
+			running[p.pipeline.ID] = p.resources
+		}
+	}
+	return reasons
+}
+
+// next returns the first pending pipeline which could be started right now, nil if there is none
+func (s *pipelineScheduler) next() (*pendingPipeline, errors.Error) {
+	pending, err := loadPendingPipelines()
+	if err != nil {
+		return nil, err
+	}
+	running := s.snapshot()
+	for _, p := range pending {
+		if s.blockedBy(running, p.resources) == "" {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+// loadPendingPipelines loads pipelines waiting to run in the order they should be started
+func loadPendingPipelines() ([]*pendingPipeline, errors.Error) {
+	pipelines := make([]*models.Pipeline, 0)
+	err := db.All(
+		&pipelines,
+		dal.Where("status IN ?", []string{models.TASK_CREATED, models.TASK_RERUN}),
+		dal.Orderby("priority DESC, id ASC"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading pending pipelines")
+	}
+	if len(pipelines) == 0 {
+		return nil, nil
+	}
+	pipelineIds := make([]uint64, len(pipelines))
+	for i, pipeline := range pipelines {
+		pipelineIds[i] = pipeline.ID
+	}
+	labels := make([]models.DbPipelineLabel, 0)
+	err = db.All(&labels, dal.Where("pipeline_id IN ?", pipelineIds))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading labels of pending pipelines")
+	}
+	pending := make([]*pendingPipeline, len(pipelines))
+	for i, pipeline := range pipelines {
+		for _, label := range labels {
+			if label.PipelineId == pipeline.ID {
+				pipeline.Labels = append(pipeline.Labels, label.Name)
+			}
+		}
+		pending[i] = &pendingPipeline{
+			pipeline:  pipeline,
+			resources: getPipelineResources(pipeline),
+		}
+	}
+	return pending, nil
+}
+
+// getPipelineResources collects the parallel labels, plugins and connections of the pipeline
+func getPipelineResources(pipeline *models.Pipeline) *pipelineResources {
+	resources := &pipelineResources{}
+	for _, label := range pipeline.Labels {
+		if strings.HasPrefix(label, `parallel/`) {
+			resources.parallelLabels = append(resources.parallelLabels, label)
+		}
+	}
+	var plan plugin.PipelinePlan
+	if err := json.Unmarshal(pipeline.Plan, &plan); err != nil {
+		// the pipeline would fail on the same error once started, nothing to be occupied
+		globalPipelineLog.Warn(err, "failed to parse the plan of pipeline #%d", pipeline.ID)
+		return resources
+	}
+	for _, stage := range plan {
+		for _, task := range stage {
+			if task == nil {
+				continue
+			}
+			resources.plugins = append(resources.plugins, task.Plugin)
+			connectionId := fmt.Sprintf("%v", task.Options["connectionId"])
+			if connectionId != "" && connectionId != "0" && connectionId != "<nil>" {
+				resources.connections = append(resources.connections, fmt.Sprintf("%s:%s", task.Plugin, connectionId))
+			}
+		}
+	}
+	resources.plugins = utils.StringsUniq(resources.plugins)
+	resources.connections = utils.StringsUniq(resources.connections)
+	return resources
+}
+
+// GetPipelineQueue returns the pending pipelines in the order they are going to be started
+func GetPipelineQueue() ([]*QueuedPipeline, errors.Error) {
+	pending, err := loadPendingPipelines()
+	if err != nil {
+		return nil, err
+	}
+	reasons := pipelineQueue.schedule(pending)
+	queue := make([]*QueuedPipeline, len(pending))
+	for i, p := range pending {
+		queue[i] = &QueuedPipeline{
+			ID:          p.pipeline.ID,
+			Name:        p.pipeline.Name,
+			BlueprintId: p.pipeline.BlueprintId,
+			Status:      p.pipeline.Status,
+			Priority:    p.pipeline.Priority,
+			Labels:      p.pipeline.Labels,
+			CreatedAt:   p.pipeline.CreatedAt,
+			Position:    i + 1,
+			BlockedBy:   reasons[i],
+		}
+	}
+	return queue, nil
+}
+
+func getPipelineMaxParallelOfPlugin(pluginName string) int {
+	return cfg.GetInt(fmt.Sprintf("PIPELINE_MAX_PARALLEL_%s", strings.ToUpper(pluginName)))
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPipelineResources(t *testing.T) {
+	pipeline := &models.Pipeline{
+		Labels: []string{"parallel/jira", "team-a"},
+		Plan: []byte(`[
+			[{"plugin": "jira", "options": {"connectionId": 1, "boardId": 8}}, {"plugin": "jira", "options": {"connectionId": 1, "boardId": 9}}],
+			[{"plugin": "gitextractor", "options": {"url": "https://github.com/apache/incubator-devlake.git"}}]
+		]`),
+	}
+	resources := getPipelineResources(pipeline)
+	assert.Equal(t, []string{"parallel/jira"}, resources.parallelLabels)
+	assert.Equal(t, []string{"jira", "gitextractor"}, resources.plugins)
+	assert.Equal(t, []string{"jira:1"}, resources.connections)
+}
+
+func TestPipelineSchedulerBlockedBy(t *testing.T) {
+	scheduler := newPipelineScheduler(3, 1, func(pluginName string) int {
+		if pluginName == "github" {
+			return 1
+		}
+		return 0
+	})
+	scheduler.start(1, &pipelineResources{plugins: []string{"jira"}, connections: []string{"jira:1"}, parallelLabels: []string{"parallel/a"}})
+	scheduler.start(2, &pipelineResources{plugins: []string{"github"}, connections: []string{"github:1"}})
+	running := scheduler.snapshot()
+
+	assert.Equal(t, "", scheduler.blockedBy(running, &pipelineResources{plugins: []string{"jira"}, connections: []string{"jira:2"}}))
+	assert.Equal(t, "the limit of 1 running pipelines using connection jira:1 is reached",
+		scheduler.blockedBy(running, &pipelineResources{plugins: []string{"jira"}, connections: []string{"jira:1"}}))
+	assert.Equal(t, "the limit of 1 running pipelines using plugin github is reached",
+		scheduler.blockedBy(running, &pipelineResources{plugins: []string{"github"}, connections: []string{"github:2"}}))
+	assert.Equal(t, "another pipeline labeled parallel/a is running",
+		scheduler.blockedBy(running, &pipelineResources{parallelLabels: []string{"parallel/a"}}))
+
+	scheduler.start(3, &pipelineResources{})
+	assert.Equal(t, "the limit of 3 running pipelines is reached", scheduler.blockedBy(scheduler.snapshot(), &pipelineResources{}))
+
+	scheduler.finish(1)
+	scheduler.finish(3)
+	assert.Equal(t, "", scheduler.blockedBy(scheduler.snapshot(), &pipelineResources{plugins: []string{"jira"}, connections: []string{"jira:1"}}))
+}
+
+func TestPipelineSchedulerSchedule(t *testing.T) {
+	scheduler := newPipelineScheduler(2, 1, func(string) int { return 0 })
+	pending := []*pendingPipeline{
+		{pipeline: &models.Pipeline{Priority: models.PIPELINE_PRIORITY_MANUAL}, resources: &pipelineResources{connections: []string{"jira:1"}}},
+		{pipeline: &models.Pipeline{Priority: models.PIPELINE_PRIORITY_CRON}, resources: &pipelineResources{connections: []string{"jira:1"}}},
+		{pipeline: &models.Pipeline{Priority: models.PIPELINE_PRIORITY_CRON}, resources: &pipelineResources{connections: []string{"jira:2"}}},
+		{pipeline: &models.Pipeline{Priority: models.PIPELINE_PRIORITY_CRON}, resources: &pipelineResources{connections: []string{"jira:3"}}},
+	}
+	for i, p := range pending {
+		p.pipeline.ID = uint64(i + 1)
+	}
+	reasons := scheduler.schedule(pending)
+	assert.Equal(t, []string{
+		"",
+		"the limit of 1 running pipelines using connection jira:1 is reached",
+		"",
+		"the limit of 2 running pipelines is reached",
+	}, reasons)
+	// nothing is actually started by scheduling
+	assert.Empty(t, scheduler.snapshot())
+}
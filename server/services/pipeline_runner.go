@@ -35,16 +35,41 @@ import (
 type pipelineRunner struct {
 	logger   log.Logger
 	pipeline *models.Pipeline
+	timeout  time.Duration
+	timedOut bool
 }
 
 func (p *pipelineRunner) runPipelineStandalone() errors.Error {
-	return runner.RunPipeline(
+	ctx, cancel := p.newContext()
+	defer cancel()
+	err := runner.RunPipeline(
 		basicRes.ReplaceLogger(p.logger),
 		p.pipeline.ID,
 		func(taskIds []uint64) errors.Error {
-			return RunTasksStandalone(p.logger, taskIds)
+			return RunTasksStandalone(ctx, p.logger, taskIds)
 		},
 	)
+	if ctx.Err() == context.DeadlineExceeded {
+		p.timedOut = true
+		return p.timeoutError(err)
+	}
+	return err
+}
+
+// newContext creates the context carrying the PIPELINE_TIMEOUT deadline
+func (p *pipelineRunner) newContext() (context.Context, context.CancelFunc) {
+	if p.timeout > 0 {
+		return context.WithTimeout(context.Background(), p.timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+func (p *pipelineRunner) timeoutError(err error) errors.Error {
+	message := fmt.Sprintf("pipeline timed out after %s", p.timeout)
+	if err != nil {
+		return errors.Timeout.Wrap(err, message)
+	}
+	return errors.Timeout.New(message)
 }
 
 func (p *pipelineRunner) runPipelineViaTemporal() errors.Error {
@@ -70,7 +95,19 @@ func (p *pipelineRunner) runPipelineViaTemporal() errors.Error {
 		p.logger.Error(err, "failed to enqueue pipeline #%d into temporal", p.pipeline.ID)
 		return errors.Convert(err)
 	}
-	err = workflow.Get(context.Background(), nil)
+	ctx, cancel := p.newContext()
+	defer cancel()
+	err = workflow.Get(ctx, nil)
+	if ctx.Err() == context.DeadlineExceeded {
+		// cancel the workflow and wait for the activities to finish their cancellation
+		p.timedOut = true
+		p.logger.Info("pipeline #%d timed out, cancelling the workflow", p.pipeline.ID)
+		err = temporalClient.CancelWorkflow(context.Background(), workflowOpts.ID, "")
+		if err == nil {
+			err = workflow.Get(context.Background(), nil)
+		}
+		return p.timeoutError(err)
+	}
 	if err != nil {
 		p.logger.Info("failed to execute pipeline #%d via temporal: %v", p.pipeline.ID, err)
 	}
@@ -105,6 +142,7 @@ func runPipeline(pipelineId uint64) errors.Error {
 	pipelineRun := pipelineRunner{
 		logger:   GetPipelineLogger(ppl),
 		pipeline: ppl,
+		timeout:  runner.GetPipelineTimeout(basicRes),
 	}
 	// run
 	if temporalClient != nil {
@@ -130,11 +168,14 @@ func runPipeline(pipelineId uint64) errors.Error {
 		dbPipeline.Message = err.Error()
 		dbPipeline.ErrorName = err.Messages().Format()
 	}
-	dbPipeline.Status, err = ComputePipelineStatus(dbPipeline, isCancelled)
+	dbPipeline.Status, err = ComputePipelineStatus(dbPipeline, isCancelled || pipelineRun.timedOut)
 	if err != nil {
 		globalPipelineLog.Error(err, "compute pipeline status failed")
 		return err
 	}
+	if pipelineRun.timedOut {
+		dbPipeline.Status = models.TASK_TIMEOUT
+	}
 	err = db.Update(dbPipeline)
 	if err != nil {
 		globalPipelineLog.Error(err, "update pipeline state failed")
@@ -148,6 +189,7 @@ func runPipeline(pipelineId uint64) errors.Error {
 // 1. TASK_COMPLETED: all tasks were executed sucessfully
 // 2. TASK_FAILED: SkipOnFail=false with failed task(s)
 // 3. TASK_PARTIAL: SkipOnFail=true with failed task(s)
+// timed out tasks are counted as failed ones, while the TASK_TIMEOUT of the pipeline itself is decided by runPipeline
 func ComputePipelineStatus(pipeline *models.Pipeline, isCancelled bool) (string, errors.Error) {
 	tasks, err := GetLatestTasksOfPipeline(pipeline)
 	if err != nil {
@@ -159,7 +201,7 @@ func ComputePipelineStatus(pipeline *models.Pipeline, isCancelled bool) (string,
 	for _, task := range tasks {
 		if task.Status == models.TASK_COMPLETED {
 			succeeded += 1
-		} else if task.Status == models.TASK_FAILED || task.Status == models.TASK_CANCELLED {
+		} else if task.Status == models.TASK_FAILED || task.Status == models.TASK_CANCELLED || task.Status == models.TASK_TIMEOUT {
 			failed += 1
 		} else if task.Status == models.TASK_RUNNING {
 			running += 1
@@ -0,0 +1,282 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/utils"
+)
+
+// ProjectMetricQuery used to filter and group the metrics of a project
+type ProjectMetricQuery struct {
+	StartDate *time.Time `form:"startDate" time_format:"2006-01-02"`
+	EndDate   *time.Time `form:"endDate" time_format:"2006-01-02"`
+	GroupBy   string     `form:"groupBy" validate:"omitempty,oneof=WEEK MONTH"`
+}
+
+// ProjectPrMetricAggregate is the aggregated pr cycle time of a period, all times are in minutes
+type ProjectPrMetricAggregate struct {
+	PeriodStart       *time.Time `json:"periodStart,omitempty"`
+	PeriodEnd         *time.Time `json:"periodEnd,omitempty"`
+	PrCount           int        `json:"prCount"`
+	AvgPrCodingTime   *float64   `json:"avgPrCodingTime"`
+	AvgPrPickupTime   *float64   `json:"avgPrPickupTime"`
+	AvgPrReviewTime   *float64   `json:"avgPrReviewTime"`
+	AvgPrDeployTime   *float64   `json:"avgPrDeployTime"`
+	AvgPrCycleTime    *float64   `json:"avgPrCycleTime"`
+	MedianPrCycleTime *int64     `json:"medianPrCycleTime"`
+	prCodingTimes     []int64
+	prPickupTimes     []int64
+	prReviewTimes     []int64
+	prDeployTimes     []int64
+	prCycleTimes      []int64
+}
+
+// ProjectIssueMetricAggregate is the aggregated lead time of issues resolved in a period, all times are in minutes
+type ProjectIssueMetricAggregate struct {
+	PeriodStart           *time.Time `json:"periodStart,omitempty"`
+	PeriodEnd             *time.Time `json:"periodEnd,omitempty"`
+	IssueCount            int        `json:"issueCount"`
+	IncidentCount         int        `json:"incidentCount"`
+	DeploymentCausedCount int        `json:"deploymentCausedCount"`
+	AvgLeadTime           *float64   `json:"avgLeadTime"`
+	MedianLeadTime        *int64     `json:"medianLeadTime"`
+	leadTimes             []int64
+}
+
+// ProjectMetrics holds all metrics of a project
+type ProjectMetrics struct {
+	ProjectName  string                           `json:"projectName"`
+	GroupBy      string                           `json:"groupBy"`
+	Dora         []*crossdomain.ProjectDoraMetric `json:"dora"`
+	PullRequests []*ProjectPrMetricAggregate      `json:"pullRequests"`
+	Issues       []*ProjectIssueMetricAggregate   `json:"issues"`
+}
+
+type projectPrMetricRow struct {
+	crossdomain.ProjectPrMetric
+	MergedDate *time.Time
+}
+
+type projectIssueMetricRow struct {
+	Id              string
+	Type            string
+	ResolutionDate  *time.Time
+	LeadTimeMinutes int64
+	DeploymentId    string
+}
+
+// GetProjectMetrics returns dora, pr cycle time and issue lead time metrics of a project
+func GetProjectMetrics(name string, query *ProjectMetricQuery) (*ProjectMetrics, errors.Error) {
+	dora, err := GetProjectDoraMetrics(name, query)
+	if err != nil {
+		return nil, err
+	}
+	prs, err := GetProjectPrMetrics(name, query)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := GetProjectIssueMetrics(name, query)
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectMetrics{
+		ProjectName:  name,
+		GroupBy:      query.GroupBy,
+		Dora:         dora,
+		PullRequests: prs,
+		Issues:       issues,
+	}, nil
+}
+
+// GetProjectDoraMetrics returns the dora rollups of a project, grouped by month unless
+// WEEK is specified since the rollups are calculated per period by the dora plugin
+func GetProjectDoraMetrics(name string, query *ProjectMetricQuery) ([]*crossdomain.ProjectDoraMetric, errors.Error) {
+	if err := verifyProjectMetricQuery(name, query); err != nil {
+		return nil, err
+	}
+	period := query.GroupBy
+	if period == "" {
+		period = crossdomain.DORA_PERIOD_MONTH
+	}
+	clauses := []dal.Clause{
+		dal.From(&crossdomain.ProjectDoraMetric{}),
+		dal.Where("project_name = ? AND period = ?", name, period),
+	}
+	if query.StartDate != nil {
+		clauses = append(clauses, dal.Where("period_end > ?", *query.StartDate))
+	}
+	if query.EndDate != nil {
+		clauses = append(clauses, dal.Where("period_start <= ?", *query.EndDate))
+	}
+	clauses = append(clauses, dal.Orderby("period_start"))
+	metrics := make([]*crossdomain.ProjectDoraMetric, 0)
+	err := db.All(&metrics, clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting DB project dora metrics")
+	}
+	return metrics, nil
+}
+
+// GetProjectPrMetrics returns the pr cycle time of a project aggregated by merged date
+func GetProjectPrMetrics(name string, query *ProjectMetricQuery) ([]*ProjectPrMetricAggregate, errors.Error) {
+	if err := verifyProjectMetricQuery(name, query); err != nil {
+		return nil, err
+	}
+	clauses := []dal.Clause{
+		dal.Select("ppm.*, pr.merged_date"),
+		dal.From("project_pr_metrics ppm"),
+		dal.Join("INNER JOIN pull_requests pr ON (pr.id = ppm.id)"),
+		dal.Where("ppm.project_name = ? AND pr.merged_date IS NOT NULL", name),
+	}
+	if query.StartDate != nil {
+		clauses = append(clauses, dal.Where("pr.merged_date >= ?", *query.StartDate))
+	}
+	if query.EndDate != nil {
+		clauses = append(clauses, dal.Where("pr.merged_date < ?", query.EndDate.AddDate(0, 0, 1)))
+	}
+	rows := make([]*projectPrMetricRow, 0)
+	err := db.All(&rows, clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting DB project pr metrics")
+	}
+
+	aggregates := make(map[time.Time]*ProjectPrMetricAggregate)
+	for _, row := range rows {
+		start, end := metricPeriodOf(query.GroupBy, *row.MergedDate)
+		aggregate, ok := aggregates[start]
+		if !ok {
+			aggregate = &ProjectPrMetricAggregate{}
+			if query.GroupBy != "" {
+				aggregate.PeriodStart, aggregate.PeriodEnd = &start, &end
+			}
+			aggregates[start] = aggregate
+		}
+		aggregate.PrCount++
+		aggregate.prCodingTimes = appendIfNotNil(aggregate.prCodingTimes, row.PrCodingTime)
+		aggregate.prPickupTimes = appendIfNotNil(aggregate.prPickupTimes, row.PrPickupTime)
+		aggregate.prReviewTimes = appendIfNotNil(aggregate.prReviewTimes, row.PrReviewTime)
+		aggregate.prDeployTimes = appendIfNotNil(aggregate.prDeployTimes, row.PrDeployTime)
+		aggregate.prCycleTimes = appendIfNotNil(aggregate.prCycleTimes, row.PrCycleTime)
+	}
+	result := make([]*ProjectPrMetricAggregate, 0, len(aggregates))
+	for _, aggregate := range aggregates {
+		aggregate.AvgPrCodingTime = utils.Average(aggregate.prCodingTimes)
+		aggregate.AvgPrPickupTime = utils.Average(aggregate.prPickupTimes)
+		aggregate.AvgPrReviewTime = utils.Average(aggregate.prReviewTimes)
+		aggregate.AvgPrDeployTime = utils.Average(aggregate.prDeployTimes)
+		aggregate.AvgPrCycleTime = utils.Average(aggregate.prCycleTimes)
+		aggregate.MedianPrCycleTime = utils.Median(aggregate.prCycleTimes)
+		result = append(result, aggregate)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PeriodStart != nil && result[i].PeriodStart.Before(*result[j].PeriodStart)
+	})
+	return result, nil
+}
+
+// GetProjectIssueMetrics returns the lead time of issues of a project aggregated by resolution date
+func GetProjectIssueMetrics(name string, query *ProjectMetricQuery) ([]*ProjectIssueMetricAggregate, errors.Error) {
+	if err := verifyProjectMetricQuery(name, query); err != nil {
+		return nil, err
+	}
+	clauses := []dal.Clause{
+		dal.Select("DISTINCT i.id, i.type, i.resolution_date, i.lead_time_minutes, pim.deployment_id"),
+		dal.From("issues i"),
+		dal.Join("INNER JOIN board_issues bi ON (bi.issue_id = i.id)"),
+		dal.Join("INNER JOIN project_mapping pm ON (pm.table = 'boards' AND pm.row_id = bi.board_id)"),
+		dal.Join("LEFT JOIN project_issue_metrics pim ON (pim.id = i.id AND pim.project_name = pm.project_name)"),
+		dal.Where("pm.project_name = ? AND i.resolution_date IS NOT NULL", name),
+	}
+	if query.StartDate != nil {
+		clauses = append(clauses, dal.Where("i.resolution_date >= ?", *query.StartDate))
+	}
+	if query.EndDate != nil {
+		clauses = append(clauses, dal.Where("i.resolution_date < ?", query.EndDate.AddDate(0, 0, 1)))
+	}
+	rows := make([]*projectIssueMetricRow, 0)
+	err := db.All(&rows, clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting DB project issue metrics")
+	}
+
+	aggregates := make(map[time.Time]*ProjectIssueMetricAggregate)
+	for _, row := range rows {
+		start, end := metricPeriodOf(query.GroupBy, *row.ResolutionDate)
+		aggregate, ok := aggregates[start]
+		if !ok {
+			aggregate = &ProjectIssueMetricAggregate{}
+			if query.GroupBy != "" {
+				aggregate.PeriodStart, aggregate.PeriodEnd = &start, &end
+			}
+			aggregates[start] = aggregate
+		}
+		aggregate.IssueCount++
+		if row.Type == ticket.INCIDENT {
+			aggregate.IncidentCount++
+		}
+		if row.DeploymentId != "" {
+			aggregate.DeploymentCausedCount++
+		}
+		if row.LeadTimeMinutes > 0 {
+			aggregate.leadTimes = append(aggregate.leadTimes, row.LeadTimeMinutes)
+		}
+	}
+	result := make([]*ProjectIssueMetricAggregate, 0, len(aggregates))
+	for _, aggregate := range aggregates {
+		aggregate.AvgLeadTime = utils.Average(aggregate.leadTimes)
+		aggregate.MedianLeadTime = utils.Median(aggregate.leadTimes)
+		result = append(result, aggregate)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PeriodStart != nil && result[i].PeriodStart.Before(*result[j].PeriodStart)
+	})
+	return result, nil
+}
+
+func verifyProjectMetricQuery(name string, query *ProjectMetricQuery) errors.Error {
+	if name == "" {
+		return errors.BadInput.New("project name is missing")
+	}
+	if err := VerifyStruct(query); err != nil {
+		return err
+	}
+	_, err := getProjectByName(db, name)
+	return err
+}
+
+// metricPeriodOf returns the period that t falls in, or zero values when the metrics are not grouped
+func metricPeriodOf(groupBy string, t time.Time) (time.Time, time.Time) {
+	if groupBy == "" {
+		return time.Time{}, time.Time{}
+	}
+	return crossdomain.DoraPeriodOf(groupBy, t)
+}
+
+func appendIfNotNil(values []int64, value *int64) []int64 {
+	if value == nil {
+		return values
+	}
+	return append(values, *value)
+}
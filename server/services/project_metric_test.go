@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMetricPeriodOf(t *testing.T) {
+	day := time.Date(2023, 5, 17, 13, 30, 0, 0, time.UTC)
+	start, end := metricPeriodOf(crossdomain.DORA_PERIOD_WEEK, day)
+	assert.Equal(t, time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2023, 5, 22, 0, 0, 0, 0, time.UTC), end)
+	start, end = metricPeriodOf(crossdomain.DORA_PERIOD_MONTH, day)
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), end)
+	start, end = metricPeriodOf("", day)
+	assert.True(t, start.IsZero())
+	assert.True(t, end.IsZero())
+}
+
+func mockProjectMetricsDal(projectName string) *mockdal.Dal {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.AnythingOfType("*models.Project"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Project).Name = projectName
+	})
+	mockDal.On("All", mock.AnythingOfType("*[]*crossdomain.ProjectDoraMetric"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]*crossdomain.ProjectDoraMetric) = []*crossdomain.ProjectDoraMetric{
+			{ProjectName: projectName, Period: crossdomain.DORA_PERIOD_MONTH, DeploymentCount: 3},
+		}
+	})
+	mockDal.On("All", mock.AnythingOfType("*[]*services.projectPrMetricRow"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		cycleTime := func(minutes int64) *int64 { return &minutes }
+		mergedDate := func(t time.Time) *time.Time { return &t }
+		*args.Get(0).(*[]*projectPrMetricRow) = []*projectPrMetricRow{
+			{ProjectPrMetric: crossdomain.ProjectPrMetric{PrCycleTime: cycleTime(30)}, MergedDate: mergedDate(time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC))},
+			{ProjectPrMetric: crossdomain.ProjectPrMetric{PrCycleTime: cycleTime(60)}, MergedDate: mergedDate(time.Date(2023, 5, 30, 0, 0, 0, 0, time.UTC))},
+			{ProjectPrMetric: crossdomain.ProjectPrMetric{PrCycleTime: cycleTime(90)}, MergedDate: mergedDate(time.Date(2023, 4, 10, 0, 0, 0, 0, time.UTC))},
+			{MergedDate: mergedDate(time.Date(2023, 4, 20, 0, 0, 0, 0, time.UTC))},
+		}
+	})
+	mockDal.On("All", mock.AnythingOfType("*[]*services.projectIssueMetricRow"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		resolutionDate := time.Date(2023, 5, 17, 0, 0, 0, 0, time.UTC)
+		*args.Get(0).(*[]*projectIssueMetricRow) = []*projectIssueMetricRow{
+			{Id: "1", Type: ticket.INCIDENT, ResolutionDate: &resolutionDate, LeadTimeMinutes: 100, DeploymentId: "d1"},
+			{Id: "2", Type: ticket.BUG, ResolutionDate: &resolutionDate, LeadTimeMinutes: 200},
+			{Id: "3", Type: ticket.REQUIREMENT, ResolutionDate: &resolutionDate},
+		}
+	})
+	return mockDal
+}
+
+func TestGetProjectMetrics(t *testing.T) {
+	vld = validator.New()
+	mockDal := mockProjectMetricsDal("team/metrics")
+	db = mockDal
+	defer func() { db = nil }()
+
+	metrics, err := GetProjectMetrics("team/metrics", &ProjectMetricQuery{GroupBy: crossdomain.DORA_PERIOD_MONTH})
+	assert.Nil(t, err)
+	assert.Equal(t, "team/metrics", metrics.ProjectName)
+	assert.Len(t, metrics.Dora, 1)
+	assert.Equal(t, 3, metrics.Dora[0].DeploymentCount)
+
+	// pull requests are grouped by the month they were merged in, sorted by period
+	assert.Len(t, metrics.PullRequests, 2)
+	april, may := metrics.PullRequests[0], metrics.PullRequests[1]
+	assert.Equal(t, time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), *april.PeriodStart)
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), *april.PeriodEnd)
+	assert.Equal(t, 2, april.PrCount)
+	assert.Equal(t, 90.0, *april.AvgPrCycleTime)
+	assert.Nil(t, april.AvgPrCodingTime)
+	assert.Equal(t, 2, may.PrCount)
+	assert.Equal(t, 45.0, *may.AvgPrCycleTime)
+	assert.Equal(t, int64(45), *may.MedianPrCycleTime)
+
+	assert.Len(t, metrics.Issues, 1)
+	issues := metrics.Issues[0]
+	assert.Equal(t, 3, issues.IssueCount)
+	assert.Equal(t, 1, issues.IncidentCount)
+	assert.Equal(t, 1, issues.DeploymentCausedCount)
+	assert.Equal(t, 150.0, *issues.AvgLeadTime)
+	assert.Equal(t, int64(150), *issues.MedianLeadTime)
+}
+
+func TestGetProjectMetricsUngrouped(t *testing.T) {
+	vld = validator.New()
+	db = mockProjectMetricsDal("p")
+	defer func() { db = nil }()
+
+	prs, err := GetProjectPrMetrics("p", &ProjectMetricQuery{})
+	assert.Nil(t, err)
+	assert.Len(t, prs, 1)
+	assert.Nil(t, prs[0].PeriodStart)
+	assert.Equal(t, 4, prs[0].PrCount)
+	assert.Equal(t, int64(60), *prs[0].MedianPrCycleTime)
+}
+
+func TestGetProjectMetricsInvalid(t *testing.T) {
+	vld = validator.New()
+	mockDal := new(mockdal.Dal)
+	notFound := errors.NotFound.New("record not found")
+	mockDal.On("First", mock.Anything, mock.Anything).Return(notFound)
+	mockDal.On("IsErrorNotFound", notFound).Return(true)
+	db = mockDal
+	defer func() { db = nil }()
+
+	_, err := GetProjectDoraMetrics("", &ProjectMetricQuery{})
+	assert.Equal(t, errors.BadInput, err.GetType())
+	_, err = GetProjectDoraMetrics("p", &ProjectMetricQuery{GroupBy: "DAY"})
+	assert.Equal(t, errors.BadInput, err.GetType())
+	_, err = GetProjectIssueMetrics("missing", &ProjectMetricQuery{})
+	assert.Equal(t, errors.NotFound, err.GetType())
+	mockDal.AssertNotCalled(t, "All", mock.Anything, mock.Anything)
+}
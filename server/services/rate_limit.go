@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// RateLimitQuery is a query for GetConnectionRateLimits
+type RateLimitQuery struct {
+	Plugin       string `form:"plugin"`
+	ConnectionId uint64 `form:"connectionId"`
+}
+
+// GetConnectionRateLimits returns the rate limits of connections used by tasks running in this process
+func GetConnectionRateLimits(query *RateLimitQuery) []*helper.ConnectionRateLimit {
+	limits := make([]*helper.ConnectionRateLimit, 0)
+	for _, limit := range helper.GetConnectionRateLimits() {
+		if query.Plugin != "" && limit.Plugin != query.Plugin {
+			continue
+		}
+		if query.ConnectionId != 0 && limit.ConnectionId != query.ConnectionId {
+			continue
+		}
+		limits = append(limits, limit)
+	}
+	return limits
+}
@@ -95,11 +95,14 @@ func (pa *pluginAPI) GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResour
 }
 
 func (pa *pluginAPI) DeleteScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	refs, err := pa.scopeHelper.DeleteScope(input)
+	refs, deletion, err := pa.scopeHelper.DeleteScope(input)
 	if err != nil {
-		return &plugin.ApiResourceOutput{Body: refs, Status: err.GetType().GetHttpCode()}, nil
+		if refs != nil {
+			return &plugin.ApiResourceOutput{Body: refs, Status: err.GetType().GetHttpCode()}, nil
+		}
+		return &plugin.ApiResourceOutput{Body: deletion, Status: err.GetType().GetHttpCode()}, nil
 	}
-	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+	return &plugin.ApiResourceOutput{Body: deletion, Status: http.StatusAccepted}, nil
 }
 
 // convertScopeResponse adapt the "remote" scopes to a serializable api.ScopeRes. This code is needed because squashed mapstructure don't work
@@ -0,0 +1,402 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/robfig/cron/v3"
+)
+
+const retentionBatchSize = 1000
+
+var retentionLog = logruslog.Global.Nested("retention janitor")
+var retentionLocker sync.Mutex
+
+// written by the janitor and read by the api concurrently
+var lastRetentionReport atomic.Pointer[RetentionReport]
+
+// RetentionPolicy describes how long the raw data and the pipeline history are kept, zero disables a rule
+type RetentionPolicy struct {
+	// Cron is the schedule of the janitor, in the standard cron format
+	Cron string `json:"cron"`
+	// RawDataDays prunes `_raw_*` rows collected more than N days ago
+	RawDataDays int `json:"rawDataDays"`
+	// PipelinesPerBlueprint keeps only the last K pipelines of every blueprint
+	PipelinesPerBlueprint int `json:"pipelinesPerBlueprint"`
+	// PipelineDays prunes pipelines created more than N days ago, the last pipeline of a blueprint is always kept
+	PipelineDays int `json:"pipelineDays"`
+}
+
+// Enabled returns true if any rule was configured
+func (p *RetentionPolicy) Enabled() bool {
+	return p.RawDataDays > 0 || p.PipelinesPerBlueprint > 0 || p.PipelineDays > 0
+}
+
+// RetentionTableReport is the number of rows and bytes reclaimed from a raw table
+type RetentionTableReport struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+	Bytes int64  `json:"bytes"`
+}
+
+// RetentionReport summarizes a run of the retention janitor
+type RetentionReport struct {
+	DryRun     bool                    `json:"dryRun"`
+	StartedAt  time.Time               `json:"startedAt"`
+	FinishedAt time.Time               `json:"finishedAt"`
+	Policy     RetentionPolicy         `json:"policy"`
+	RawTables  []*RetentionTableReport `json:"rawTables"`
+	RawRows    int64                   `json:"rawRows"`
+	RawBytes   int64                   `json:"rawBytes"`
+	// RawDataSkipped is set when raw data was not pruned because pipelines were running
+	RawDataSkipped bool   `json:"rawDataSkipped"`
+	Pipelines      int64  `json:"pipelines"`
+	Tasks          int64  `json:"tasks"`
+	Subtasks       int64  `json:"subtasks"`
+	LogFiles       int64  `json:"logFiles"`
+	LogBytes       int64  `json:"logBytes"`
+	Error          string `json:"error,omitempty"`
+}
+
+// GetRetentionPolicy returns the retention policy configured by the RETENTION_* variables
+func GetRetentionPolicy() *RetentionPolicy {
+	policy := &RetentionPolicy{
+		Cron:                  cfg.GetString("RETENTION_CRON"),
+		RawDataDays:           cfg.GetInt("RETENTION_RAW_DATA_DAYS"),
+		PipelinesPerBlueprint: cfg.GetInt("RETENTION_PIPELINES_PER_BLUEPRINT"),
+		PipelineDays:          cfg.GetInt("RETENTION_PIPELINE_DAYS"),
+	}
+	if policy.Cron == "" {
+		policy.Cron = "0 3 * * *"
+	}
+	return policy
+}
+
+// GetLastRetentionReport returns the report of the last janitor run, nil if it never ran
+func GetLastRetentionReport() *RetentionReport {
+	return lastRetentionReport.Load()
+}
+
+// startRetentionJanitor schedules the janitor if any retention rule was configured. It uses its own cron
+// instance because the blueprint cron entries are reset whenever blueprints get reloaded.
+func startRetentionJanitor() {
+	policy := GetRetentionPolicy()
+	if !policy.Enabled() {
+		return
+	}
+	c := cron.New(cron.WithLocation(time.UTC))
+	_, err := c.AddFunc(policy.Cron, func() {
+		_, err := RunRetentionJanitor(false)
+		if err != nil {
+			retentionLog.Error(err, "retention janitor failed")
+		}
+	})
+	if err != nil {
+		panic(errors.BadInput.Wrap(err, "invalid RETENTION_CRON"))
+	}
+	c.Start()
+	retentionLog.Info("retention janitor scheduled at [%s]", policy.Cron)
+}
+
+// RunRetentionJanitor enforces the retention policy once, nothing gets deleted when dryRun is true
+func RunRetentionJanitor(dryRun bool) (*RetentionReport, errors.Error) {
+	if !retentionLocker.TryLock() {
+		return nil, errors.Conflict.New("the retention janitor is running")
+	}
+	defer retentionLocker.Unlock()
+	policy := GetRetentionPolicy()
+	report := &RetentionReport{DryRun: dryRun, StartedAt: time.Now(), Policy: *policy}
+	err := pruneRawData(policy, report)
+	if err == nil {
+		err = prunePipelines(policy, report)
+	}
+	report.FinishedAt = time.Now()
+	if err != nil {
+		report.Error = err.Error()
+	}
+	if !dryRun {
+		lastRetentionReport.Store(report)
+	}
+	retentionLog.Info(
+		"retention janitor finished (dryRun: %v): %d raw rows / %d bytes, %d pipelines, %d tasks, %d subtasks, %d log files / %d bytes",
+		dryRun, report.RawRows, report.RawBytes, report.Pipelines, report.Tasks, report.Subtasks, report.LogFiles, report.LogBytes,
+	)
+	return report, err
+}
+
+// pruneRawData deletes raw rows older than the policy from all `_raw_*` tables. The collector states of the
+// affected params are reset as well, so the next collection is a full one instead of an incremental one
+// building on top of the pruned rows.
+func pruneRawData(policy *RetentionPolicy, report *RetentionReport) errors.Error {
+	if policy.RawDataDays <= 0 {
+		return nil
+	}
+	// an incremental collector running now would save its state after we reset it
+	running, err := db.Count(dal.From(&models.Pipeline{}), dal.Where("status = ?", models.TASK_RUNNING))
+	if err != nil {
+		return err
+	}
+	if running > 0 {
+		retentionLog.Info("skip pruning raw data since %d pipelines are running", running)
+		report.RawDataSkipped = true
+		return nil
+	}
+	tables, err := db.AllTables()
+	if err != nil {
+		return err
+	}
+	sort.Strings(tables)
+	cutoff := time.Now().AddDate(0, 0, -policy.RawDataDays)
+	for _, table := range tables {
+		if !strings.HasPrefix(table, "_raw_") {
+			continue
+		}
+		tableReport, err := pruneRawTable(table, cutoff, report.DryRun)
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to prune "+table)
+		}
+		if tableReport.Rows == 0 {
+			continue
+		}
+		report.RawTables = append(report.RawTables, tableReport)
+		report.RawRows += tableReport.Rows
+		report.RawBytes += tableReport.Bytes
+	}
+	return nil
+}
+
+func pruneRawTable(table string, cutoff time.Time, dryRun bool) (*RetentionTableReport, errors.Error) {
+	tableReport := &RetentionTableReport{Table: table}
+	outdated := dal.Where("created_at < ?", cutoff)
+	cursor, err := db.Cursor(
+		dal.Select("COUNT(*), COALESCE(SUM(OCTET_LENGTH(data)), 0)"),
+		dal.From(table),
+		outdated,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Next() {
+		err = errors.Convert(cursor.Scan(&tableReport.Rows, &tableReport.Bytes))
+	}
+	cursor.Close()
+	if err != nil || dryRun || tableReport.Rows == 0 {
+		return tableReport, err
+	}
+	var params []string
+	err = db.Pluck("DISTINCT params", &params, dal.From(table), outdated)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var ids []uint64
+		err = db.Pluck("id", &ids, dal.From(table), outdated, dal.Limit(retentionBatchSize))
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+		err = db.Exec("DELETE FROM "+table+" WHERE id IN ?", ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(params) > 0 {
+		err = db.Delete(
+			&models.CollectorLatestState{},
+			dal.Where("raw_data_table = ? AND raw_data_params IN ?", table, params),
+		)
+	}
+	return tableReport, err
+}
+
+type retentionPipeline struct {
+	ID          uint64
+	BlueprintId uint64
+	Status      string
+	CreatedAt   time.Time
+}
+
+// selectPrunablePipelines picks the pipelines violating the policy, pipelines must be sorted by id descending.
+// Pending or running pipelines and the last pipeline of every blueprint are never picked.
+func selectPrunablePipelines(pipelines []*retentionPipeline, policy *RetentionPolicy, now time.Time) []uint64 {
+	var cutoff time.Time
+	if policy.PipelineDays > 0 {
+		cutoff = now.AddDate(0, 0, -policy.PipelineDays)
+	}
+	pending := make(map[string]bool)
+	for _, status := range models.PendingTaskStatus {
+		pending[status] = true
+	}
+	ranks := make(map[uint64]int)
+	var prunable []uint64
+	for _, pipeline := range pipelines {
+		rank := 0
+		if pipeline.BlueprintId != 0 {
+			ranks[pipeline.BlueprintId]++
+			rank = ranks[pipeline.BlueprintId]
+		}
+		if pending[pipeline.Status] || rank == 1 {
+			continue
+		}
+		exceeded := rank > 0 && policy.PipelinesPerBlueprint > 0 && rank > policy.PipelinesPerBlueprint
+		outdated := !cutoff.IsZero() && pipeline.CreatedAt.Before(cutoff)
+		if exceeded || outdated {
+			prunable = append(prunable, pipeline.ID)
+		}
+	}
+	return prunable
+}
+
+// prunePipelines deletes the pipelines violating the policy along with their tasks, subtasks, labels and log files
+func prunePipelines(policy *RetentionPolicy, report *RetentionReport) errors.Error {
+	if policy.PipelinesPerBlueprint <= 0 && policy.PipelineDays <= 0 {
+		return nil
+	}
+	var pipelines []*retentionPipeline
+	err := db.All(
+		&pipelines,
+		dal.Select("id, blueprint_id, status, created_at"),
+		dal.From(&models.Pipeline{}),
+		dal.Orderby("id DESC"),
+	)
+	if err != nil {
+		return err
+	}
+	prunable := selectPrunablePipelines(pipelines, policy, time.Now())
+	for start := 0; start < len(prunable); start += retentionBatchSize {
+		end := start + retentionBatchSize
+		if end > len(prunable) {
+			end = len(prunable)
+		}
+		err = prunePipelineBatch(prunable[start:end], report)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func prunePipelineBatch(pipelineIds []uint64, report *RetentionReport) (err errors.Error) {
+	var pipelines []*models.Pipeline
+	err = db.All(&pipelines, dal.Select("id, created_at"), dal.Where("id IN ?", pipelineIds))
+	if err != nil {
+		return err
+	}
+	var taskIds []uint64
+	err = db.Pluck("id", &taskIds, dal.From(&models.Task{}), dal.Where("pipeline_id IN ?", pipelineIds))
+	if err != nil {
+		return err
+	}
+	subtasks := int64(0)
+	if len(taskIds) > 0 {
+		subtasks, err = db.Count(dal.From(&models.Subtask{}), dal.Where("task_id IN ?", taskIds))
+		if err != nil {
+			return err
+		}
+	}
+	if !report.DryRun {
+		tx := db.Begin()
+		defer func() {
+			if r := recover(); r != nil || err != nil {
+				if e := tx.Rollback(); e != nil {
+					retentionLog.Error(e, "failed to rollback")
+				}
+			}
+		}()
+		if len(taskIds) > 0 {
+			err = tx.Delete(&models.Subtask{}, dal.Where("task_id IN ?", taskIds))
+			if err != nil {
+				return err
+			}
+			err = tx.Delete(&models.Task{}, dal.Where("id IN ?", taskIds))
+			if err != nil {
+				return err
+			}
+		}
+		err = tx.Delete(&models.DbPipelineLabel{}, dal.Where("pipeline_id IN ?", pipelineIds))
+		if err != nil {
+			return err
+		}
+		err = tx.Delete(&models.Pipeline{}, dal.Where("id IN ?", pipelineIds))
+		if err != nil {
+			return err
+		}
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+	}
+	report.Pipelines += int64(len(pipelines))
+	report.Tasks += int64(len(taskIds))
+	report.Subtasks += subtasks
+	// log files are removed after the records, a failure leaves some files behind rather than dangling records
+	for _, pipeline := range pipelines {
+		files, bytes := prunePipelineLogs(pipeline, report.DryRun)
+		report.LogFiles += files
+		report.LogBytes += bytes
+	}
+	return nil
+}
+
+// prunePipelineLogs removes the log directory of the pipeline, returns the number of files and bytes in it
+func prunePipelineLogs(pipeline *models.Pipeline, dryRun bool) (files int64, bytes int64) {
+	loggerConfig := globalPipelineLog.GetConfig()
+	if loggerConfig.Path == "" {
+		return 0, 0
+	}
+	if _, err := os.Stat(loggerConfig.Path); err != nil {
+		return 0, 0
+	}
+	dir := filepath.Dir(logruslog.GetPipelineLoggerPath(loggerConfig, pipeline))
+	files, bytes = dirUsage(dir)
+	if files > 0 && !dryRun {
+		if err := os.RemoveAll(dir); err != nil {
+			retentionLog.Error(err, "failed to remove logs of pipeline #%d", pipeline.ID)
+			return 0, 0
+		}
+	}
+	return files, bytes
+}
+
+func dirUsage(dir string) (files int64, bytes int64) {
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, e := d.Info(); e == nil {
+			files++
+			bytes += info.Size()
+		}
+		return nil
+	})
+	return files, bytes
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectPrunablePipelines(t *testing.T) {
+	now := time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+	pipelines := []*retentionPipeline{
+		{ID: 9, BlueprintId: 1, Status: models.TASK_RUNNING, CreatedAt: daysAgo(0)},
+		{ID: 8, BlueprintId: 2, Status: models.TASK_COMPLETED, CreatedAt: daysAgo(40)},
+		{ID: 7, BlueprintId: 1, Status: models.TASK_FAILED, CreatedAt: daysAgo(1)},
+		{ID: 6, BlueprintId: 1, Status: models.TASK_COMPLETED, CreatedAt: daysAgo(2)},
+		{ID: 5, BlueprintId: 0, Status: models.TASK_COMPLETED, CreatedAt: daysAgo(3)},
+		{ID: 4, BlueprintId: 2, Status: models.TASK_COMPLETED, CreatedAt: daysAgo(50)},
+		{ID: 3, BlueprintId: 0, Status: models.TASK_COMPLETED, CreatedAt: daysAgo(60)},
+		{ID: 2, BlueprintId: 1, Status: models.TASK_CREATED, CreatedAt: daysAgo(70)},
+	}
+
+	// the running #9 counts as the last pipeline of blueprint 1, the pending #2 is kept anyway
+	assert.Equal(t, []uint64{6}, selectPrunablePipelines(pipelines, &RetentionPolicy{PipelinesPerBlueprint: 2}, now))
+	// #8 is the last pipeline of blueprint 2
+	assert.Equal(t, []uint64{4, 3}, selectPrunablePipelines(pipelines, &RetentionPolicy{PipelineDays: 30}, now))
+	assert.Equal(t, []uint64{7, 6, 4, 3}, selectPrunablePipelines(pipelines, &RetentionPolicy{PipelinesPerBlueprint: 1, PipelineDays: 30}, now))
+	assert.Empty(t, selectPrunablePipelines(pipelines, &RetentionPolicy{}, now))
+}
+
+func TestDirUsage(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "pipeline.log"), []byte("12345"), 0600))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "task-1-1-1-github.log"), []byte("123"), 0600))
+	files, bytes := dirUsage(dir)
+	assert.Equal(t, int64(2), files)
+	assert.Equal(t, int64(8), bytes)
+
+	files, bytes = dirUsage(filepath.Join(dir, "missing"))
+	assert.Zero(t, files)
+	assert.Zero(t, bytes)
+}
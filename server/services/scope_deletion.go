@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// ScopeDeletionQuery used to filter scope deletions as the api input
+type ScopeDeletionQuery struct {
+	Pagination
+	Plugin       string `form:"plugin"`
+	ConnectionId uint64 `form:"connectionId"`
+	ScopeId      string `form:"scopeId"`
+	Status       string `form:"status"`
+}
+
+// GetScopeDeletions returns a paginated list of scope deletions based on `query`, newest first
+func GetScopeDeletions(query *ScopeDeletionQuery) ([]*models.ScopeDeletion, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.ScopeDeletion{}),
+	}
+	if query.Plugin != "" {
+		clauses = append(clauses, dal.Where("plugin = ?", query.Plugin))
+	}
+	if query.ConnectionId != 0 {
+		clauses = append(clauses, dal.Where("connection_id = ?", query.ConnectionId))
+	}
+	if query.ScopeId != "" {
+		clauses = append(clauses, dal.Where("scope_id = ?", query.ScopeId))
+	}
+	if query.Status != "" {
+		clauses = append(clauses, dal.Where("status = ?", query.Status))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of scope deletions")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	deletions := make([]*models.ScopeDeletion, 0)
+	err = db.All(&deletions, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB scope deletions")
+	}
+	return deletions, count, nil
+}
+
+// GetScopeDeletion returns the scope deletion with the id
+func GetScopeDeletion(deletionId uint64) (*models.ScopeDeletion, errors.Error) {
+	deletion := &models.ScopeDeletion{}
+	err := db.First(deletion, dal.Where("id = ?", deletionId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("scope deletion #%d not found", deletionId))
+		}
+		return nil, errors.Default.Wrap(err, "error getting scope deletion")
+	}
+	return deletion, nil
+}
+
+// failInterruptedScopeDeletions marks the scope deletions interrupted by the last shutdown as failed,
+// deleting the scope again resumes from where they stopped since deleted rows are gone already
+func failInterruptedScopeDeletions() errors.Error {
+	return db.UpdateColumns(
+		&models.ScopeDeletion{},
+		[]dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_FAILED},
+			{ColumnName: "message", Value: "The process was terminated unexpectedly"},
+		},
+		dal.Where("status IN ?", []string{models.TASK_CREATED, models.TASK_RUNNING}),
+	)
+}
@@ -62,6 +62,7 @@ func CreateTask(newTask *models.NewTask) (*models.Task, errors.Error) {
 		PipelineId:  newTask.PipelineId,
 		PipelineRow: newTask.PipelineRow,
 		PipelineCol: newTask.PipelineCol,
+		ResumeFrom:  newTask.ResumeFrom,
 	}
 	if newTask.IsRerun {
 		task.Status = models.TASK_RERUN
@@ -173,8 +174,8 @@ func CancelTask(taskId uint64) errors.Error {
 	return nil
 }
 
-// RunTasksStandalone run tasks in parallel
-func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error {
+// RunTasksStandalone run tasks in parallel, tasks would be cancelled along with the ctx
+func RunTasksStandalone(ctx context.Context, parentLogger log.Logger, taskIds []uint64) errors.Error {
 	if len(taskIds) == 0 {
 		return nil
 	}
@@ -183,7 +184,7 @@ func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error
 		go func(id uint64) {
 			taskLog.Info("run task #%d in background ", id)
 			var err errors.Error
-			taskErr := runTaskStandalone(parentLogger, id)
+			taskErr := runTaskStandalone(ctx, parentLogger, id)
 			if taskErr != nil {
 				err = errors.Default.Wrap(taskErr, fmt.Sprintf("Error running task %d.", id))
 			}
@@ -218,13 +219,13 @@ func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error
 	return errors.Convert(err)
 }
 
-// RerunTask reruns specified task
-func RerunTask(taskId uint64) (*models.Task, errors.Error) {
+// RerunTask reruns specified task, it would start from the failed subtask if mode was RERUN_MODE_RESUME
+func RerunTask(taskId uint64, mode string) (*models.Task, errors.Error) {
 	task, err := GetTask(taskId)
 	if err != nil {
 		return nil, err
 	}
-	rerunTasks, err := RerunPipeline(task.PipelineId, task)
+	rerunTasks, err := RerunPipeline(task.PipelineId, task, mode)
 	if err != nil {
 		return nil, err
 	}
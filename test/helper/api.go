@@ -18,6 +18,7 @@ limitations under the License.
 package helper
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/services"
 	"net/http"
@@ -228,12 +229,37 @@ func (d *DevlakeClient) GetScope(pluginName string, connectionId uint64, scopeId
 	return getScopeResponse(scopeRaw)
 }
 
+// DeleteScope deletes the scope and waits for the scope deletion to finish, LastReturnedStatusCode is then the one
+// of the last GET /scope-deletions/:deletionId. The references are returned instead if the scope was referred by blueprints
 func (d *DevlakeClient) DeleteScope(pluginName string, connectionId uint64, scopeId string, deleteDataOnly bool) services.BlueprintProjectPairs {
-	return sendHttpRequest[services.BlueprintProjectPairs](d.testCtx, d.timeout, &testContext{
+	body := sendHttpRequest[json.RawMessage](d.testCtx, d.timeout, &testContext{
 		client:       d,
 		printPayload: true,
 		inlineJson:   false,
 	}, http.MethodDelete, fmt.Sprintf("%s/plugins/%s/connections/%d/scopes/%s?delete_data_only=%v", d.Endpoint, pluginName, connectionId, scopeId, deleteDataOnly), nil, nil)
+	refs := services.BlueprintProjectPairs{}
+	if d.LastReturnedStatusCode() != http.StatusAccepted {
+		_ = json.Unmarshal(body, &refs)
+		return refs
+	}
+	deletion := &models.ScopeDeletion{}
+	require.NoError(d.testCtx, json.Unmarshal(body, deletion))
+	err := runWithTimeout(d.timeout, func() (bool, errors.Error) {
+		deletion = d.GetScopeDeletion(deletion.ID)
+		return deletion.Status == models.TASK_COMPLETED || deletion.Status == models.TASK_FAILED, nil
+	})
+	require.NoError(d.testCtx, err)
+	require.Equal(d.testCtx, models.TASK_COMPLETED, deletion.Status, deletion.Message)
+	return refs
+}
+
+// GetScopeDeletion returns the scope deletion
+func (d *DevlakeClient) GetScopeDeletion(deletionId uint64) *models.ScopeDeletion {
+	return sendHttpRequest[*models.ScopeDeletion](d.testCtx, d.timeout, &testContext{
+		client:       d,
+		printPayload: false,
+		inlineJson:   false,
+	}, http.MethodGet, fmt.Sprintf("%s/scope-deletions/%d", d.Endpoint, deletionId), nil, nil)
 }
 
 func (d *DevlakeClient) CreateScopeConfig(pluginName string, connectionId uint64, scopeConfig any) any {